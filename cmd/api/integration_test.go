@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/Ali-Gorgani/task-manager/internal/cache"
 	"github.com/Ali-Gorgani/task-manager/internal/models"
@@ -375,9 +376,9 @@ func TestIntegration_Filtering(t *testing.T) {
 		// Filter by user1
 		assignee := "user1@example.com"
 		filter := &models.TaskFilter{
-			Assignee: &assignee,
-			Page:     1,
-			PageSize: 10,
+			Assignees: []string{assignee},
+			Page:      1,
+			PageSize:  10,
 		}
 		result, err := taskService.ListTasks(ctx, filter)
 		require.NoError(t, err)
@@ -407,10 +408,10 @@ func TestIntegration_Filtering(t *testing.T) {
 		status := models.TaskStatusInProgress
 		assignee := "combined@example.com"
 		filter := &models.TaskFilter{
-			Status:   &status,
-			Assignee: &assignee,
-			Page:     1,
-			PageSize: 10,
+			Status:    &status,
+			Assignees: []string{assignee},
+			Page:      1,
+			PageSize:  10,
 		}
 		result, err := taskService.ListTasks(ctx, filter)
 		require.NoError(t, err)
@@ -481,3 +482,30 @@ func TestIntegration_ErrorHandling(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestIntegration_TrendNonUTCSession(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, repo := setupTestDB(t)
+	defer db.Close()
+
+	// Pin the pool to one connection so the session time zone applies to
+	// every query below
+	db.SetMaxOpenConns(1)
+	_, err := db.Exec("SET TIME ZONE 'America/New_York'")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	day := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	task := models.NewTask("Trend Task", "Created early in the UTC day", "trend@example.com", models.TaskStatusPending)
+	task.CreatedAt = day.Add(2 * time.Hour)
+	task.UpdatedAt = task.CreatedAt
+	require.NoError(t, repo.Create(ctx, task))
+
+	buckets, err := repo.Trend(ctx, "day", day, day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Equal(t, []models.TrendBucket{{Start: day, Created: 1}}, buckets)
+}
@@ -13,13 +13,16 @@ import (
 	"github.com/Ali-Gorgani/task-manager/internal/cache"
 	"github.com/Ali-Gorgani/task-manager/internal/config"
 	"github.com/Ali-Gorgani/task-manager/internal/handlers"
+	"github.com/Ali-Gorgani/task-manager/internal/health"
+	"github.com/Ali-Gorgani/task-manager/internal/lifecycle"
 	"github.com/Ali-Gorgani/task-manager/internal/metrics"
+	"github.com/Ali-Gorgani/task-manager/internal/middleware"
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/Ali-Gorgani/task-manager/internal/notify"
 	"github.com/Ali-Gorgani/task-manager/internal/repository"
 	"github.com/Ali-Gorgani/task-manager/internal/service"
 	"github.com/gin-gonic/gin"
 	_ "github.com/lib/pq"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"github.com/redis/go-redis/v9"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
@@ -45,6 +48,7 @@ import (
 func main() {
 	// Load configuration
 	cfg := config.LoadConfig()
+	log.Printf("Effective configuration: %s", cfg.Redacted())
 
 	// Set Gin mode
 	if !cfg.IsDevelopment() {
@@ -65,19 +69,66 @@ func main() {
 	log.Println("Successfully connected to PostgreSQL database")
 
 	// Initialize schema
-	taskRepo := repository.NewPostgresTaskRepository(db)
+	if err := repository.ValidateSearchFields(cfg.SearchFields); err != nil {
+		log.Fatalf("Invalid SEARCH_FIELDS: %v", err)
+	}
+	sortCollation := cfg.SortCollation
+	if sortCollation != "" {
+		exists, err := repository.CollationExists(context.Background(), db, sortCollation)
+		switch {
+		case err != nil:
+			log.Fatalf("Failed to check SORT_COLLATION: %v", err)
+		case !exists:
+			log.Printf("Warning: collation %q does not exist; sorting titles with the database default", sortCollation)
+			sortCollation = ""
+		default:
+			log.Printf("Sorting titles with collation %q", sortCollation)
+		}
+	}
+	taskRepo := repository.NewPostgresTaskRepository(db,
+		repository.WithSearchFields(cfg.SearchFields),
+		repository.WithSlowQueryThreshold(cfg.SlowQueryThreshold),
+		repository.WithSortCollation(sortCollation),
+	)
 	if err := taskRepo.InitSchema(context.Background()); err != nil {
 		log.Fatalf("Failed to initialize database schema: %v", err)
 	}
+	templateRepo := repository.NewPostgresTemplateRepository(db,
+		repository.WithTemplateSlowQueryThreshold(cfg.SlowQueryThreshold),
+	)
+	if err := templateRepo.InitSchema(context.Background()); err != nil {
+		log.Fatalf("Failed to initialize template schema: %v", err)
+	}
 	log.Println("Database schema initialized successfully")
 
+	if cfg.UniqueTitlePerAssignee {
+		applied, err := taskRepo.EnsureUniqueTitlePerAssignee(context.Background())
+		switch {
+		case err != nil:
+			log.Fatalf("Failed to enforce unique titles per assignee: %v", err)
+		case !applied:
+			log.Println("Warning: duplicate titles per assignee exist; unique title constraint not created")
+		default:
+			log.Println("Unique titles per assignee enforced")
+		}
+	} else if err := taskRepo.DropUniqueTitlePerAssignee(context.Background()); err != nil {
+		log.Fatalf("Failed to drop unique title constraint: %v", err)
+	}
+
 	// Initialize Redis cache
 	var redisCache *cache.RedisCache
-	redisClient := redis.NewClient(&redis.Options{
-		Addr:     cfg.RedisURL,
-		Password: cfg.RedisPassword,
-		DB:       cfg.RedisDB,
+	redisClient, err := cache.NewClient(cache.ClientOptions{
+		Mode:          cfg.RedisMode,
+		Addr:          cfg.RedisURL,
+		Password:      cfg.RedisPassword,
+		DB:            cfg.RedisDB,
+		MasterName:    cfg.RedisMasterName,
+		SentinelAddrs: cfg.RedisSentinelAddrs,
+		ClusterAddrs:  cfg.RedisClusterAddrs,
 	})
+	if err != nil {
+		log.Fatalf("Invalid Redis configuration: %v", err)
+	}
 
 	// Test Redis connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -87,13 +138,116 @@ func main() {
 		log.Printf("Warning: Redis connection failed: %v. Running without cache.", err)
 		redisCache = nil
 	} else {
-		redisCache = cache.NewRedisCache(redisClient)
+		redisCache = cache.NewRedisCache(redisClient,
+			cache.WithListAccessTracking(cfg.ListCacheCompactEvery > 0),
+			cache.WithCompression(cfg.CacheCompressMinBytes),
+			cache.WithWriteRetry(cfg.CacheWriteRetryQueue, cfg.CacheWriteRetryMaxAttempts, cfg.CacheWriteRetryBackoff),
+		)
 		log.Println("Successfully connected to Redis")
 	}
 
 	// Initialize service and handler
-	taskService := service.NewTaskService(taskRepo, redisCache)
-	taskHandler := handlers.NewTaskHandler(taskService)
+	if err := service.ValidateRequiredFields(cfg.RequiredFields); err != nil {
+		log.Fatalf("Invalid REQUIRED_FIELDS: %v", err)
+	}
+	if err := service.ValidateDefaultSort(cfg.DefaultSortBy, cfg.DefaultSortOrder); err != nil {
+		log.Fatalf("Invalid DEFAULT_SORT_BY/DEFAULT_SORT_ORDER: %v", err)
+	}
+	statusAssignees, err := service.ParseStatusAssignees(cfg.StatusAssignees)
+	if err != nil {
+		log.Fatalf("Invalid STATUS_ASSIGNEES: %v", err)
+	}
+	serviceOpts := []service.Option{
+		service.WithCountCacheTTL(cfg.CountCacheTTL),
+		service.WithStrictPageSize(cfg.StrictPageSize),
+		service.WithMaxOffset(cfg.MaxOffset),
+		service.WithFullListCaching(cfg.FullListCacheMaxRows),
+		service.WithRequiredFields(cfg.RequiredFields),
+		service.WithImportClockSkew(cfg.ImportMaxClockSkew),
+		service.WithDefaultSort(cfg.DefaultSortBy, cfg.DefaultSortOrder),
+		service.WithStatusAssignees(statusAssignees),
+		service.WithPageTokenSecret(cfg.PageTokenSecret),
+	}
+	var webhooks *notify.Dispatcher
+	if cfg.AssigneeWebhookURL != "" {
+		var webhookOpts []notify.WebhookOption
+		if cfg.WebhookSecret != "" {
+			webhookOpts = append(webhookOpts, notify.WithSigningSecret(cfg.WebhookSecret))
+		}
+		webhooks = notify.NewDispatcher(
+			notify.NewWebhookNotifier(cfg.AssigneeWebhookURL, cfg.WebhookTimeout, webhookOpts...),
+			notify.WithRetries(cfg.WebhookMaxAttempts, cfg.WebhookRetryBackoff),
+			notify.WithQueueSize(cfg.WebhookQueueSize),
+		)
+		serviceOpts = append(serviceOpts, service.WithAssigneeNotifier(webhooks))
+		log.Println("Assignee change notifications enabled")
+	}
+	if cfg.SanitizeInput {
+		serviceOpts = append(serviceOpts, service.WithSanitization(service.HTMLPolicy(cfg.SanitizeHTML)))
+		log.Printf("Task text sanitization enabled (HTML: %s)", cfg.SanitizeHTML)
+	}
+	taskService := service.NewTaskService(taskRepo, redisCache, serviceOpts...)
+	templateService := service.NewTemplateService(templateRepo, taskService)
+
+	// Background workers share a context that is cancelled on shutdown
+	workers := lifecycle.NewGroup(context.Background())
+
+	if webhooks != nil {
+		workers.Go(webhooks.Run)
+	}
+
+	// Retry failed cache writes (CACHE_WRITE_RETRY_QUEUE=0 disables it)
+	if redisCache != nil && cfg.CacheWriteRetryQueue > 0 {
+		workers.Go(redisCache.RunWriteRetries)
+		log.Printf("Failed cache writes are retried, up to %d attempts in total", cfg.CacheWriteRetryMaxAttempts)
+	}
+
+	// Warm the cache in the background so startup is not delayed
+	if cfg.CacheWarmOnStart && redisCache != nil {
+		workers.Go(func(ctx context.Context) {
+			warmCtx, warmCancel := context.WithTimeout(ctx, 30*time.Second)
+			defer warmCancel()
+
+			warmed, err := taskService.WarmCache(warmCtx)
+			if err != nil {
+				log.Printf("Warning: cache warming failed: %v", err)
+				return
+			}
+			log.Printf("Cache warmed with %d tasks", warmed)
+		})
+	}
+
+	handlerOpts := []handlers.Option{
+		handlers.WithEnvelopeResponse(cfg.UseEnvelopeResponse()),
+		handlers.WithLinksOnlyResponse(cfg.UseLinksOnlyResponse()),
+		handlers.WithCamelCaseFields(cfg.UseCamelCaseFields()),
+		handlers.WithStrictJSON(cfg.StrictJSON),
+		handlers.WithTaskIDValidation(cfg.ValidateTaskIDs),
+		handlers.WithPrettyJSON(cfg.PrettyJSONAllowed()),
+		handlers.WithServiceVersion(cfg.ServiceVersion),
+	}
+
+	maintenance := middleware.NewMaintenance(cfg.MaintenanceMode, cfg.MaintenanceRetryAfter)
+	handlerOpts = append(handlerOpts, handlers.WithMaintenance(maintenance))
+	if cfg.MaintenanceMode {
+		log.Println("Starting in maintenance mode: writes are rejected")
+	}
+
+	// Check dependencies in the background so readiness probes never wait on
+	// a ping (HEALTH_CHECK_INTERVAL=0 pings on every probe instead)
+	if cfg.HealthCheckInterval > 0 {
+		if cfg.HealthCheckTimeout <= 0 {
+			log.Fatalf("Invalid HEALTH_CHECK_TIMEOUT %s: must be positive", cfg.HealthCheckTimeout)
+		}
+		healthMonitor := health.NewMonitor(taskService.CheckDependencies, cfg.HealthCheckTimeout)
+		workers.Go(func(ctx context.Context) {
+			healthMonitor.Run(ctx, cfg.HealthCheckInterval)
+		})
+		handlerOpts = append(handlerOpts, handlers.WithHealthMonitor(healthMonitor))
+	}
+
+	taskHandler := handlers.NewTaskHandler(taskService, handlerOpts...)
+	templateHandler := handlers.NewTemplateHandler(templateService, taskHandler)
 
 	// Setup router
 	router := gin.Default()
@@ -101,11 +255,34 @@ func main() {
 	// Add Prometheus middleware
 	router.Use(metrics.PrometheusMiddleware())
 
+	// Cap in-flight requests; probes and scrapes must get through when saturated
+	if cfg.MaxConcurrentRequests > 0 {
+		router.Use(middleware.ConcurrencyLimit(cfg.MaxConcurrentRequests, time.Second, "/health", "/metrics"))
+		log.Printf("Concurrent requests limited to %d", cfg.MaxConcurrentRequests)
+	}
+
+	// Bound request bodies before anything buffers or decodes them
+	if cfg.MaxRequestBodyBytes <= 0 {
+		log.Fatalf("Invalid MAX_REQUEST_BODY_BYTES %d: must be positive", cfg.MaxRequestBodyBytes)
+	}
+	router.Use(middleware.BodyLimit(cfg.MaxRequestBodyBytes))
+
+	// Reject writes in maintenance mode, except the request turning it off and
+	// validation, which stores nothing
+	router.Use(maintenance.Middleware("/api/v1/admin/maintenance", "/api/v1/tasks/validate"))
+
+	// Log request/response bodies in development or when DEBUG_HTTP is set
+	if cfg.DebugHTTPEnabled() {
+		router.Use(middleware.DebugBodyLogger(cfg.DebugRedactFields))
+		log.Println("HTTP body debug logging enabled")
+	}
+
 	// Health check
 	router.GET("/health", taskHandler.HealthCheck)
+	router.GET("/health/ready", taskHandler.ReadinessCheck)
 
 	// Prometheus metrics endpoint
-	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
 
 	// Swagger documentation
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -116,24 +293,79 @@ func main() {
 		tasks := v1.Group("/tasks")
 		{
 			tasks.POST("", taskHandler.CreateTask)
+			tasks.POST("/import", taskHandler.ImportTasks)
+			tasks.POST("/validate", taskHandler.ValidateTask)
 			tasks.GET("", taskHandler.ListTasks)
+			tasks.GET("/changes", taskHandler.ListTaskChanges)
+			tasks.GET("/recent", taskHandler.ListRecentTasks)
+			tasks.GET("/board", taskHandler.GetBoard)
+			tasks.GET("/stats/completions", taskHandler.GetCompletionStats)
+			tasks.GET("/stats/trend", taskHandler.GetTrend)
 			tasks.GET("/:id", taskHandler.GetTask)
+			tasks.HEAD("/:id", taskHandler.HeadTask)
 			tasks.PUT("/:id", taskHandler.UpdateTask)
+			tasks.PUT("/external/:source/:external_id", taskHandler.UpsertTaskByExternalID)
+			tasks.POST("/:id/assign", taskHandler.AssignTask)
+			tasks.POST("/reassign", taskHandler.ReassignTasks)
 			tasks.DELETE("/:id", taskHandler.DeleteTask)
 		}
+
+		admin := v1.Group("/admin")
+		{
+			admin.POST("/tasks/purge", taskHandler.PurgeCompletedTasks)
+			admin.GET("/maintenance", taskHandler.GetMaintenance)
+			admin.PUT("/maintenance", taskHandler.SetMaintenance)
+		}
+
+		templates := v1.Group("/templates")
+		{
+			templates.POST("", templateHandler.CreateTemplate)
+			templates.GET("", templateHandler.ListTemplates)
+			templates.GET("/:id", templateHandler.GetTemplate)
+			templates.PUT("/:id", templateHandler.UpdateTemplate)
+			templates.DELETE("/:id", templateHandler.DeleteTemplate)
+			templates.POST("/:id/instantiate", templateHandler.InstantiateTemplate)
+		}
+
+		meta := v1.Group("/meta")
+		{
+			meta.GET("/statuses", taskHandler.ListStatuses)
+		}
 	}
 
-	// Start periodic task count update for metrics
-	go func() {
-		ticker := time.NewTicker(30 * time.Second)
-		defer ticker.Stop()
-		for range ticker.C {
-			count, err := taskService.GetTaskCount(context.Background())
-			if err == nil {
-				metrics.UpdateTasksCount(count)
-			}
+	// Route listing for onboarding; never exposed outside development
+	if cfg.IsDevelopment() {
+		router.GET("/debug/routes", handlers.ListRoutes(router))
+	}
+
+	// Start periodic task count update for metrics (METRICS_COUNT_INTERVAL=0 disables it)
+	if cfg.MetricsCountInterval > 0 {
+		workers.Go(func(ctx context.Context) {
+			runTaskCountUpdater(ctx, taskService, cfg.MetricsCountInterval)
+		})
+	} else {
+		log.Println("Periodic task count updater disabled")
+	}
+
+	// Move tasks stuck in one status to another (STATUS_EXPIRY_INTERVAL=0 disables it)
+	if cfg.StatusExpiryInterval > 0 {
+		from, to := models.NormalizeStatus(cfg.StatusExpiryFrom), models.NormalizeStatus(cfg.StatusExpiryTo)
+		if !models.IsValidStatus(from) || !models.IsValidStatus(to) || from == to || cfg.StatusExpiryAge <= 0 {
+			log.Fatalf("Invalid status expiry: STATUS_EXPIRY_FROM=%q STATUS_EXPIRY_TO=%q STATUS_EXPIRY_AGE=%s",
+				cfg.StatusExpiryFrom, cfg.StatusExpiryTo, cfg.StatusExpiryAge)
 		}
-	}()
+		workers.Go(func(ctx context.Context) {
+			runStatusExpiry(ctx, taskService, from, to, cfg.StatusExpiryAge, cfg.StatusExpiryInterval)
+		})
+		log.Printf("Status expiry enabled: %s tasks idle for %s become %s", from, cfg.StatusExpiryAge, to)
+	}
+
+	// Cap the number of cached list pages (LIST_CACHE_COMPACT_INTERVAL=0 disables it)
+	if redisCache != nil && cfg.ListCacheCompactEvery > 0 {
+		workers.Go(func(ctx context.Context) {
+			runListCacheCompactor(ctx, redisCache, cfg.ListCacheMaxKeys, cfg.ListCacheCompactEvery)
+		})
+	}
 
 	// Setup HTTP server
 	srv := &http.Server{
@@ -162,8 +394,98 @@ func main() {
 	defer cancel()
 
 	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		log.Printf("Server forced to shutdown: %v", err)
+	}
+
+	// Stop background workers and wait for them to drain
+	drainCtx, drainCancel := context.WithTimeout(context.Background(), cfg.ShutdownDrainTimeout)
+	defer drainCancel()
+
+	if err := workers.Shutdown(drainCtx); err != nil {
+		log.Printf("Background workers did not stop in time: %v", err)
+	}
+
+	// Finish the async work the stopped workers left queued, so a redeploy
+	// does not silently lose notifications or cache writes
+	flushStart := time.Now()
+	flushCtx, flushCancel := context.WithTimeout(context.Background(), cfg.ShutdownFlushTimeout)
+	defer flushCancel()
+
+	if webhooks != nil {
+		if err := taskService.WaitForNotifications(flushCtx); err != nil {
+			log.Printf("Assignee change notifications still pending: %v", err)
+		}
+		if delivered, dropped := webhooks.Flush(flushCtx); delivered+dropped > 0 {
+			log.Printf("Flushed webhook events on shutdown: %d delivered, %d dropped", delivered, dropped)
+		}
+	}
+	if redisCache != nil {
+		if written, dropped := redisCache.FlushWriteRetries(flushCtx); written+dropped > 0 {
+			log.Printf("Flushed cache write retries on shutdown: %d written, %d dropped", written, dropped)
+		}
 	}
+	log.Printf("Shutdown flush finished in %s", time.Since(flushStart).Round(time.Millisecond))
 
 	log.Println("Server exited successfully")
 }
+
+// runTaskCountUpdater refreshes the task count and age gauges on every tick until ctx is cancelled
+func runTaskCountUpdater(ctx context.Context, taskService *service.TaskService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			count, err := taskService.GetTaskCount(ctx)
+			if err == nil {
+				metrics.UpdateTasksCount(count)
+			}
+			ages, err := taskService.GetOldestOpenTaskAges(ctx)
+			if err == nil {
+				metrics.UpdateOldestTaskAges(ages)
+			}
+		}
+	}
+}
+
+// runListCacheCompactor evicts least recently used list cache entries beyond
+// maxKeys on every tick until ctx is cancelled
+func runListCacheCompactor(ctx context.Context, redisCache *cache.RedisCache, maxKeys int, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			evicted, err := redisCache.CompactTaskLists(ctx, maxKeys)
+			if err != nil {
+				log.Printf("Warning: list cache compaction failed: %v", err)
+			} else if evicted > 0 {
+				log.Printf("Evicted %d list cache entries", evicted)
+			}
+		}
+	}
+}
+
+// runStatusExpiry moves tasks idle in status from for longer than maxAge to
+// status to on every tick until ctx is cancelled
+func runStatusExpiry(ctx context.Context, taskService *service.TaskService, from, to models.TaskStatus, maxAge, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := taskService.ExpireStaleTasks(ctx, from, to, maxAge)
+			if err != nil {
+				log.Printf("Warning: status expiry failed: %v", err)
+			} else if expired > 0 {
+				log.Printf("Moved %d stale %s tasks to %s", expired, from, to)
+			}
+		}
+	}
+}
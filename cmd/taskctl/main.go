@@ -0,0 +1,276 @@
+// Command taskctl runs one-off task queries against the database configured
+// for the API, without going through the HTTP layer.
+//
+// Usage:
+//
+//	taskctl list [-status s] [-assignee a] [-page n] [-page-size n]
+//	taskctl get <id>
+//	taskctl create -title t [-description d] [-assignee a] [-status s]
+//	taskctl delete <id>
+//
+// Commands go through the same task service as the API, so creates are
+// validated and sanitized the same way and writes invalidate the Redis cache.
+// When Redis is unreachable the commands still run, and the API may serve
+// stale cached entries until they expire.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/Ali-Gorgani/task-manager/internal/cache"
+	"github.com/Ali-Gorgani/task-manager/internal/config"
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/Ali-Gorgani/task-manager/internal/repository"
+	"github.com/Ali-Gorgani/task-manager/internal/service"
+	_ "github.com/lib/pq"
+)
+
+const (
+	commandTimeout = 30 * time.Second
+	maxPageSize    = 100
+)
+
+const usage = `Usage: taskctl <command> [flags]
+
+Commands:
+  list     List tasks (-status, -assignee, -page, -page-size)
+  get      Print one task: taskctl get <id>
+  create   Create a task (-title, -description, -assignee, -status)
+  delete   Delete a task: taskctl delete <id>
+`
+
+// errHelp is returned by lookupCommand when usage was asked for
+var errHelp = errors.New("help requested")
+
+// command runs one subcommand against svc, writing its output to out
+type command func(ctx context.Context, svc *service.TaskService, out io.Writer, args []string) error
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err := run(os.Args[1], os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "taskctl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run executes a single command against a freshly built task service
+func run(name string, args []string) error {
+	exec, err := lookupCommand(name)
+	if errors.Is(err, errHelp) {
+		fmt.Print(usage)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	cfg := config.LoadConfig()
+	db, err := sql.Open("postgres", cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	svc, err := newService(ctx, cfg, db)
+	if err != nil {
+		return err
+	}
+
+	return exec(ctx, svc, os.Stdout, args)
+}
+
+// lookupCommand returns the subcommand called name
+func lookupCommand(name string) (command, error) {
+	switch name {
+	case "list":
+		return listTasks, nil
+	case "get":
+		return getTask, nil
+	case "create":
+		return createTask, nil
+	case "delete":
+		return deleteTask, nil
+	case "help", "-h", "-help", "--help":
+		return nil, errHelp
+	default:
+		return nil, fmt.Errorf("unknown command %q\n\n%s", name, usage)
+	}
+}
+
+// newService builds the task service the API would, with the cache when
+// Redis is reachable
+func newService(ctx context.Context, cfg *config.Config, db *sql.DB) (*service.TaskService, error) {
+	repo := repository.NewPostgresTaskRepository(db,
+		repository.WithSearchFields(cfg.SearchFields),
+		repository.WithSlowQueryThreshold(cfg.SlowQueryThreshold),
+	)
+	if err := repo.Ping(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := service.ValidateRequiredFields(cfg.RequiredFields); err != nil {
+		return nil, fmt.Errorf("invalid REQUIRED_FIELDS: %w", err)
+	}
+	opts := []service.Option{
+		service.WithRequiredFields(cfg.RequiredFields),
+		service.WithCountCacheTTL(cfg.CountCacheTTL),
+	}
+	if cfg.SanitizeInput {
+		opts = append(opts, service.WithSanitization(service.HTMLPolicy(cfg.SanitizeHTML)))
+	}
+
+	return service.NewTaskService(repo, connectCache(ctx, cfg), opts...), nil
+}
+
+// connectCache returns the Redis cache, or nil with a warning when Redis is
+// misconfigured or unreachable
+func connectCache(ctx context.Context, cfg *config.Config) *cache.RedisCache {
+	client, err := cache.NewClient(cache.ClientOptions{
+		Mode:          cfg.RedisMode,
+		Addr:          cfg.RedisURL,
+		Password:      cfg.RedisPassword,
+		DB:            cfg.RedisDB,
+		MasterName:    cfg.RedisMasterName,
+		SentinelAddrs: cfg.RedisSentinelAddrs,
+		ClusterAddrs:  cfg.RedisClusterAddrs,
+	})
+	if err == nil {
+		err = client.Ping(ctx).Err()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "taskctl: warning: running without cache: %v\n", err)
+		return nil
+	}
+	return cache.NewRedisCache(client)
+}
+
+func listTasks(ctx context.Context, svc *service.TaskService, out io.Writer, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	fs.SetOutput(out)
+	status := fs.String("status", "", "filter by status")
+	assignee := fs.String("assignee", "", "filter by assignee email")
+	page := fs.Int("page", 1, "page number")
+	pageSize := fs.Int("page-size", 10, fmt.Sprintf("page size (max %d)", maxPageSize))
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *page < 1 || *pageSize < 1 || *pageSize > maxPageSize {
+		return fmt.Errorf("page must be at least 1 and page-size between 1 and %d", maxPageSize)
+	}
+
+	filter := &models.TaskFilter{
+		Page:     *page,
+		PageSize: *pageSize,
+	}
+	if *status != "" {
+		s, err := parseStatus(*status)
+		if err != nil {
+			return err
+		}
+		filter.Status = &s
+	}
+	if *assignee != "" {
+		filter.Assignees = []string{*assignee}
+	}
+
+	response, err := svc.ListTasks(ctx, filter)
+	if err != nil {
+		return err
+	}
+	return printJSON(out, response)
+}
+
+func getTask(ctx context.Context, svc *service.TaskService, out io.Writer, args []string) error {
+	id, err := singleID("get", args)
+	if err != nil {
+		return err
+	}
+
+	task, err := svc.GetTask(ctx, id)
+	if err != nil {
+		return err
+	}
+	return printJSON(out, task)
+}
+
+func createTask(ctx context.Context, svc *service.TaskService, out io.Writer, args []string) error {
+	fs := flag.NewFlagSet("create", flag.ContinueOnError)
+	fs.SetOutput(out)
+	title := fs.String("title", "", "task title (required)")
+	description := fs.String("description", "", "task description")
+	assignee := fs.String("assignee", "", "assignee email")
+	status := fs.String("status", string(models.TaskStatusPending), "initial status")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *title == "" {
+		return errors.New("-title is required")
+	}
+
+	s, err := parseStatus(*status)
+	if err != nil {
+		return err
+	}
+
+	task, err := svc.CreateTask(ctx, &models.CreateTaskRequest{
+		Title:       *title,
+		Description: *description,
+		Assignee:    *assignee,
+		Status:      s,
+	})
+	if err != nil {
+		return err
+	}
+	return printJSON(out, task)
+}
+
+func deleteTask(ctx context.Context, svc *service.TaskService, out io.Writer, args []string) error {
+	id, err := singleID("delete", args)
+	if err != nil {
+		return err
+	}
+
+	if err := svc.DeleteTask(ctx, id); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Deleted task %s\n", id)
+	return nil
+}
+
+// singleID returns the only positional argument of an id-based command
+func singleID(command string, args []string) (string, error) {
+	if len(args) != 1 || args[0] == "" {
+		return "", fmt.Errorf("usage: taskctl %s <id>", command)
+	}
+	return args[0], nil
+}
+
+// parseStatus accepts the same status spellings as the API
+func parseStatus(raw string) (models.TaskStatus, error) {
+	status := models.NormalizeStatus(raw)
+	if !models.IsValidStatus(status) {
+		return "", fmt.Errorf("invalid status: %s", raw)
+	}
+	return status, nil
+}
+
+func printJSON(out io.Writer, v any) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
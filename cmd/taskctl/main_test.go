@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/Ali-Gorgani/task-manager/internal/repository"
+	"github.com/Ali-Gorgani/task-manager/internal/service"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var taskColumns = []string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at"}
+
+// setupService builds a task service over a mocked database
+func setupService(t *testing.T, opts ...service.Option) (*service.TaskService, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return service.NewTaskService(repository.NewPostgresTaskRepository(db), nil, opts...), mock
+}
+
+func TestLookupCommand(t *testing.T) {
+	for _, name := range []string{"list", "get", "create", "delete"} {
+		exec, err := lookupCommand(name)
+		assert.NoError(t, err, name)
+		assert.NotNil(t, exec, name)
+	}
+
+	_, err := lookupCommand("--help")
+	assert.ErrorIs(t, err, errHelp)
+
+	_, err = lookupCommand("purge")
+	assert.ErrorContains(t, err, `unknown command "purge"`)
+}
+
+func TestListTasks(t *testing.T) {
+	t.Run("Filters and pages", func(t *testing.T) {
+		svc, mock := setupService(t)
+		task := models.NewTask("Task", "Desc", "a@example.com", models.TaskStatusInProgress)
+
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM tasks").
+			WithArgs(models.TaskStatusInProgress, "a@example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectQuery("SELECT (.+) FROM tasks WHERE (.+) LIMIT").
+			WithArgs(models.TaskStatusInProgress, "a@example.com", 2, 2).
+			WillReturnRows(sqlmock.NewRows(taskColumns).
+				AddRow(task.ID, task.Title, task.Description, task.Status, task.Assignee, nil, nil, task.CreatedAt, task.UpdatedAt))
+
+		var out bytes.Buffer
+		err := listTasks(context.Background(), svc, &out,
+			[]string{"-status", "In-Progress", "-assignee", "a@example.com", "-page", "2", "-page-size", "2"})
+		require.NoError(t, err)
+
+		var response models.TaskListResponse
+		require.NoError(t, json.Unmarshal(out.Bytes(), &response))
+		assert.Equal(t, 3, response.Total)
+		assert.Equal(t, 2, response.Page)
+		require.Len(t, response.Tasks, 1)
+		assert.Equal(t, task.ID, response.Tasks[0].ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid arguments", func(t *testing.T) {
+		svc, mock := setupService(t)
+		for _, args := range [][]string{
+			{"-page", "0"},
+			{"-page-size", "101"},
+			{"-status", "someday"},
+			{"-unknown"},
+		} {
+			assert.Error(t, listTasks(context.Background(), svc, new(bytes.Buffer), args), args)
+		}
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetTask(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		svc, mock := setupService(t)
+		task := models.NewTask("Task", "Desc", "", models.TaskStatusPending)
+
+		mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\$1").
+			WithArgs(task.ID).
+			WillReturnRows(sqlmock.NewRows(taskColumns).
+				AddRow(task.ID, task.Title, task.Description, task.Status, task.Assignee, nil, nil, task.CreatedAt, task.UpdatedAt))
+
+		var out bytes.Buffer
+		require.NoError(t, getTask(context.Background(), svc, &out, []string{task.ID}))
+		assert.Contains(t, out.String(), task.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		svc, mock := setupService(t)
+		mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\$1").
+			WithArgs("missing").
+			WillReturnError(sql.ErrNoRows)
+
+		err := getTask(context.Background(), svc, new(bytes.Buffer), []string{"missing"})
+		assert.ErrorIs(t, err, repository.ErrTaskNotFound)
+	})
+
+	t.Run("Needs exactly one ID", func(t *testing.T) {
+		svc, _ := setupService(t)
+		for _, args := range [][]string{nil, {""}, {"a", "b"}} {
+			assert.ErrorContains(t, getTask(context.Background(), svc, new(bytes.Buffer), args), "usage: taskctl get <id>")
+		}
+	})
+}
+
+func TestCreateTask(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		svc, mock := setupService(t)
+		mock.ExpectExec("INSERT INTO tasks").
+			WithArgs(sqlmock.AnyArg(), "Write docs", "For taskctl", models.TaskStatusInProgress, "a@example.com",
+				nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		var out bytes.Buffer
+		err := createTask(context.Background(), svc, &out,
+			[]string{"-title", "Write docs", "-description", "For taskctl", "-assignee", "a@example.com", "-status", "in progress"})
+		require.NoError(t, err)
+
+		var task models.Task
+		require.NoError(t, json.Unmarshal(out.Bytes(), &task))
+		assert.NotEmpty(t, task.ID)
+		assert.Equal(t, models.TaskStatusInProgress, task.Status)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Applies service validation", func(t *testing.T) {
+		svc, mock := setupService(t, service.WithRequiredFields([]string{"assignee"}))
+
+		err := createTask(context.Background(), svc, new(bytes.Buffer), []string{"-title", "Unassigned"})
+		var missing *service.MissingFieldsError
+		require.ErrorAs(t, err, &missing)
+		assert.Equal(t, []string{"assignee"}, missing.Fields)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid arguments", func(t *testing.T) {
+		svc, _ := setupService(t)
+		assert.ErrorContains(t, createTask(context.Background(), svc, new(bytes.Buffer), nil), "-title is required")
+		assert.ErrorContains(t, createTask(context.Background(), svc, new(bytes.Buffer),
+			[]string{"-title", "Task", "-status", "someday"}), "invalid status")
+	})
+}
+
+func TestDeleteTask(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		svc, mock := setupService(t)
+		mock.ExpectExec("DELETE FROM tasks WHERE id = \\$1").
+			WithArgs("task-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		var out bytes.Buffer
+		require.NoError(t, deleteTask(context.Background(), svc, &out, []string{"task-1"}))
+		assert.Equal(t, "Deleted task task-1\n", out.String())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		svc, mock := setupService(t)
+		mock.ExpectExec("DELETE FROM tasks WHERE id = \\$1").
+			WithArgs("missing").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := deleteTask(context.Background(), svc, new(bytes.Buffer), []string{"missing"})
+		assert.ErrorIs(t, err, repository.ErrTaskNotFound)
+	})
+
+	t.Run("Needs exactly one ID", func(t *testing.T) {
+		svc, _ := setupService(t)
+		assert.ErrorContains(t, deleteTask(context.Background(), svc, new(bytes.Buffer), nil), "usage: taskctl delete <id>")
+	})
+}
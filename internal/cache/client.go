@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis deployment modes supported by NewClient
+const (
+	ModeStandalone = "standalone"
+	ModeSentinel   = "sentinel"
+	ModeCluster    = "cluster"
+)
+
+// ClientOptions describes how to reach Redis. Addr is used in standalone
+// mode, MasterName and SentinelAddrs in sentinel mode and ClusterAddrs in
+// cluster mode.
+type ClientOptions struct {
+	Mode          string
+	Addr          string
+	Password      string
+	DB            int
+	MasterName    string
+	SentinelAddrs []string
+	ClusterAddrs  []string
+}
+
+// NewClient builds the Redis client for opts.Mode: a plain client, a
+// Sentinel-managed failover client that follows the current master, or a
+// cluster client. An empty mode means standalone.
+func NewClient(opts ClientOptions) (redis.UniversalClient, error) {
+	switch opts.Mode {
+	case ModeStandalone, "":
+		return redis.NewClient(&redis.Options{
+			Addr:     opts.Addr,
+			Password: opts.Password,
+			DB:       opts.DB,
+		}), nil
+	case ModeSentinel:
+		if opts.MasterName == "" || len(opts.SentinelAddrs) == 0 {
+			return nil, errors.New("sentinel mode requires a master name and sentinel addresses")
+		}
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:    opts.MasterName,
+			SentinelAddrs: opts.SentinelAddrs,
+			Password:      opts.Password,
+			DB:            opts.DB,
+		}), nil
+	case ModeCluster:
+		if len(opts.ClusterAddrs) == 0 {
+			return nil, errors.New("cluster mode requires cluster addresses")
+		}
+		if opts.DB != 0 {
+			return nil, errors.New("cluster mode only supports database 0")
+		}
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:    opts.ClusterAddrs,
+			Password: opts.Password,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown redis mode %q, expected standalone, sentinel or cluster", opts.Mode)
+	}
+}
@@ -0,0 +1,45 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClient(t *testing.T) {
+	t.Run("Defaults to standalone", func(t *testing.T) {
+		client, err := NewClient(ClientOptions{Addr: "localhost:6379"})
+		require.NoError(t, err)
+		defer client.Close()
+		assert.IsType(t, &redis.Client{}, client)
+	})
+
+	t.Run("Sentinel", func(t *testing.T) {
+		client, err := NewClient(ClientOptions{Mode: ModeSentinel, MasterName: "mymaster", SentinelAddrs: []string{"localhost:26379"}})
+		require.NoError(t, err)
+		defer client.Close()
+		assert.IsType(t, &redis.Client{}, client)
+	})
+
+	t.Run("Cluster", func(t *testing.T) {
+		client, err := NewClient(ClientOptions{Mode: ModeCluster, ClusterAddrs: []string{"localhost:7000", "localhost:7001"}})
+		require.NoError(t, err)
+		defer client.Close()
+		assert.IsType(t, &redis.ClusterClient{}, client)
+	})
+
+	t.Run("Rejects incomplete settings", func(t *testing.T) {
+		for name, opts := range map[string]ClientOptions{
+			"sentinel without master":    {Mode: ModeSentinel, SentinelAddrs: []string{"localhost:26379"}},
+			"sentinel without addresses": {Mode: ModeSentinel, MasterName: "mymaster"},
+			"cluster without addresses":  {Mode: ModeCluster},
+			"cluster with db":            {Mode: ModeCluster, ClusterAddrs: []string{"localhost:7000"}, DB: 1},
+			"unknown mode":               {Mode: "replicated"},
+		} {
+			_, err := NewClient(opts)
+			assert.Error(t, err, name)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// gzipMagic opens every gzip stream. A JSON document never starts with these
+// bytes, so they double as the marker for compressed cache values.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// gzipWriters reuses writers; each one holds several hundred KB of state
+var gzipWriters = sync.Pool{
+	New: func() any { return gzip.NewWriter(io.Discard) },
+}
+
+// encodeValue marshals v to JSON and gzips the result when compression is
+// enabled and the JSON is at least compressMinBytes long. Compressed output
+// that is not smaller than the JSON is discarded.
+func (c *RedisCache) encodeValue(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if c.compressMinBytes <= 0 || len(data) < c.compressMinBytes {
+		return data, nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzipWriters.Get().(*gzip.Writer)
+	defer gzipWriters.Put(zw)
+	zw.Reset(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to compress value: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress value: %w", err)
+	}
+	if buf.Len() >= len(data) {
+		return data, nil
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeValue unmarshals a cached value into v, decompressing it first if it
+// carries the gzip marker. Plain values are always accepted so entries written
+// before compression was enabled stay readable.
+func decodeValue(data []byte, v any) error {
+	if bytes.HasPrefix(data, gzipMagic) {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("failed to decompress value: %w", err)
+		}
+		defer zr.Close()
+
+		if data, err = io.ReadAll(zr); err != nil {
+			return fmt.Errorf("failed to decompress value: %w", err)
+		}
+	}
+	return json.Unmarshal(data, v)
+}
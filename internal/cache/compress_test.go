@@ -0,0 +1,123 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// largeTaskList builds n tasks with the repetitive text typical of real lists
+func largeTaskList(n int) []models.Task {
+	tasks := make([]models.Task, n)
+	for i := range tasks {
+		tasks[i] = *models.NewTask(
+			fmt.Sprintf("Task %d", i),
+			"Write comprehensive README and API docs for the task manager service",
+			"john.doe@example.com",
+			models.TaskStatusPending,
+		)
+	}
+	return tasks
+}
+
+func TestEncodeValue(t *testing.T) {
+	entry := TaskListEntry{Tasks: largeTaskList(50), Total: 50}
+	plain, err := json.Marshal(entry)
+	require.NoError(t, err)
+
+	t.Run("Disabled", func(t *testing.T) {
+		c := NewRedisCache(nil)
+		data, err := c.encodeValue(entry)
+		require.NoError(t, err)
+		assert.Equal(t, plain, data)
+	})
+
+	t.Run("Below threshold", func(t *testing.T) {
+		c := NewRedisCache(nil, WithCompression(len(plain)+1))
+		data, err := c.encodeValue(entry)
+		require.NoError(t, err)
+		assert.Equal(t, plain, data)
+	})
+
+	t.Run("Compressed round trip", func(t *testing.T) {
+		c := NewRedisCache(nil, WithCompression(1024))
+		data, err := c.encodeValue(entry)
+		require.NoError(t, err)
+		assert.Equal(t, gzipMagic, data[:2])
+		assert.Less(t, len(data), len(plain))
+
+		var decoded TaskListEntry
+		require.NoError(t, decodeValue(data, &decoded))
+		assert.Equal(t, entry.Total, decoded.Total)
+		assert.Len(t, decoded.Tasks, 50)
+		assert.Equal(t, entry.Tasks[0].ID, decoded.Tasks[0].ID)
+	})
+
+	t.Run("Plain values still decode", func(t *testing.T) {
+		var decoded TaskListEntry
+		require.NoError(t, decodeValue(plain, &decoded))
+		assert.Len(t, decoded.Tasks, 50)
+	})
+
+	t.Run("Corrupt compressed value", func(t *testing.T) {
+		var decoded TaskListEntry
+		assert.Error(t, decodeValue([]byte{0x1f, 0x8b, 0x00}, &decoded))
+	})
+}
+
+func TestRedisCache_CompressedTaskList(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	cache := NewRedisCache(db, WithCompression(1024))
+	ctx := context.Background()
+
+	cacheKey := "tasks:list:page:1:size:100"
+	tasks := largeTaskList(100)
+
+	data, err := cache.encodeValue(TaskListEntry{Tasks: tasks, Total: 100})
+	require.NoError(t, err)
+	mock.ExpectSet(cacheKey, data, cacheTTL).SetVal("OK")
+	mock.ExpectGet(cacheKey).SetVal(string(data))
+
+	require.NoError(t, cache.SetTaskList(ctx, cacheKey, tasks, 100))
+
+	entry, err := cache.GetTaskList(ctx, cacheKey)
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, 100, entry.Total)
+	assert.Len(t, entry.Tasks, 100)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// BenchmarkEncodeTaskList compares plain and compressed list values. The
+// bytes/value metric is what Redis stores and sends per cached page.
+func BenchmarkEncodeTaskList(b *testing.B) {
+	entry := TaskListEntry{Tasks: largeTaskList(100), Total: 100}
+
+	for _, bc := range []struct {
+		name     string
+		minBytes int
+	}{
+		{"Plain", 0},
+		{"Gzip", 1024},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			c := NewRedisCache(nil, WithCompression(bc.minBytes))
+			var size int
+			b.ReportAllocs()
+			for b.Loop() {
+				data, err := c.encodeValue(entry)
+				if err != nil {
+					b.Fatal(err)
+				}
+				size = len(data)
+			}
+			b.ReportMetric(float64(size), "bytes/value")
+		})
+	}
+}
@@ -2,10 +2,14 @@ package cache
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/Ali-Gorgani/task-manager/internal/metrics"
 	"github.com/Ali-Gorgani/task-manager/internal/models"
 	"github.com/redis/go-redis/v9"
 )
@@ -13,21 +17,72 @@ import (
 const (
 	taskCachePrefix = "task:"
 	taskListKey     = "tasks:list"
+	taskCountKey    = "tasks:count"
 	cacheTTL        = 5 * time.Minute
+
+	// recentTasksTTL is short because the recent activity feed should not
+	// lag behind for long even if an invalidation is missed
+	recentTasksTTL = 30 * time.Second
+
+	// listAccessKey is a sorted set of list cache keys scored by last access
+	// time. It sits outside the tasks:list* pattern, so list invalidation
+	// removes it explicitly along with the keys it tracks.
+	listAccessKey = "cache:tasks:list:access"
 )
 
 // RedisCache implements a Redis-based cache for tasks
 type RedisCache struct {
-	client *redis.Client
+	client           redis.UniversalClient
+	trackListAccess  bool
+	compressMinBytes int
+
+	retries       chan pendingWrite
+	retryAttempts int
+	retryBackoff  time.Duration
+	invalidations atomic.Uint64
+}
+
+// Option configures optional RedisCache behaviour
+type Option func(*RedisCache)
+
+// WithListAccessTracking records list cache accesses so CompactTaskLists can
+// evict the least recently used entries
+func WithListAccessTracking(enabled bool) Option {
+	return func(c *RedisCache) {
+		c.trackListAccess = enabled
+	}
 }
 
-// NewRedisCache creates a new Redis cache instance
-func NewRedisCache(client *redis.Client) *RedisCache {
-	return &RedisCache{client: client}
+// WithCompression gzips cached task and list values whose JSON is at least
+// minBytes long. Zero or less disables compression.
+func WithCompression(minBytes int) Option {
+	return func(c *RedisCache) {
+		c.compressMinBytes = minBytes
+	}
+}
+
+// NewRedisCache creates a new Redis cache instance on a standalone,
+// Sentinel failover or cluster client
+func NewRedisCache(client redis.UniversalClient, opts ...Option) *RedisCache {
+	c := &RedisCache{client: client}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Ping verifies the Redis connection is alive
+func (c *RedisCache) Ping(ctx context.Context) error {
+	defer observe("Ping", time.Now())
+	if err := c.client.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("failed to ping cache: %w", err)
+	}
+	return nil
 }
 
 // GetTask retrieves a task from cache
 func (c *RedisCache) GetTask(ctx context.Context, id string) (*models.Task, error) {
+	defer observe("GetTask", time.Now())
 	key := taskCachePrefix + id
 	data, err := c.client.Get(ctx, key).Bytes()
 	if err == redis.Nil {
@@ -38,22 +93,28 @@ func (c *RedisCache) GetTask(ctx context.Context, id string) (*models.Task, erro
 	}
 
 	var task models.Task
-	if err := json.Unmarshal(data, &task); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal task: %w", err)
+	if err := decodeValue(data, &task); err != nil {
+		c.discardCorrupt(ctx, key, "task", err)
+		return nil, nil
 	}
 
 	return &task, nil
 }
 
-// SetTask stores a task in cache
+// SetTask stores a task in cache. With write retries enabled, a failed write
+// is also queued for another attempt.
 func (c *RedisCache) SetTask(ctx context.Context, task *models.Task) error {
+	defer observe("SetTask", time.Now())
 	key := taskCachePrefix + task.ID
-	data, err := json.Marshal(task)
+	data, err := c.encodeValue(task)
 	if err != nil {
 		return fmt.Errorf("failed to marshal task: %w", err)
 	}
 
 	if err := c.client.Set(ctx, key, data, cacheTTL).Err(); err != nil {
+		c.retryLater("SetTask", func(ctx context.Context) error {
+			return c.client.Set(ctx, key, data, cacheTTL).Err()
+		})
 		return fmt.Errorf("failed to set cache: %w", err)
 	}
 
@@ -62,6 +123,8 @@ func (c *RedisCache) SetTask(ctx context.Context, task *models.Task) error {
 
 // DeleteTask removes a task from cache
 func (c *RedisCache) DeleteTask(ctx context.Context, id string) error {
+	defer observe("DeleteTask", time.Now())
+	c.invalidated()
 	key := taskCachePrefix + id
 	if err := c.client.Del(ctx, key).Err(); err != nil {
 		return fmt.Errorf("failed to delete from cache: %w", err)
@@ -69,8 +132,46 @@ func (c *RedisCache) DeleteTask(ctx context.Context, id string) error {
 	return nil
 }
 
+// GetTaskCount retrieves the cached total task count. ok is false on a miss.
+func (c *RedisCache) GetTaskCount(ctx context.Context) (count int, ok bool, err error) {
+	defer observe("GetTaskCount", time.Now())
+	count, err = c.client.Get(ctx, taskCountKey).Int()
+	if err == redis.Nil {
+		return 0, false, nil // Cache miss
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get count from cache: %w", err)
+	}
+	return count, true, nil
+}
+
+// SetTaskCount caches the total task count for ttl
+func (c *RedisCache) SetTaskCount(ctx context.Context, count int, ttl time.Duration) error {
+	defer observe("SetTaskCount", time.Now())
+	if err := c.client.Set(ctx, taskCountKey, count, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to set count cache: %w", err)
+	}
+	return nil
+}
+
+// InvalidateTaskCount removes the cached total task count
+func (c *RedisCache) InvalidateTaskCount(ctx context.Context) error {
+	defer observe("InvalidateTaskCount", time.Now())
+	if err := c.client.Del(ctx, taskCountKey).Err(); err != nil {
+		return fmt.Errorf("failed to delete count cache: %w", err)
+	}
+	return nil
+}
+
+// TaskListEntry is a cached page of tasks together with the filter's total count
+type TaskListEntry struct {
+	Tasks []models.Task `json:"tasks"`
+	Total int           `json:"total"`
+}
+
 // GetTaskList retrieves task list from cache
-func (c *RedisCache) GetTaskList(ctx context.Context, cacheKey string) ([]models.Task, error) {
+func (c *RedisCache) GetTaskList(ctx context.Context, cacheKey string) (*TaskListEntry, error) {
+	defer observe("GetTaskList", time.Now())
 	data, err := c.client.Get(ctx, cacheKey).Bytes()
 	if err == redis.Nil {
 		return nil, nil // Cache miss
@@ -79,34 +180,215 @@ func (c *RedisCache) GetTaskList(ctx context.Context, cacheKey string) ([]models
 		return nil, fmt.Errorf("failed to get list from cache: %w", err)
 	}
 
-	var tasks []models.Task
-	if err := json.Unmarshal(data, &tasks); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal tasks: %w", err)
+	var entry TaskListEntry
+	if err := decodeValue(data, &entry); err != nil {
+		c.discardCorrupt(ctx, cacheKey, "list", err)
+		return nil, nil
 	}
 
-	return tasks, nil
+	c.touchTaskList(ctx, cacheKey)
+	return &entry, nil
 }
 
-// SetTaskList stores task list in cache
-func (c *RedisCache) SetTaskList(ctx context.Context, cacheKey string, tasks []models.Task) error {
-	data, err := json.Marshal(tasks)
+// SetTaskList stores task list in cache along with the total matching count.
+// With write retries enabled, a failed write is also queued for another
+// attempt.
+func (c *RedisCache) SetTaskList(ctx context.Context, cacheKey string, tasks []models.Task, total int) error {
+	defer observe("SetTaskList", time.Now())
+	data, err := c.encodeValue(TaskListEntry{Tasks: tasks, Total: total})
 	if err != nil {
 		return fmt.Errorf("failed to marshal tasks: %w", err)
 	}
 
 	if err := c.client.Set(ctx, cacheKey, data, cacheTTL).Err(); err != nil {
+		c.retryLater("SetTaskList", func(ctx context.Context) error {
+			if err := c.client.Set(ctx, cacheKey, data, cacheTTL).Err(); err != nil {
+				return err
+			}
+			c.touchTaskList(ctx, cacheKey)
+			return nil
+		})
 		return fmt.Errorf("failed to set list cache: %w", err)
 	}
 
+	c.touchTaskList(ctx, cacheKey)
 	return nil
 }
 
-// InvalidateTaskList invalidates all task list caches
+// recentTasksKey is the cache key for the limit most recently updated tasks.
+// It shares the list prefix, so list invalidation removes it.
+func recentTasksKey(limit int) string {
+	return fmt.Sprintf("%s:recent:%d", taskListKey, limit)
+}
+
+// GetRecentTasks retrieves the cached limit most recently updated tasks
+func (c *RedisCache) GetRecentTasks(ctx context.Context, limit int) ([]models.Task, error) {
+	defer observe("GetRecentTasks", time.Now())
+	key := recentTasksKey(limit)
+	data, err := c.client.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		return nil, nil // Cache miss
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent tasks from cache: %w", err)
+	}
+
+	var tasks []models.Task
+	if err := decodeValue(data, &tasks); err != nil {
+		c.discardCorrupt(ctx, key, "list", err)
+		return nil, nil
+	}
+
+	return tasks, nil
+}
+
+// SetRecentTasks caches the limit most recently updated tasks
+func (c *RedisCache) SetRecentTasks(ctx context.Context, limit int, tasks []models.Task) error {
+	defer observe("SetRecentTasks", time.Now())
+	data, err := c.encodeValue(tasks)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tasks: %w", err)
+	}
+
+	if err := c.client.Set(ctx, recentTasksKey(limit), data, recentTasksTTL).Err(); err != nil {
+		return fmt.Errorf("failed to set recent tasks cache: %w", err)
+	}
+
+	return nil
+}
+
+// observe records the duration of a cache operation. It is meant to be
+// deferred at the top of each operation:
+//
+//	defer observe("GetTask", time.Now())
+func observe(op string, start time.Time) {
+	metrics.CacheOperationDuration.WithLabelValues(op).Observe(time.Since(start).Seconds())
+}
+
+// discardCorrupt deletes a cached value that failed to decode so the next
+// read repopulates it, and counts it. Callers then report a cache miss.
+func (c *RedisCache) discardCorrupt(ctx context.Context, key, kind string, err error) {
+	metrics.CacheCorruptTotal.WithLabelValues(kind).Inc()
+	log.Printf("Warning: discarding corrupt cache value %s: %v", key, err)
+	_ = c.client.Del(ctx, key).Err()
+}
+
+// touchTaskList records an access to a list cache key. Failures only make
+// eviction less accurate, so they are ignored.
+func (c *RedisCache) touchTaskList(ctx context.Context, cacheKey string) {
+	if !c.trackListAccess {
+		return
+	}
+	_ = c.client.ZAdd(ctx, listAccessKey, redis.Z{
+		Score:  float64(time.Now().UnixNano()),
+		Member: cacheKey,
+	}).Err()
+}
+
+// CompactTaskLists evicts the least recently used list cache entries beyond
+// maxKeys and returns how many were evicted. Tracked keys that have already
+// expired are untracked and do not count towards maxKeys.
+func (c *RedisCache) CompactTaskLists(ctx context.Context, maxKeys int) (int, error) {
+	defer observe("CompactTaskLists", time.Now())
+	count, err := c.client.ZCard(ctx, listAccessKey).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count list keys: %w", err)
+	}
+	if count <= int64(maxKeys) {
+		return 0, nil
+	}
+
+	// Oldest access first
+	keys, err := c.client.ZRange(ctx, listAccessKey, 0, -1).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read list keys: %w", err)
+	}
+
+	pipe := c.client.Pipeline()
+	checks := make([]*redis.IntCmd, len(keys))
+	for i, key := range keys {
+		checks[i] = pipe.Exists(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to check list keys: %w", err)
+	}
+
+	var live, untrack []string
+	for i, key := range keys {
+		if checks[i].Val() > 0 {
+			live = append(live, key)
+		} else {
+			untrack = append(untrack, key)
+		}
+	}
+
+	var evict []string
+	if excess := len(live) - maxKeys; excess > 0 {
+		evict = live[:excess]
+		// One DEL per key: the keys hash to different cluster slots, so a
+		// multi-key DEL fails with CROSSSLOT in cluster mode
+		pipe := c.client.Pipeline()
+		for _, key := range evict {
+			pipe.Del(ctx, key)
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return 0, fmt.Errorf("failed to evict list keys: %w", err)
+		}
+		untrack = append(untrack, evict...)
+	}
+
+	if len(untrack) > 0 {
+		members := make([]interface{}, len(untrack))
+		for i, key := range untrack {
+			members[i] = key
+		}
+		if err := c.client.ZRem(ctx, listAccessKey, members...).Err(); err != nil {
+			return 0, fmt.Errorf("failed to untrack list keys: %w", err)
+		}
+	}
+
+	return len(evict), nil
+}
+
+// InvalidateTaskList invalidates all task list caches, and the access
+// tracking for them
 func (c *RedisCache) InvalidateTaskList(ctx context.Context) error {
-	// Delete all keys matching the pattern
-	iter := c.client.Scan(ctx, 0, "tasks:list*", 0).Iterator()
+	defer observe("InvalidateTaskList", time.Now())
+	c.invalidated()
+	if err := c.deleteMatching(ctx, taskListKey+"*"); err != nil {
+		return err
+	}
+	if c.trackListAccess {
+		if err := c.client.Del(ctx, listAccessKey).Err(); err != nil {
+			return fmt.Errorf("failed to delete list access tracking: %w", err)
+		}
+	}
+	return nil
+}
+
+// InvalidateAllTasks removes every cached task entry
+func (c *RedisCache) InvalidateAllTasks(ctx context.Context) error {
+	defer observe("InvalidateAllTasks", time.Now())
+	c.invalidated()
+	return c.deleteMatching(ctx, taskCachePrefix+"*")
+}
+
+// deleteMatching deletes every key matching pattern. SCAN only covers the
+// node it runs on, so a cluster is scanned master by master.
+func (c *RedisCache) deleteMatching(ctx context.Context, pattern string) error {
+	if cluster, ok := c.client.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return deleteMatching(ctx, node, pattern)
+		})
+	}
+	return deleteMatching(ctx, c.client, pattern)
+}
+
+// deleteMatching scans client for keys matching pattern and deletes them
+func deleteMatching(ctx context.Context, client redis.Cmdable, pattern string) error {
+	iter := client.Scan(ctx, 0, pattern, 0).Iterator()
 	for iter.Next(ctx) {
-		if err := c.client.Del(ctx, iter.Val()).Err(); err != nil {
+		if err := client.Del(ctx, iter.Val()).Err(); err != nil {
 			return fmt.Errorf("failed to delete key %s: %w", iter.Val(), err)
 		}
 	}
@@ -119,18 +401,67 @@ func (c *RedisCache) InvalidateTaskList(ctx context.Context) error {
 
 // GenerateCacheKey generates a cache key for task list with filters
 func GenerateCacheKey(filter *models.TaskFilter) string {
-	key := taskListKey
 	if filter == nil {
-		return key + ":all"
+		return taskListKey + ":all"
 	}
+	return listFilterKey(filter) + fmt.Sprintf(":page:%d:size:%d", filter.Page, filter.PageSize)
+}
+
+// GenerateFullListCacheKey generates the cache key for the unpaginated
+// result of a filter. It shares the list prefix, so list invalidation
+// removes it along with the per-page entries.
+func GenerateFullListCacheKey(filter *models.TaskFilter) string {
+	if filter == nil {
+		return taskListKey + ":all:full"
+	}
+	return listFilterKey(filter) + ":full"
+}
+
+// keyValueEscaper escapes the characters that separate the parts of a list
+// cache key, and the escape character itself, so no filter value can forge
+// the key of another filter
+var keyValueEscaper = strings.NewReplacer("%", "%25", ":", "%3A", ",", "%2C")
+
+// listFilterKey encodes every filter, sort and order setting of a list
+// request, but not its pagination. Client-supplied values are escaped.
+func listFilterKey(filter *models.TaskFilter) string {
+	key := taskListKey
 
 	if filter.Status != nil {
-		key += fmt.Sprintf(":status:%s", *filter.Status)
+		key += fmt.Sprintf(":status:%s", keyValueEscaper.Replace(string(*filter.Status)))
+	}
+	if len(filter.Assignees) > 0 {
+		// Sorted, so the order of ?assignee= parameters shares one entry
+		assignees := slices.Sorted(slices.Values(filter.Assignees))
+		for i, assignee := range assignees {
+			assignees[i] = keyValueEscaper.Replace(assignee)
+		}
+		key += fmt.Sprintf(":assignee:%s", strings.Join(assignees, ","))
+	}
+	if filter.Source != nil {
+		key += fmt.Sprintf(":source:%s", keyValueEscaper.Replace(*filter.Source))
+	}
+	if filter.ExternalID != nil {
+		key += fmt.Sprintf(":external_id:%s", keyValueEscaper.Replace(*filter.ExternalID))
+	}
+	if filter.HasDescription != nil {
+		key += fmt.Sprintf(":has_description:%t", *filter.HasDescription)
+	}
+	if filter.Search != "" {
+		key += fmt.Sprintf(":search:%s", keyValueEscaper.Replace(filter.Search))
+	}
+	if filter.TitlePrefix != nil {
+		key += fmt.Sprintf(":title_prefix:%s", keyValueEscaper.Replace(*filter.TitlePrefix))
+	}
+	if filter.StaleDays > 0 {
+		key += fmt.Sprintf(":stale_days:%d", filter.StaleDays)
+	}
+	if filter.Sort != "" {
+		key += fmt.Sprintf(":sort:%s", filter.Sort)
 	}
-	if filter.Assignee != nil {
-		key += fmt.Sprintf(":assignee:%s", *filter.Assignee)
+	if filter.Order != "" {
+		key += fmt.Sprintf(":order:%s", filter.Order)
 	}
-	key += fmt.Sprintf(":page:%d:size:%d", filter.Page, filter.PageSize)
 
 	return key
 }
@@ -5,8 +5,13 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/Ali-Gorgani/task-manager/internal/metrics"
 	"github.com/Ali-Gorgani/task-manager/internal/models"
 	"github.com/go-redis/redismock/v9"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -33,21 +38,77 @@ func TestGenerateCacheKey(t *testing.T) {
 		{
 			name: "With assignee",
 			filter: &models.TaskFilter{
-				Assignee: ptrString("test@example.com"),
-				Page:     2,
-				PageSize: 20,
+				Assignees: []string{"test@example.com"},
+				Page:      2,
+				PageSize:  20,
 			},
 			expected: "tasks:list:assignee:test@example.com:page:2:size:20",
 		},
 		{
 			name: "With both",
 			filter: &models.TaskFilter{
-				Status:   ptrTaskStatus(models.TaskStatusCompleted),
-				Assignee: ptrString("user@example.com"),
+				Status:    ptrTaskStatus(models.TaskStatusCompleted),
+				Assignees: []string{"user@example.com"},
+				Page:      1,
+				PageSize:  10,
+			},
+			expected: "tasks:list:status:completed:assignee:user@example.com:page:1:size:10",
+		},
+		{
+			name: "With several assignees",
+			filter: &models.TaskFilter{
+				Assignees: []string{"bob@example.com", "alice@example.com"},
+				Page:      1,
+				PageSize:  10,
+			},
+			expected: "tasks:list:assignee:alice@example.com,bob@example.com:page:1:size:10",
+		},
+		{
+			name: "With title_prefix",
+			filter: &models.TaskFilter{
+				TitlePrefix: ptrString("Comp"),
+				Page:        1,
+				PageSize:    10,
+			},
+			expected: "tasks:list:title_prefix:Comp:page:1:size:10",
+		},
+		{
+			name: "With stale_days",
+			filter: &models.TaskFilter{
+				Stale:     true,
+				StaleDays: 14,
+				Page:      1,
+				PageSize:  10,
+			},
+			expected: "tasks:list:stale_days:14:page:1:size:10",
+		},
+		{
+			name: "With has_description",
+			filter: &models.TaskFilter{
+				HasDescription: ptrBool(false),
+				Page:           1,
+				PageSize:       10,
+			},
+			expected: "tasks:list:has_description:false:page:1:size:10",
+		},
+		{
+			name: "With order",
+			filter: &models.TaskFilter{
+				Order:    models.SortOrderAsc,
 				Page:     1,
 				PageSize: 10,
 			},
-			expected: "tasks:list:status:completed:assignee:user@example.com:page:1:size:10",
+			expected: "tasks:list:order:asc:page:1:size:10",
+		},
+		{
+			name: "With separators in values",
+			filter: &models.TaskFilter{
+				Assignees: []string{"a,b@example.com"},
+				Search:    "x:title_prefix:y%",
+				Page:      1,
+				PageSize:  10,
+			},
+			expected: "tasks:list:assignee:a%2Cb@example.com:search:x%3Atitle_prefix%3Ay%25:page:1:size:10",
 		},
 	}
 
@@ -59,6 +120,32 @@ func TestGenerateCacheKey(t *testing.T) {
 	}
 }
 
+func TestGenerateCacheKey_NoCollisions(t *testing.T) {
+	pairs := [][2]*models.TaskFilter{
+		{
+			{Search: "x:title_prefix:y"},
+			{Search: "x", TitlePrefix: ptrString("y")},
+		},
+		{
+			{Assignees: []string{"a,b"}},
+			{Assignees: []string{"a", "b"}},
+		},
+		{
+			{Source: ptrString("jira:external_id:1")},
+			{Source: ptrString("jira"), ExternalID: ptrString("1")},
+		},
+		{
+			{Search: "%3A"},
+			{Search: ":"},
+		},
+	}
+
+	for _, pair := range pairs {
+		assert.NotEqual(t, GenerateCacheKey(pair[0]), GenerateCacheKey(pair[1]))
+		assert.NotEqual(t, GenerateFullListCacheKey(pair[0]), GenerateFullListCacheKey(pair[1]))
+	}
+}
+
 func ptrTaskStatus(s models.TaskStatus) *models.TaskStatus {
 	return &s
 }
@@ -67,6 +154,28 @@ func ptrString(s string) *string {
 	return &s
 }
 
+func ptrBool(b bool) *bool {
+	return &b
+}
+
+func TestGenerateFullListCacheKey(t *testing.T) {
+	filter := &models.TaskFilter{
+		Status:   ptrTaskStatus(models.TaskStatusPending),
+		Sort:     "title",
+		Order:    models.SortOrderAsc,
+		Page:     3,
+		PageSize: 20,
+	}
+
+	key := GenerateFullListCacheKey(filter)
+	assert.Equal(t, "tasks:list:status:pending:sort:title:order:asc:full", key)
+
+	// Every page of the same filter shares the full list entry
+	filter.Page = 1
+	assert.Equal(t, key, GenerateFullListCacheKey(filter))
+	assert.NotEqual(t, key, GenerateCacheKey(filter))
+}
+
 // Mock Redis client test
 func TestRedisCache_MockOperations(t *testing.T) {
 	// These tests would require a Redis instance or mock
@@ -114,6 +223,41 @@ func TestRedisCache_GetTask(t *testing.T) {
 		assert.Error(t, err)
 		assert.Nil(t, result)
 	})
+
+	t.Run("Corrupt value is discarded", func(t *testing.T) {
+		before := testutil.ToFloat64(metrics.CacheCorruptTotal.WithLabelValues("task"))
+		mock.ExpectGet("task:corrupt").SetVal(`{"id":"corrupt","title":"Trunc`)
+		mock.ExpectDel("task:corrupt").SetVal(1)
+
+		result, err := cache.GetTask(ctx, "corrupt")
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, before+1, testutil.ToFloat64(metrics.CacheCorruptTotal.WithLabelValues("task")))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRedisCache_OperationDuration(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	cache := NewRedisCache(db)
+
+	sampleCount := func(op string) uint64 {
+		var metric dto.Metric
+		err := metrics.CacheOperationDuration.WithLabelValues(op).(prometheus.Metric).Write(&metric)
+		assert.NoError(t, err)
+		return metric.GetHistogram().GetSampleCount()
+	}
+
+	before := sampleCount("DeleteTask")
+	mock.ExpectDel("task:1").SetVal(1)
+	mock.ExpectDel("task:2").SetErr(assert.AnError)
+
+	assert.NoError(t, cache.DeleteTask(context.Background(), "1"))
+	assert.Error(t, cache.DeleteTask(context.Background(), "2"))
+
+	// Failed operations are timed too
+	assert.Equal(t, before+2, sampleCount("DeleteTask"))
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestRedisCache_SetTask(t *testing.T) {
@@ -174,7 +318,7 @@ func TestRedisCache_GetTaskList(t *testing.T) {
 			*models.NewTask("Task 1", "Desc 1", "user1@example.com", models.TaskStatusPending),
 			*models.NewTask("Task 2", "Desc 2", "user2@example.com", models.TaskStatusCompleted),
 		}
-		tasksData, _ := json.Marshal(tasks)
+		tasksData, _ := json.Marshal(TaskListEntry{Tasks: tasks, Total: 12})
 		cacheKey := "tasks:list:all"
 
 		mock.ExpectGet(cacheKey).SetVal(string(tasksData))
@@ -182,7 +326,8 @@ func TestRedisCache_GetTaskList(t *testing.T) {
 		result, err := cache.GetTaskList(ctx, cacheKey)
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
-		assert.Len(t, result, 2)
+		assert.Len(t, result.Tasks, 2)
+		assert.Equal(t, 12, result.Total)
 	})
 
 	t.Run("Cache miss", func(t *testing.T) {
@@ -202,6 +347,19 @@ func TestRedisCache_GetTaskList(t *testing.T) {
 		assert.Error(t, err)
 		assert.Nil(t, result)
 	})
+
+	t.Run("Corrupt value is discarded", func(t *testing.T) {
+		cacheKey := "tasks:list:corrupt"
+		before := testutil.ToFloat64(metrics.CacheCorruptTotal.WithLabelValues("list"))
+		mock.ExpectGet(cacheKey).SetVal("\x1f\x8b\x08garbage")
+		mock.ExpectDel(cacheKey).SetVal(1)
+
+		result, err := cache.GetTaskList(ctx, cacheKey)
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, before+1, testutil.ToFloat64(metrics.CacheCorruptTotal.WithLabelValues("list")))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
 }
 
 func TestRedisCache_SetTaskList(t *testing.T) {
@@ -213,12 +371,12 @@ func TestRedisCache_SetTaskList(t *testing.T) {
 		tasks := []models.Task{
 			*models.NewTask("Task 1", "Desc 1", "user1@example.com", models.TaskStatusPending),
 		}
-		tasksData, _ := json.Marshal(tasks)
+		tasksData, _ := json.Marshal(TaskListEntry{Tasks: tasks, Total: 1})
 		cacheKey := "tasks:list:test"
 
 		mock.ExpectSet(cacheKey, tasksData, cacheTTL).SetVal("OK")
 
-		err := cache.SetTaskList(ctx, cacheKey, tasks)
+		err := cache.SetTaskList(ctx, cacheKey, tasks, 1)
 		assert.NoError(t, err)
 	})
 
@@ -226,12 +384,12 @@ func TestRedisCache_SetTaskList(t *testing.T) {
 		tasks := []models.Task{
 			*models.NewTask("Task 1", "Desc 1", "user1@example.com", models.TaskStatusPending),
 		}
-		tasksData, _ := json.Marshal(tasks)
+		tasksData, _ := json.Marshal(TaskListEntry{Tasks: tasks, Total: 1})
 		cacheKey := "tasks:list:error"
 
 		mock.ExpectSet(cacheKey, tasksData, cacheTTL).SetErr(assert.AnError)
 
-		err := cache.SetTaskList(ctx, cacheKey, tasks)
+		err := cache.SetTaskList(ctx, cacheKey, tasks, 1)
 		assert.Error(t, err)
 	})
 }
@@ -258,6 +416,120 @@ func TestRedisCache_InvalidateTaskList(t *testing.T) {
 		err := cache.InvalidateTaskList(ctx)
 		assert.NoError(t, err)
 	})
+
+	t.Run("Drops access tracking", func(t *testing.T) {
+		tracked := NewRedisCache(db, WithListAccessTracking(true))
+		mock.ExpectScan(0, "tasks:list*", 0).SetVal([]string{"tasks:list:1"}, 0)
+		mock.ExpectDel("tasks:list:1").SetVal(1)
+		mock.ExpectDel(listAccessKey).SetVal(1)
+
+		assert.NoError(t, tracked.InvalidateTaskList(ctx))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRedisCache_Ping(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	cache := NewRedisCache(db)
+	ctx := context.Background()
+
+	mock.ExpectPing().SetVal("PONG")
+	assert.NoError(t, cache.Ping(ctx))
+
+	mock.ExpectPing().SetErr(assert.AnError)
+	assert.Error(t, cache.Ping(ctx))
+}
+
+func TestRedisCache_ListAccessTracking(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	cache := NewRedisCache(db, WithListAccessTracking(true))
+	ctx := context.Background()
+
+	tasks := []models.Task{}
+	tasksData, _ := json.Marshal(TaskListEntry{Tasks: tasks, Total: 0})
+	cacheKey := "tasks:list:tracked"
+
+	mock.ExpectSet(cacheKey, tasksData, cacheTTL).SetVal("OK")
+	mock.CustomMatch(func(expected, actual []interface{}) error {
+		assert.Equal(t, "zadd", actual[0])
+		assert.Equal(t, listAccessKey, actual[1])
+		assert.Equal(t, cacheKey, actual[3])
+		return nil
+	}).ExpectZAdd(listAccessKey, redis.Z{Member: cacheKey}).SetVal(1)
+
+	err := cache.SetTaskList(ctx, cacheKey, tasks, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedisCache_CompactTaskLists(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	cache := NewRedisCache(db, WithListAccessTracking(true))
+	ctx := context.Background()
+
+	t.Run("Evicts least recently used beyond limit", func(t *testing.T) {
+		keys := []string{"tasks:list:a", "tasks:list:b", "tasks:list:c", "tasks:list:d", "tasks:list:e"}
+		mock.ExpectZCard(listAccessKey).SetVal(5)
+		mock.ExpectZRange(listAccessKey, 0, -1).SetVal(keys)
+		for _, key := range keys {
+			mock.ExpectExists(key).SetVal(1)
+		}
+		mock.ExpectDel("tasks:list:a").SetVal(1)
+		mock.ExpectDel("tasks:list:b").SetVal(1)
+		mock.ExpectZRem(listAccessKey, "tasks:list:a", "tasks:list:b").SetVal(2)
+
+		evicted, err := cache.CompactTaskLists(ctx, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, evicted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Expired keys do not count", func(t *testing.T) {
+		keys := []string{"tasks:list:gone", "tasks:list:a", "tasks:list:b", "tasks:list:c"}
+		mock.ExpectZCard(listAccessKey).SetVal(4)
+		mock.ExpectZRange(listAccessKey, 0, -1).SetVal(keys)
+		mock.ExpectExists("tasks:list:gone").SetVal(0)
+		for _, key := range keys[1:] {
+			mock.ExpectExists(key).SetVal(1)
+		}
+		mock.ExpectZRem(listAccessKey, "tasks:list:gone").SetVal(1)
+
+		evicted, err := cache.CompactTaskLists(ctx, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, evicted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Under limit", func(t *testing.T) {
+		mock.ExpectZCard(listAccessKey).SetVal(2)
+
+		evicted, err := cache.CompactTaskLists(ctx, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, evicted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRedisCache_CompactTaskLists_Cluster(t *testing.T) {
+	db, mock := redismock.NewClusterMock()
+	cache := NewRedisCache(db, WithListAccessTracking(true))
+	ctx := context.Background()
+
+	// Keys in different slots must be deleted one by one
+	keys := []string{"tasks:list:status:pending", "tasks:list:assignee:bob", "tasks:list:all"}
+	mock.ExpectZCard(listAccessKey).SetVal(3)
+	mock.ExpectZRange(listAccessKey, 0, -1).SetVal(keys)
+	for _, key := range keys {
+		mock.ExpectExists(key).SetVal(1)
+	}
+	mock.ExpectDel(keys[0]).SetVal(1)
+	mock.ExpectDel(keys[1]).SetVal(1)
+	mock.ExpectZRem(listAccessKey, keys[0], keys[1]).SetVal(2)
+
+	evicted, err := cache.CompactTaskLists(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, evicted)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestNewRedisCache(t *testing.T) {
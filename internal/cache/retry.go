@@ -0,0 +1,163 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ali-Gorgani/task-manager/internal/metrics"
+)
+
+// pendingWrite is a failed cache write waiting to be retried
+type pendingWrite struct {
+	op    string
+	write func(ctx context.Context) error
+	// generation is the invalidation count when the write was first tried
+	generation uint64
+	attempt    int
+	due        time.Time
+}
+
+// WithWriteRetry retries failed SetTask and SetTaskList writes in the
+// background, so a transient Redis error does not leave the entry cold until
+// the next miss. Up to queueSize writes wait at once; further failures are
+// dropped. Each write is tried at most attempts times in total, backoff
+// apart and doubling. Retries only run while RunWriteRetries does.
+//
+// A retry is skipped once this cache has invalidated any entry since the
+// write was first tried, so it never restores data that a later write made
+// stale. Invalidations by other replicas are not seen; the entry TTL bounds
+// how long such a value can linger.
+func WithWriteRetry(queueSize, attempts int, backoff time.Duration) Option {
+	return func(c *RedisCache) {
+		if queueSize <= 0 || attempts <= 1 {
+			return
+		}
+		c.retries = make(chan pendingWrite, queueSize)
+		c.retryAttempts = attempts
+		c.retryBackoff = backoff
+	}
+}
+
+// retryLater queues a failed write for another attempt without blocking
+func (c *RedisCache) retryLater(op string, write func(ctx context.Context) error) {
+	if c.retries == nil {
+		return
+	}
+	c.enqueueRetry(pendingWrite{
+		op:         op,
+		write:      write,
+		generation: c.invalidations.Load(),
+		attempt:    1,
+		due:        time.Now().Add(c.retryBackoff),
+	})
+}
+
+// enqueueRetry queues w, dropping it when the queue is full
+func (c *RedisCache) enqueueRetry(w pendingWrite) {
+	select {
+	case c.retries <- w:
+		metrics.CacheWriteRetriesTotal.WithLabelValues(w.op, "queued").Inc()
+	default:
+		metrics.CacheWriteRetriesTotal.WithLabelValues(w.op, "dropped").Inc()
+	}
+}
+
+// RunWriteRetries retries queued cache writes until ctx is cancelled. It
+// returns at once when write retries are disabled. Writes still queued at
+// shutdown stay queued for FlushWriteRetries.
+func (c *RedisCache) RunWriteRetries(ctx context.Context) {
+	if c.retries == nil {
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case w := <-c.retries:
+			if !sleepUntil(ctx, w.due) {
+				// Hand the write back so a final flush can still make it
+				select {
+				case c.retries <- w:
+				default:
+				}
+				return
+			}
+			c.retryWrite(ctx, w)
+		}
+	}
+}
+
+// FlushWriteRetries makes one last attempt at every queued write once
+// RunWriteRetries has returned, without waiting for backoff, and reports how
+// many were written and how many were dropped because they failed, had gone
+// stale or ctx ended first. It is meant for shutdown.
+func (c *RedisCache) FlushWriteRetries(ctx context.Context) (written, dropped int) {
+	if c.retries == nil {
+		return 0, 0
+	}
+	for {
+		select {
+		case w := <-c.retries:
+			switch {
+			case ctx.Err() != nil:
+				metrics.CacheWriteRetriesTotal.WithLabelValues(w.op, "dropped").Inc()
+				dropped++
+			case c.invalidations.Load() != w.generation:
+				metrics.CacheWriteRetriesTotal.WithLabelValues(w.op, "skipped").Inc()
+				dropped++
+			case w.write(ctx) != nil:
+				metrics.CacheWriteRetriesTotal.WithLabelValues(w.op, "failed").Inc()
+				dropped++
+			default:
+				metrics.CacheWriteRetriesTotal.WithLabelValues(w.op, "succeeded").Inc()
+				written++
+			}
+		default:
+			return written, dropped
+		}
+	}
+}
+
+// retryWrite makes one more attempt at w and requeues it on failure while
+// attempts remain
+func (c *RedisCache) retryWrite(ctx context.Context, w pendingWrite) {
+	if c.invalidations.Load() != w.generation {
+		metrics.CacheWriteRetriesTotal.WithLabelValues(w.op, "skipped").Inc()
+		return
+	}
+
+	if err := w.write(ctx); err == nil {
+		metrics.CacheWriteRetriesTotal.WithLabelValues(w.op, "succeeded").Inc()
+		return
+	}
+
+	w.attempt++
+	if w.attempt >= c.retryAttempts {
+		metrics.CacheWriteRetriesTotal.WithLabelValues(w.op, "failed").Inc()
+		return
+	}
+	w.due = time.Now().Add(c.retryBackoff << (w.attempt - 1))
+	c.enqueueRetry(w)
+}
+
+// invalidated records that cached entries were removed, which makes every
+// queued retry stale
+func (c *RedisCache) invalidated() {
+	c.invalidations.Add(1)
+}
+
+// sleepUntil waits until t and reports false if ctx was cancelled first
+func sleepUntil(ctx context.Context, t time.Time) bool {
+	wait := time.Until(t)
+	if wait <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
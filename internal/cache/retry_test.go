@@ -0,0 +1,146 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Ali-Gorgani/task-manager/internal/metrics"
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/go-redis/redismock/v9"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisCache_WriteRetry(t *testing.T) {
+	task := models.NewTask("Test Task", "Description", "test@example.com", models.TaskStatusPending)
+	taskData, _ := json.Marshal(task)
+	outcome := func(outcome string) float64 {
+		return testutil.ToFloat64(metrics.CacheWriteRetriesTotal.WithLabelValues("SetTask", outcome))
+	}
+
+	t.Run("Succeeds on a later attempt", func(t *testing.T) {
+		db, mock := redismock.NewClientMock()
+		cache := NewRedisCache(db, WithWriteRetry(4, 3, time.Millisecond))
+		succeeded := outcome("succeeded")
+
+		mock.ExpectSet("task:"+task.ID, taskData, cacheTTL).SetErr(assert.AnError)
+		mock.ExpectSet("task:"+task.ID, taskData, cacheTTL).SetErr(assert.AnError)
+		mock.ExpectSet("task:"+task.ID, taskData, cacheTTL).SetVal("OK")
+
+		// The caller still sees the original failure
+		assert.Error(t, cache.SetTask(context.Background(), task))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go cache.RunWriteRetries(ctx)
+
+		require.Eventually(t, func() bool { return outcome("succeeded") == succeeded+1 }, time.Second, time.Millisecond)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Gives up after the last attempt", func(t *testing.T) {
+		db, mock := redismock.NewClientMock()
+		cache := NewRedisCache(db, WithWriteRetry(4, 2, time.Millisecond))
+		failed := outcome("failed")
+
+		mock.ExpectSet("task:"+task.ID, taskData, cacheTTL).SetErr(assert.AnError)
+		mock.ExpectSet("task:"+task.ID, taskData, cacheTTL).SetErr(assert.AnError)
+
+		assert.Error(t, cache.SetTask(context.Background(), task))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go cache.RunWriteRetries(ctx)
+
+		require.Eventually(t, func() bool { return outcome("failed") == failed+1 }, time.Second, time.Millisecond)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Skips writes made stale by an invalidation", func(t *testing.T) {
+		db, mock := redismock.NewClientMock()
+		cache := NewRedisCache(db, WithWriteRetry(4, 3, time.Millisecond))
+		skipped := outcome("skipped")
+
+		mock.ExpectSet("task:"+task.ID, taskData, cacheTTL).SetErr(assert.AnError)
+		mock.ExpectDel("task:" + task.ID).SetVal(0)
+
+		assert.Error(t, cache.SetTask(context.Background(), task))
+		assert.NoError(t, cache.DeleteTask(context.Background(), task.ID))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go cache.RunWriteRetries(ctx)
+
+		require.Eventually(t, func() bool { return outcome("skipped") == skipped+1 }, time.Second, time.Millisecond)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Drops writes when the queue is full", func(t *testing.T) {
+		db, mock := redismock.NewClientMock()
+		cache := NewRedisCache(db, WithWriteRetry(1, 3, time.Millisecond))
+		dropped := outcome("dropped")
+
+		mock.ExpectSet("task:"+task.ID, taskData, cacheTTL).SetErr(assert.AnError)
+		mock.ExpectSet("task:"+task.ID, taskData, cacheTTL).SetErr(assert.AnError)
+
+		assert.Error(t, cache.SetTask(context.Background(), task))
+		assert.Error(t, cache.SetTask(context.Background(), task))
+		assert.Equal(t, dropped+1, outcome("dropped"))
+		assert.Len(t, cache.retries, 1)
+	})
+
+	t.Run("Flush writes what is still queued", func(t *testing.T) {
+		db, mock := redismock.NewClientMock()
+		// A long backoff keeps both writes queued until the flush
+		cache := NewRedisCache(db, WithWriteRetry(4, 3, time.Hour))
+		other := models.NewTask("Other Task", "Description", "test@example.com", models.TaskStatusPending)
+		otherData, _ := json.Marshal(other)
+
+		mock.ExpectSet("task:"+task.ID, taskData, cacheTTL).SetErr(assert.AnError)
+		mock.ExpectSet("task:"+other.ID, otherData, cacheTTL).SetErr(assert.AnError)
+		mock.ExpectSet("task:"+task.ID, taskData, cacheTTL).SetVal("OK")
+		mock.ExpectSet("task:"+other.ID, otherData, cacheTTL).SetErr(assert.AnError)
+
+		assert.Error(t, cache.SetTask(context.Background(), task))
+		assert.Error(t, cache.SetTask(context.Background(), other))
+
+		written, dropped := cache.FlushWriteRetries(context.Background())
+		assert.Equal(t, 1, written)
+		assert.Equal(t, 1, dropped)
+		assert.Empty(t, cache.retries)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Flush drops everything once its context ends", func(t *testing.T) {
+		db, mock := redismock.NewClientMock()
+		cache := NewRedisCache(db, WithWriteRetry(4, 3, time.Hour))
+
+		mock.ExpectSet("task:"+task.ID, taskData, cacheTTL).SetErr(assert.AnError)
+		assert.Error(t, cache.SetTask(context.Background(), task))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		written, dropped := cache.FlushWriteRetries(ctx)
+		assert.Equal(t, 0, written)
+		assert.Equal(t, 1, dropped)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Disabled by default", func(t *testing.T) {
+		db, mock := redismock.NewClientMock()
+		cache := NewRedisCache(db)
+
+		mock.ExpectSet("task:"+task.ID, taskData, cacheTTL).SetErr(assert.AnError)
+
+		assert.Error(t, cache.SetTask(context.Background(), task))
+		assert.Nil(t, cache.retries)
+
+		// Returns at once instead of blocking
+		cache.RunWriteRetries(context.Background())
+		written, dropped := cache.FlushWriteRetries(context.Background())
+		assert.Zero(t, written+dropped)
+	})
+}
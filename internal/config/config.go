@@ -3,21 +3,85 @@ package config
 import (
 	"fmt"
 	"log"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 // Config holds application configuration
 type Config struct {
-	ServerPort    string
-	DatabaseURL   string
-	RedisURL      string
-	RedisPassword string
-	RedisDB       int
-	Environment   string
+	ConfigFile                 string
+	ServerPort                 string
+	DatabaseURL                string
+	RedisURL                   string
+	RedisPassword              string
+	RedisDB                    int
+	RedisMode                  string
+	RedisMasterName            string
+	RedisSentinelAddrs         []string
+	RedisClusterAddrs          []string
+	Environment                string
+	ListResponseFormat         string
+	DebugHTTP                  bool
+	DebugRedactFields          []string
+	MetricsCountInterval       time.Duration
+	CacheWarmOnStart           bool
+	ShutdownDrainTimeout       time.Duration
+	ShutdownFlushTimeout       time.Duration
+	AssigneeWebhookURL         string
+	SearchFields               []string
+	SortCollation              string
+	UniqueTitlePerAssignee     bool
+	ResponseFieldCase          string
+	ListCacheMaxKeys           int
+	ListCacheCompactEvery      time.Duration
+	SanitizeInput              bool
+	SanitizeHTML               string
+	CountCacheTTL              time.Duration
+	StrictPageSize             bool
+	StrictJSON                 bool
+	ValidateTaskIDs            bool
+	CacheCompressMinBytes      int
+	HealthCheckInterval        time.Duration
+	HealthCheckTimeout         time.Duration
+	SlowQueryThreshold         time.Duration
+	MaxOffset                  int
+	StatusExpiryInterval       time.Duration
+	StatusExpiryAge            time.Duration
+	StatusExpiryFrom           string
+	StatusExpiryTo             string
+	StatusAssignees            []string
+	MaxConcurrentRequests      int
+	MaxRequestBodyBytes        int64
+	FullListCacheMaxRows       int
+	RequiredFields             []string
+	ImportMaxClockSkew         time.Duration
+	ServiceVersion             string
+	DefaultSortBy              string
+	DefaultSortOrder           string
+	WebhookSecret              string
+	PageTokenSecret            string
+	WebhookTimeout             time.Duration
+	WebhookMaxAttempts         int
+	WebhookRetryBackoff        time.Duration
+	WebhookQueueSize           int
+	MaintenanceMode            bool
+	MaintenanceRetryAfter      time.Duration
+	CacheWriteRetryQueue       int
+	CacheWriteRetryMaxAttempts int
+	CacheWriteRetryBackoff     time.Duration
 }
 
-// LoadConfig loads configuration from .env file or environment variables
+// fatalf stops the process when configuration cannot be loaded. Tests
+// replace it to observe the failure.
+var fatalf = log.Fatalf
+
+// LoadConfig loads configuration from the .env file, the optional YAML or
+// JSON file named by CONFIG_FILE and environment variables, in increasing
+// order of precedence
 func LoadConfig() *Config {
 	// Set config name and type
 	viper.SetConfigName(".env")
@@ -36,7 +100,60 @@ func LoadConfig() *Config {
 	viper.SetDefault("REDIS_URL", "localhost:6379")
 	viper.SetDefault("REDIS_PASSWORD", "")
 	viper.SetDefault("REDIS_DB", 0)
+	viper.SetDefault("REDIS_MODE", "standalone")
+	viper.SetDefault("REDIS_MASTER_NAME", "")
+	viper.SetDefault("REDIS_SENTINEL_ADDRS", "")
+	viper.SetDefault("REDIS_CLUSTER_ADDRS", "")
 	viper.SetDefault("ENVIRONMENT", "development")
+	viper.SetDefault("LIST_RESPONSE_FORMAT", "flat")
+	viper.SetDefault("DEBUG_HTTP", false)
+	viper.SetDefault("DEBUG_HTTP_REDACT_FIELDS", "password,token,secret,authorization")
+	viper.SetDefault("METRICS_COUNT_INTERVAL", "30s")
+	viper.SetDefault("CACHE_WARM_ON_START", false)
+	viper.SetDefault("SHUTDOWN_DRAIN_TIMEOUT", "5s")
+	viper.SetDefault("SHUTDOWN_FLUSH_TIMEOUT", "5s")
+	viper.SetDefault("ASSIGNEE_WEBHOOK_URL", "")
+	viper.SetDefault("SEARCH_FIELDS", "title,description")
+	viper.SetDefault("SORT_COLLATION", "")
+	viper.SetDefault("UNIQUE_TITLE_PER_ASSIGNEE", false)
+	viper.SetDefault("RESPONSE_FIELD_CASE", "snake")
+	viper.SetDefault("LIST_CACHE_MAX_KEYS", 1000)
+	viper.SetDefault("LIST_CACHE_COMPACT_INTERVAL", "1m")
+	viper.SetDefault("SANITIZE_INPUT", false)
+	viper.SetDefault("SANITIZE_HTML", "keep")
+	viper.SetDefault("COUNT_CACHE_TTL", "10s")
+	viper.SetDefault("STRICT_PAGE_SIZE", false)
+	viper.SetDefault("STRICT_JSON", false)
+	viper.SetDefault("VALIDATE_TASK_IDS", false)
+	viper.SetDefault("CACHE_COMPRESS_MIN_BYTES", 0)
+	viper.SetDefault("HEALTH_CHECK_INTERVAL", "10s")
+	viper.SetDefault("HEALTH_CHECK_TIMEOUT", "2s")
+	viper.SetDefault("SLOW_QUERY_THRESHOLD", "500ms")
+	viper.SetDefault("MAX_OFFSET", 10000)
+	viper.SetDefault("STATUS_EXPIRY_INTERVAL", "0")
+	viper.SetDefault("STATUS_EXPIRY_AGE", "720h")
+	viper.SetDefault("STATUS_EXPIRY_FROM", "pending")
+	viper.SetDefault("STATUS_EXPIRY_TO", "cancelled")
+	viper.SetDefault("STATUS_ASSIGNEES", "")
+	viper.SetDefault("MAX_CONCURRENT_REQUESTS", 0)
+	viper.SetDefault("MAX_REQUEST_BODY_BYTES", 1<<20)
+	viper.SetDefault("FULL_LIST_CACHE_MAX_ROWS", 0)
+	viper.SetDefault("REQUIRED_FIELDS", "title")
+	viper.SetDefault("IMPORT_MAX_CLOCK_SKEW", "5m")
+	viper.SetDefault("SERVICE_VERSION", "dev")
+	viper.SetDefault("DEFAULT_SORT_BY", "created_at")
+	viper.SetDefault("DEFAULT_SORT_ORDER", "desc")
+	viper.SetDefault("WEBHOOK_SECRET", "")
+	viper.SetDefault("PAGE_TOKEN_SECRET", "")
+	viper.SetDefault("WEBHOOK_TIMEOUT", "5s")
+	viper.SetDefault("WEBHOOK_MAX_ATTEMPTS", 3)
+	viper.SetDefault("WEBHOOK_RETRY_BACKOFF", "1s")
+	viper.SetDefault("WEBHOOK_QUEUE_SIZE", 100)
+	viper.SetDefault("MAINTENANCE_MODE", false)
+	viper.SetDefault("MAINTENANCE_RETRY_AFTER", "60s")
+	viper.SetDefault("CACHE_WRITE_RETRY_QUEUE", 0)
+	viper.SetDefault("CACHE_WRITE_RETRY_MAX_ATTEMPTS", 3)
+	viper.SetDefault("CACHE_WRITE_RETRY_BACKOFF", "200ms")
 
 	// Try to read .env file (not required, just optional)
 	if err := viper.ReadInConfig(); err != nil {
@@ -49,14 +166,116 @@ func LoadConfig() *Config {
 		log.Printf("Using .env file: %s", viper.ConfigFileUsed())
 	}
 
+	// A structured config file overrides .env but not environment variables
+	configFile := viper.GetString("CONFIG_FILE")
+	if configFile != "" {
+		if err := mergeConfigFile(configFile); err != nil {
+			// An explicitly requested file must not silently fall back to defaults
+			fatalf("Error reading config file %s: %v", configFile, err)
+		} else {
+			log.Printf("Using config file: %s", configFile)
+		}
+	}
+
 	return &Config{
-		ServerPort:    viper.GetString("SERVER_PORT"),
-		DatabaseURL:   viper.GetString("DATABASE_URL"),
-		RedisURL:      viper.GetString("REDIS_URL"),
-		RedisPassword: viper.GetString("REDIS_PASSWORD"),
-		RedisDB:       viper.GetInt("REDIS_DB"),
-		Environment:   viper.GetString("ENVIRONMENT"),
+		ConfigFile:                 configFile,
+		ServerPort:                 viper.GetString("SERVER_PORT"),
+		DatabaseURL:                viper.GetString("DATABASE_URL"),
+		RedisURL:                   viper.GetString("REDIS_URL"),
+		RedisPassword:              viper.GetString("REDIS_PASSWORD"),
+		RedisDB:                    viper.GetInt("REDIS_DB"),
+		RedisMode:                  viper.GetString("REDIS_MODE"),
+		RedisMasterName:            viper.GetString("REDIS_MASTER_NAME"),
+		RedisSentinelAddrs:         listSetting("REDIS_SENTINEL_ADDRS"),
+		RedisClusterAddrs:          listSetting("REDIS_CLUSTER_ADDRS"),
+		Environment:                viper.GetString("ENVIRONMENT"),
+		ListResponseFormat:         viper.GetString("LIST_RESPONSE_FORMAT"),
+		DebugHTTP:                  viper.GetBool("DEBUG_HTTP"),
+		DebugRedactFields:          listSetting("DEBUG_HTTP_REDACT_FIELDS"),
+		MetricsCountInterval:       viper.GetDuration("METRICS_COUNT_INTERVAL"),
+		CacheWarmOnStart:           viper.GetBool("CACHE_WARM_ON_START"),
+		ShutdownDrainTimeout:       viper.GetDuration("SHUTDOWN_DRAIN_TIMEOUT"),
+		ShutdownFlushTimeout:       viper.GetDuration("SHUTDOWN_FLUSH_TIMEOUT"),
+		AssigneeWebhookURL:         viper.GetString("ASSIGNEE_WEBHOOK_URL"),
+		SearchFields:               listSetting("SEARCH_FIELDS"),
+		SortCollation:              viper.GetString("SORT_COLLATION"),
+		UniqueTitlePerAssignee:     viper.GetBool("UNIQUE_TITLE_PER_ASSIGNEE"),
+		ResponseFieldCase:          viper.GetString("RESPONSE_FIELD_CASE"),
+		ListCacheMaxKeys:           viper.GetInt("LIST_CACHE_MAX_KEYS"),
+		ListCacheCompactEvery:      viper.GetDuration("LIST_CACHE_COMPACT_INTERVAL"),
+		SanitizeInput:              viper.GetBool("SANITIZE_INPUT"),
+		SanitizeHTML:               viper.GetString("SANITIZE_HTML"),
+		CountCacheTTL:              viper.GetDuration("COUNT_CACHE_TTL"),
+		StrictPageSize:             viper.GetBool("STRICT_PAGE_SIZE"),
+		StrictJSON:                 viper.GetBool("STRICT_JSON"),
+		ValidateTaskIDs:            viper.GetBool("VALIDATE_TASK_IDS"),
+		CacheCompressMinBytes:      viper.GetInt("CACHE_COMPRESS_MIN_BYTES"),
+		HealthCheckInterval:        viper.GetDuration("HEALTH_CHECK_INTERVAL"),
+		HealthCheckTimeout:         viper.GetDuration("HEALTH_CHECK_TIMEOUT"),
+		SlowQueryThreshold:         viper.GetDuration("SLOW_QUERY_THRESHOLD"),
+		MaxOffset:                  viper.GetInt("MAX_OFFSET"),
+		StatusExpiryInterval:       viper.GetDuration("STATUS_EXPIRY_INTERVAL"),
+		StatusExpiryAge:            viper.GetDuration("STATUS_EXPIRY_AGE"),
+		StatusExpiryFrom:           viper.GetString("STATUS_EXPIRY_FROM"),
+		StatusExpiryTo:             viper.GetString("STATUS_EXPIRY_TO"),
+		StatusAssignees:            listSetting("STATUS_ASSIGNEES"),
+		MaxConcurrentRequests:      viper.GetInt("MAX_CONCURRENT_REQUESTS"),
+		MaxRequestBodyBytes:        viper.GetInt64("MAX_REQUEST_BODY_BYTES"),
+		FullListCacheMaxRows:       viper.GetInt("FULL_LIST_CACHE_MAX_ROWS"),
+		RequiredFields:             listSetting("REQUIRED_FIELDS"),
+		ImportMaxClockSkew:         viper.GetDuration("IMPORT_MAX_CLOCK_SKEW"),
+		ServiceVersion:             viper.GetString("SERVICE_VERSION"),
+		DefaultSortBy:              viper.GetString("DEFAULT_SORT_BY"),
+		DefaultSortOrder:           viper.GetString("DEFAULT_SORT_ORDER"),
+		WebhookSecret:              viper.GetString("WEBHOOK_SECRET"),
+		PageTokenSecret:            viper.GetString("PAGE_TOKEN_SECRET"),
+		WebhookTimeout:             viper.GetDuration("WEBHOOK_TIMEOUT"),
+		WebhookMaxAttempts:         viper.GetInt("WEBHOOK_MAX_ATTEMPTS"),
+		WebhookRetryBackoff:        viper.GetDuration("WEBHOOK_RETRY_BACKOFF"),
+		WebhookQueueSize:           viper.GetInt("WEBHOOK_QUEUE_SIZE"),
+		MaintenanceMode:            viper.GetBool("MAINTENANCE_MODE"),
+		MaintenanceRetryAfter:      viper.GetDuration("MAINTENANCE_RETRY_AFTER"),
+		CacheWriteRetryQueue:       viper.GetInt("CACHE_WRITE_RETRY_QUEUE"),
+		CacheWriteRetryMaxAttempts: viper.GetInt("CACHE_WRITE_RETRY_MAX_ATTEMPTS"),
+		CacheWriteRetryBackoff:     viper.GetDuration("CACHE_WRITE_RETRY_BACKOFF"),
+	}
+}
+
+// mergeConfigFile merges a YAML or JSON file into the configuration. Its
+// top-level keys are the environment variable names in any case, so
+// server_port sets SERVER_PORT.
+func mergeConfigFile(path string) error {
+	ext := strings.TrimPrefix(filepath.Ext(path), ".")
+	switch ext {
+	case "yaml", "yml", "json":
+	default:
+		return fmt.Errorf("unsupported config file type %q, expected yaml or json", ext)
+	}
+	viper.SetConfigFile(path)
+	viper.SetConfigType(ext)
+	return viper.MergeInConfig()
+}
+
+// listSetting reads a list setting given either as a comma-separated string
+// (environment, .env) or as a list (YAML, JSON)
+func listSetting(key string) []string {
+	switch viper.Get(key).(type) {
+	case []interface{}, []string:
+		return splitList(strings.Join(viper.GetStringSlice(key), ","))
+	default:
+		return splitList(viper.GetString(key))
+	}
+}
+
+// splitList parses a comma-separated config value, dropping empty entries
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
+	return items
 }
 
 // IsDevelopment returns true if running in development mode
@@ -68,3 +287,218 @@ func (c *Config) IsDevelopment() bool {
 func (c *Config) GetServerAddress() string {
 	return fmt.Sprintf(":%s", c.ServerPort)
 }
+
+// UseEnvelopeResponse returns true if list responses should use the {data, meta} shape
+func (c *Config) UseEnvelopeResponse() bool {
+	return c.ListResponseFormat == "envelope"
+}
+
+// UseLinksOnlyResponse returns true if list responses should be a bare tasks
+// array with pagination only in headers
+func (c *Config) UseLinksOnlyResponse() bool {
+	return c.ListResponseFormat == "links"
+}
+
+// UseCamelCaseFields returns true if response keys should be camelCase
+func (c *Config) UseCamelCaseFields() bool {
+	return c.ResponseFieldCase == "camel"
+}
+
+// DebugHTTPEnabled returns true if request/response bodies should be logged
+func (c *Config) DebugHTTPEnabled() bool {
+	return c.IsDevelopment() || c.DebugHTTP
+}
+
+// prettyJSONEnvironments are the environments where clients may ask for
+// indented JSON. Any other value, including variants like prod, is treated
+// as production.
+var prettyJSONEnvironments = map[string]bool{
+	"development": true,
+	"test":        true,
+	"staging":     true,
+}
+
+// PrettyJSONAllowed returns true if clients may request indented JSON with
+// ?pretty=true, which only the environments in prettyJSONEnvironments allow
+func (c *Config) PrettyJSONAllowed() bool {
+	return prettyJSONEnvironments[c.Environment]
+}
+
+// redactedValue replaces secrets in the startup summary
+const redactedValue = "****"
+
+// Redacted returns the effective configuration as ordered key=value pairs
+// with passwords and URL credentials masked, for logging at startup
+func (c *Config) Redacted() string {
+	redisPassword := ""
+	if c.RedisPassword != "" {
+		redisPassword = redactedValue
+	}
+	webhookSecret := ""
+	if c.WebhookSecret != "" {
+		webhookSecret = redactedValue
+	}
+	pageTokenSecret := ""
+	if c.PageTokenSecret != "" {
+		pageTokenSecret = redactedValue
+	}
+
+	pairs := []struct {
+		key   string
+		value interface{}
+	}{
+		{"config_file", c.ConfigFile},
+		{"environment", c.Environment},
+		{"port", c.ServerPort},
+		{"database_url", redactURL(c.DatabaseURL)},
+		{"redis_url", c.RedisURL},
+		{"redis_password", redisPassword},
+		{"redis_db", c.RedisDB},
+		{"redis_mode", c.RedisMode},
+		{"redis_master_name", c.RedisMasterName},
+		{"redis_sentinel_addrs", strings.Join(c.RedisSentinelAddrs, ",")},
+		{"redis_cluster_addrs", strings.Join(c.RedisClusterAddrs, ",")},
+		{"list_response_format", c.ListResponseFormat},
+		{"response_field_case", c.ResponseFieldCase},
+		{"debug_http", c.DebugHTTPEnabled()},
+		{"metrics_count_interval", c.MetricsCountInterval},
+		{"cache_warm_on_start", c.CacheWarmOnStart},
+		{"list_cache_max_keys", c.ListCacheMaxKeys},
+		{"list_cache_compact_interval", c.ListCacheCompactEvery},
+		{"shutdown_drain_timeout", c.ShutdownDrainTimeout},
+		{"shutdown_flush_timeout", c.ShutdownFlushTimeout},
+		{"assignee_webhook_url", redactURL(c.AssigneeWebhookURL)},
+		{"search_fields", strings.Join(c.SearchFields, ",")},
+		{"sort_collation", c.SortCollation},
+		{"unique_title_per_assignee", c.UniqueTitlePerAssignee},
+		{"sanitize_input", c.SanitizeInput},
+		{"sanitize_html", c.SanitizeHTML},
+		{"count_cache_ttl", c.CountCacheTTL},
+		{"strict_page_size", c.StrictPageSize},
+		{"strict_json", c.StrictJSON},
+		{"validate_task_ids", c.ValidateTaskIDs},
+		{"cache_compress_min_bytes", c.CacheCompressMinBytes},
+		{"health_check_interval", c.HealthCheckInterval},
+		{"health_check_timeout", c.HealthCheckTimeout},
+		{"slow_query_threshold", c.SlowQueryThreshold},
+		{"max_offset", c.MaxOffset},
+		{"status_expiry_interval", c.StatusExpiryInterval},
+		{"status_expiry_age", c.StatusExpiryAge},
+		{"status_expiry_from", c.StatusExpiryFrom},
+		{"status_expiry_to", c.StatusExpiryTo},
+		{"status_assignees", strings.Join(c.StatusAssignees, ",")},
+		{"max_concurrent_requests", c.MaxConcurrentRequests},
+		{"full_list_cache_max_rows", c.FullListCacheMaxRows},
+		{"required_fields", strings.Join(c.RequiredFields, ",")},
+		{"import_max_clock_skew", c.ImportMaxClockSkew},
+		{"service_version", c.ServiceVersion},
+		{"default_sort_by", c.DefaultSortBy},
+		{"default_sort_order", c.DefaultSortOrder},
+		{"webhook_secret", webhookSecret},
+		{"page_token_secret", pageTokenSecret},
+		{"webhook_timeout", c.WebhookTimeout},
+		{"webhook_max_attempts", c.WebhookMaxAttempts},
+		{"webhook_retry_backoff", c.WebhookRetryBackoff},
+		{"webhook_queue_size", c.WebhookQueueSize},
+		{"maintenance_mode", c.MaintenanceMode},
+		{"maintenance_retry_after", c.MaintenanceRetryAfter},
+		{"cache_write_retry_queue", c.CacheWriteRetryQueue},
+		{"cache_write_retry_max_attempts", c.CacheWriteRetryMaxAttempts},
+		{"cache_write_retry_backoff", c.CacheWriteRetryBackoff},
+	}
+
+	parts := make([]string, len(pairs))
+	for i, pair := range pairs {
+		parts[i] = fmt.Sprintf("%s=%v", pair.key, pair.value)
+	}
+	return strings.Join(parts, " ")
+}
+
+// redactURL masks the password and query string of a URL, or the password
+// of a key=value connection string such as "host=db password=secret".
+// Values that parse as neither are masked entirely since they may embed
+// credentials.
+func redactURL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" {
+		if redacted, ok := redactKeyValueDSN(raw); ok {
+			return redacted
+		}
+		return redactedValue
+	}
+	if u.RawQuery != "" {
+		query := u.Query()
+		for key := range query {
+			if key != "sslmode" {
+				query.Set(key, redactedValue)
+			}
+		}
+		u.RawQuery = query.Encode()
+	}
+	return u.Redacted()
+}
+
+// redactKeyValueDSN masks the password of a libpq key=value connection
+// string. Values may be single-quoted with backslash escapes. It reports
+// false when raw is not such a string.
+func redactKeyValueDSN(raw string) (string, bool) {
+	var parts []string
+	s := strings.TrimSpace(raw)
+	for s != "" {
+		eq := strings.IndexByte(s, '=')
+		if eq <= 0 {
+			return "", false
+		}
+		key := strings.TrimSpace(s[:eq])
+		if !isDSNKey(key) {
+			return "", false
+		}
+		s = strings.TrimLeft(s[eq+1:], " \t")
+
+		var value string
+		if strings.HasPrefix(s, "'") {
+			end := 1
+			for ; end < len(s) && s[end] != '\''; end++ {
+				if s[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(s) {
+				return "", false
+			}
+			value, s = s[:end+1], s[end+1:]
+		} else {
+			end := strings.IndexAny(s, " \t")
+			if end < 0 {
+				end = len(s)
+			}
+			value, s = s[:end], s[end:]
+		}
+		s = strings.TrimLeft(s, " \t")
+
+		if key == "password" {
+			value = redactedValue
+		}
+		parts = append(parts, key+"="+value)
+	}
+	if len(parts) == 0 {
+		return "", false
+	}
+	return strings.Join(parts, " "), true
+}
+
+// isDSNKey reports whether key is a valid connection string keyword
+func isDSNKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, r := range key {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
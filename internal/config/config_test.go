@@ -1,7 +1,11 @@
 package config
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -18,6 +22,57 @@ func TestLoadConfig(t *testing.T) {
 		assert.Equal(t, "localhost:6379", cfg.RedisURL)
 		assert.Equal(t, "development", cfg.Environment)
 		assert.Equal(t, 0, cfg.RedisDB)
+		assert.Equal(t, "standalone", cfg.RedisMode)
+		assert.Empty(t, cfg.RedisSentinelAddrs)
+		assert.Empty(t, cfg.RedisClusterAddrs)
+		assert.Equal(t, "flat", cfg.ListResponseFormat)
+		assert.False(t, cfg.DebugHTTP)
+		assert.Equal(t, []string{"password", "token", "secret", "authorization"}, cfg.DebugRedactFields)
+		assert.Equal(t, 30*time.Second, cfg.MetricsCountInterval)
+		assert.False(t, cfg.CacheWarmOnStart)
+		assert.Equal(t, 5*time.Second, cfg.ShutdownDrainTimeout)
+		assert.Equal(t, 5*time.Second, cfg.ShutdownFlushTimeout)
+		assert.Equal(t, []string{"title", "description"}, cfg.SearchFields)
+		assert.Empty(t, cfg.SortCollation)
+		assert.False(t, cfg.UniqueTitlePerAssignee)
+		assert.Equal(t, "snake", cfg.ResponseFieldCase)
+		assert.Equal(t, 1000, cfg.ListCacheMaxKeys)
+		assert.Equal(t, time.Minute, cfg.ListCacheCompactEvery)
+		assert.False(t, cfg.SanitizeInput)
+		assert.Equal(t, "keep", cfg.SanitizeHTML)
+		assert.Equal(t, 10*time.Second, cfg.CountCacheTTL)
+		assert.False(t, cfg.StrictPageSize)
+		assert.False(t, cfg.StrictJSON)
+		assert.False(t, cfg.ValidateTaskIDs)
+		assert.Equal(t, 0, cfg.CacheCompressMinBytes)
+		assert.Equal(t, 10*time.Second, cfg.HealthCheckInterval)
+		assert.Equal(t, 2*time.Second, cfg.HealthCheckTimeout)
+		assert.Equal(t, 500*time.Millisecond, cfg.SlowQueryThreshold)
+		assert.Equal(t, 10000, cfg.MaxOffset)
+		assert.Equal(t, time.Duration(0), cfg.StatusExpiryInterval)
+		assert.Equal(t, 30*24*time.Hour, cfg.StatusExpiryAge)
+		assert.Equal(t, "pending", cfg.StatusExpiryFrom)
+		assert.Equal(t, "cancelled", cfg.StatusExpiryTo)
+		assert.Empty(t, cfg.StatusAssignees)
+		assert.Equal(t, 0, cfg.MaxConcurrentRequests)
+		assert.Equal(t, int64(1<<20), cfg.MaxRequestBodyBytes)
+		assert.Equal(t, 0, cfg.FullListCacheMaxRows)
+		assert.Equal(t, []string{"title"}, cfg.RequiredFields)
+		assert.Equal(t, 5*time.Minute, cfg.ImportMaxClockSkew)
+		assert.Equal(t, "dev", cfg.ServiceVersion)
+		assert.Equal(t, "created_at", cfg.DefaultSortBy)
+		assert.Equal(t, "desc", cfg.DefaultSortOrder)
+		assert.Empty(t, cfg.WebhookSecret)
+		assert.Empty(t, cfg.PageTokenSecret)
+		assert.Equal(t, 5*time.Second, cfg.WebhookTimeout)
+		assert.Equal(t, 3, cfg.WebhookMaxAttempts)
+		assert.Equal(t, time.Second, cfg.WebhookRetryBackoff)
+		assert.Equal(t, 100, cfg.WebhookQueueSize)
+		assert.False(t, cfg.MaintenanceMode)
+		assert.Equal(t, 60*time.Second, cfg.MaintenanceRetryAfter)
+		assert.Equal(t, 0, cfg.CacheWriteRetryQueue)
+		assert.Equal(t, 3, cfg.CacheWriteRetryMaxAttempts)
+		assert.Equal(t, 200*time.Millisecond, cfg.CacheWriteRetryBackoff)
 	})
 
 	t.Run("Custom values via Viper", func(t *testing.T) {
@@ -29,6 +84,7 @@ func TestLoadConfig(t *testing.T) {
 		viper.Set("REDIS_PASSWORD", "secret")
 		viper.Set("REDIS_DB", 5)
 		viper.Set("ENVIRONMENT", "production")
+		viper.Set("METRICS_COUNT_INTERVAL", "0")
 
 		cfg := LoadConfig()
 		assert.Equal(t, "9000", cfg.ServerPort)
@@ -37,12 +93,102 @@ func TestLoadConfig(t *testing.T) {
 		assert.Equal(t, "secret", cfg.RedisPassword)
 		assert.Equal(t, 5, cfg.RedisDB)
 		assert.Equal(t, "production", cfg.Environment)
+		assert.Equal(t, time.Duration(0), cfg.MetricsCountInterval)
 
 		// Clean up
 		viper.Reset()
 	})
 }
 
+func TestLoadConfig_ConfigFile(t *testing.T) {
+	writeFile := func(t *testing.T, name, content string) string {
+		path := filepath.Join(t.TempDir(), name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	t.Run("YAML", func(t *testing.T) {
+		viper.Reset()
+		defer viper.Reset()
+		t.Setenv("CONFIG_FILE", writeFile(t, "config.yaml", `
+server_port: "8080"
+environment: production
+redis_db: 3
+count_cache_ttl: 30s
+strict_json: true
+search_fields:
+  - title
+  - assignee
+`))
+
+		cfg := LoadConfig()
+		assert.Equal(t, "8080", cfg.ServerPort)
+		assert.Equal(t, "production", cfg.Environment)
+		assert.Equal(t, 3, cfg.RedisDB)
+		assert.Equal(t, 30*time.Second, cfg.CountCacheTTL)
+		assert.True(t, cfg.StrictJSON)
+		assert.Equal(t, []string{"title", "assignee"}, cfg.SearchFields)
+		// Settings the file leaves out keep their defaults
+		assert.Equal(t, "localhost:6379", cfg.RedisURL)
+		assert.Contains(t, cfg.Redacted(), "config_file=")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		viper.Reset()
+		defer viper.Reset()
+		t.Setenv("CONFIG_FILE", writeFile(t, "config.json", `{"SERVER_PORT": "8081", "search_fields": "title,description,assignee"}`))
+
+		cfg := LoadConfig()
+		assert.Equal(t, "8081", cfg.ServerPort)
+		assert.Equal(t, []string{"title", "description", "assignee"}, cfg.SearchFields)
+	})
+
+	t.Run("Environment wins", func(t *testing.T) {
+		viper.Reset()
+		defer viper.Reset()
+		t.Setenv("CONFIG_FILE", writeFile(t, "config.yaml", "server_port: \"8080\"\nenvironment: production\n"))
+		t.Setenv("SERVER_PORT", "9090")
+
+		cfg := LoadConfig()
+		assert.Equal(t, "9090", cfg.ServerPort)
+		assert.Equal(t, "production", cfg.Environment)
+	})
+
+	failures := map[string]func(t *testing.T) string{
+		"Unsupported type": func(t *testing.T) string {
+			return writeFile(t, "config.toml", `server_port = "8080"`)
+		},
+		"Missing": func(t *testing.T) string {
+			return filepath.Join(t.TempDir(), "missing.yaml")
+		},
+		"Malformed": func(t *testing.T) string {
+			return writeFile(t, "config.yaml", "server_port: [8080\n")
+		},
+	}
+	for name, path := range failures {
+		t.Run(name+" is fatal", func(t *testing.T) {
+			viper.Reset()
+			defer viper.Reset()
+			t.Setenv("CONFIG_FILE", path(t))
+
+			original := fatalf
+			defer func() { fatalf = original }()
+			fatalf = func(format string, args ...interface{}) {
+				panic(fmt.Sprintf(format, args...))
+			}
+
+			var message string
+			func() {
+				defer func() { message, _ = recover().(string) }()
+				LoadConfig()
+			}()
+			assert.Contains(t, message, "Error reading config file")
+		})
+	}
+}
+
 func TestConfig_IsDevelopment(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -69,3 +215,119 @@ func TestConfig_GetServerAddress(t *testing.T) {
 	cfg.ServerPort = "9000"
 	assert.Equal(t, ":9000", cfg.GetServerAddress())
 }
+
+func TestConfig_UseEnvelopeResponse(t *testing.T) {
+	cfg := &Config{ListResponseFormat: "flat"}
+	assert.False(t, cfg.UseEnvelopeResponse())
+
+	cfg.ListResponseFormat = "envelope"
+	assert.True(t, cfg.UseEnvelopeResponse())
+}
+
+func TestConfig_UseLinksOnlyResponse(t *testing.T) {
+	cfg := &Config{ListResponseFormat: "flat"}
+	assert.False(t, cfg.UseLinksOnlyResponse())
+
+	cfg.ListResponseFormat = "links"
+	assert.True(t, cfg.UseLinksOnlyResponse())
+	assert.False(t, cfg.UseEnvelopeResponse())
+}
+
+func TestConfig_UseCamelCaseFields(t *testing.T) {
+	cfg := &Config{ResponseFieldCase: "snake"}
+	assert.False(t, cfg.UseCamelCaseFields())
+
+	cfg.ResponseFieldCase = "camel"
+	assert.True(t, cfg.UseCamelCaseFields())
+}
+
+func TestConfig_DebugHTTPEnabled(t *testing.T) {
+	tests := []struct {
+		name        string
+		environment string
+		debugHTTP   bool
+		expected    bool
+	}{
+		{"Development", "development", false, true},
+		{"Production", "production", false, false},
+		{"Production with flag", "production", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Environment: tt.environment, DebugHTTP: tt.debugHTTP}
+			assert.Equal(t, tt.expected, cfg.DebugHTTPEnabled())
+		})
+	}
+}
+
+func TestConfig_PrettyJSONAllowed(t *testing.T) {
+	cfg := &Config{Environment: "development"}
+	assert.True(t, cfg.PrettyJSONAllowed())
+
+	cfg.Environment = "staging"
+	assert.True(t, cfg.PrettyJSONAllowed())
+
+	cfg.Environment = "production"
+	assert.False(t, cfg.PrettyJSONAllowed())
+
+	for _, environment := range []string{"prod", "staging-prod", "Production", ""} {
+		cfg.Environment = environment
+		assert.False(t, cfg.PrettyJSONAllowed(), environment)
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, splitList(" a, ,b,"))
+	assert.Equal(t, []string{}, splitList(""))
+}
+
+func TestConfig_Redacted(t *testing.T) {
+	cfg := &Config{
+		ServerPort:         "3000",
+		Environment:        "production",
+		DatabaseURL:        "postgres://admin:hunter2@db:5432/tasks?sslmode=disable",
+		RedisPassword:      "redis-secret",
+		AssigneeWebhookURL: "https://hooks.example.com/notify?token=abc123",
+		WebhookSecret:      "signing-key",
+		PageTokenSecret:    "token-key",
+	}
+
+	out := cfg.Redacted()
+	assert.Contains(t, out, "port=3000")
+	assert.Contains(t, out, "environment=production")
+	assert.Contains(t, out, "database_url=postgres://admin:xxxxx@db:5432/tasks?sslmode=disable")
+	assert.Contains(t, out, "redis_password=****")
+	assert.NotContains(t, out, "hunter2")
+	assert.NotContains(t, out, "redis-secret")
+	assert.NotContains(t, out, "abc123")
+	assert.Contains(t, out, "webhook_secret=****")
+	assert.NotContains(t, out, "signing-key")
+	assert.Contains(t, out, "page_token_secret=****")
+	assert.NotContains(t, out, "token-key")
+}
+
+func TestRedactURL(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"URL", "postgres://admin:hunter2@db:5432/tasks?sslmode=disable", "postgres://admin:xxxxx@db:5432/tasks?sslmode=disable"},
+		{"Key value", "host=db user=u password=secret dbname=tasks", "host=db user=u password=**** dbname=tasks"},
+		{"Quoted password", `host=db password='se cr\'et' sslmode=disable`, "host=db password=**** sslmode=disable"},
+		{"Spaces around equals", "host = db password = secret", "host=db password=****"},
+		{"Unterminated quote", "host=db password='secret", "****"},
+		{"Neither", "db secret", "****"},
+		{"Socket URL", "postgres:///tasks?host=/run/pg&password=secret", "postgres:///tasks?host=%2A%2A%2A%2A&password=%2A%2A%2A%2A"},
+		{"Empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, redactURL(tt.raw))
+			if tt.raw != "" {
+				assert.NotContains(t, redactURL(tt.raw), "secret")
+			}
+		})
+	}
+}
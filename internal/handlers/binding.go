@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// strictJSONBinding decodes JSON like gin's default binding but rejects
+// fields the target struct does not declare
+type strictJSONBinding struct{}
+
+func (strictJSONBinding) Name() string {
+	return "json"
+}
+
+func (strictJSONBinding) Bind(req *http.Request, obj any) error {
+	if req == nil || req.Body == nil {
+		return errors.New("invalid request")
+	}
+	decoder := json.NewDecoder(req.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(obj); err != nil {
+		return err
+	}
+	if binding.Validator == nil {
+		return nil
+	}
+	return binding.Validator.ValidateStruct(obj)
+}
+
+// decodeJSON decodes the request body into obj like bindJSON but skips its
+// binding rules, for handlers that report validation failures themselves
+func (h *TaskHandler) decodeJSON(c *gin.Context, obj any) error {
+	if c.Request.Body == nil {
+		return errors.New("invalid request")
+	}
+	decoder := json.NewDecoder(c.Request.Body)
+	if h.strictJSON {
+		decoder.DisallowUnknownFields()
+	}
+	return decoder.Decode(obj)
+}
+
+// bindJSON decodes and validates the request body into obj. Unknown fields
+// are ignored unless the handler was built with WithStrictJSON.
+func (h *TaskHandler) bindJSON(c *gin.Context, obj any) error {
+	if h.strictJSON {
+		return c.ShouldBindWith(obj, strictJSONBinding{})
+	}
+	return c.ShouldBindJSON(obj)
+}
+
+// respondBindError writes the response for a request body that could not be
+// read or decoded: 413 when it exceeded the body size limit, 400 otherwise
+func respondBindError(c *gin.Context, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "request body too large"})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
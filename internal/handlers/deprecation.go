@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// deprecation describes an input form that still works but is scheduled to
+// change
+type deprecation struct {
+	// since is when the form was deprecated
+	since time.Time
+	// sunset is when the form stops working, zero while none is scheduled
+	sunset time.Time
+	// message tells the client what to send instead
+	message string
+}
+
+// pageSizeClampDeprecation covers page_size values above the maximum, which
+// are clamped today and will be rejected with 400 like strict_page_size=true
+var pageSizeClampDeprecation = deprecation{
+	since:   time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC),
+	sunset:  time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC),
+	message: "page_size above the maximum is clamped; it will be rejected with 400, send strict_page_size=true to opt in now",
+}
+
+// deprecate marks the response as relying on a deprecated input form with an
+// RFC 9745 Deprecation header, an RFC 8594 Sunset header and a 299 Warning
+// carrying the message. Each call adds its own Warning; when a response uses
+// several deprecated forms, Deprecation and Sunset come from the first.
+func deprecate(c *gin.Context, d deprecation) {
+	header := c.Writer.Header()
+	if header.Get("Deprecation") == "" {
+		header.Set("Deprecation", "@"+strconv.FormatInt(d.since.Unix(), 10))
+		if !d.sunset.IsZero() {
+			header.Set("Sunset", d.sunset.UTC().Format(http.TimeFormat))
+		}
+	}
+	header.Add("Warning", "299 - "+strconv.Quote(d.message))
+}
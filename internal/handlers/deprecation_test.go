@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/Ali-Gorgani/task-manager/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeprecate(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	first := deprecation{
+		since:   time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+		sunset:  time.Date(2026, time.July, 1, 0, 0, 0, 0, time.UTC),
+		message: `send "x" instead`,
+	}
+	deprecate(c, first)
+	deprecate(c, deprecation{since: time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC), message: "second"})
+
+	assert.Equal(t, "@1767225600", w.Header().Get("Deprecation"))
+	assert.Equal(t, "Wed, 01 Jul 2026 00:00:00 GMT", w.Header().Get("Sunset"))
+	assert.Equal(t, []string{`299 - "send \"x\" instead"`, `299 - "second"`}, w.Header().Values("Warning"))
+}
+
+func TestListTasks_PageSizeClampDeprecation(t *testing.T) {
+	t.Run("Clamped page size is flagged", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		mockRepo.On("GetAll", mock.Anything, mock.Anything).Return([]models.Task{}, 0, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks?page_size=500", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "100", w.Header().Get("X-Page-Size"))
+		assert.NotEmpty(t, w.Header().Get("Deprecation"))
+		assert.NotEmpty(t, w.Header().Get("Sunset"))
+		assert.Contains(t, w.Header().Get("Warning"), "strict_page_size=true")
+	})
+
+	t.Run("Page size within the maximum is not", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		mockRepo.On("GetAll", mock.Anything, mock.Anything).Return([]models.Task{}, 0, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks?page_size=100", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Deprecation"))
+		assert.Empty(t, w.Header().Get("Warning"))
+	})
+}
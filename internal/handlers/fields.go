@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+)
+
+// parseFieldSelection validates a comma-separated `fields` query value against
+// the task model. An empty value means no projection. The id field is always
+// included so clients can correlate results.
+func parseFieldSelection(raw string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	known := make(map[string]bool)
+	for _, field := range models.TaskJSONFields() {
+		known[field] = true
+	}
+
+	fields := []string{"id"}
+	seen := map[string]bool{"id": true}
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" || seen[field] {
+			continue
+		}
+		if !known[field] {
+			return nil, fmt.Errorf("unknown field: %s", field)
+		}
+		seen[field] = true
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// projectTask returns only the selected fields of a task, keyed by JSON name
+func projectTask(task *models.Task, fields []string) (map[string]interface{}, error) {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal task: %w", err)
+	}
+
+	var full map[string]interface{}
+	if err := json.Unmarshal(data, &full); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal task: %w", err)
+	}
+
+	projected := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		projected[field] = full[field]
+	}
+	return projected, nil
+}
+
+// projectTasks applies projectTask to every task in the list
+func projectTasks(tasks []models.Task, fields []string) ([]map[string]interface{}, error) {
+	projected := make([]map[string]interface{}, 0, len(tasks))
+	for i := range tasks {
+		item, err := projectTask(&tasks[i], fields)
+		if err != nil {
+			return nil, err
+		}
+		projected = append(projected, item)
+	}
+	return projected, nil
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFieldSelection(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected []string
+		wantErr  bool
+	}{
+		{"Empty", "", nil, false},
+		{"Single field", "title", []string{"id", "title"}, false},
+		{"Multiple with spaces and duplicates", "title, status,title,id", []string{"id", "title", "status"}, false},
+		{"Unknown field", "title,secret", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := parseFieldSelection(tt.raw)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, fields)
+		})
+	}
+}
+
+func TestProjectTask(t *testing.T) {
+	task := models.NewTask("Test", "Description", "test@example.com", models.TaskStatusPending)
+
+	projected, err := projectTask(task, []string{"id", "title", "status"})
+	assert.NoError(t, err)
+	assert.Len(t, projected, 3)
+	assert.Equal(t, task.ID, projected["id"])
+	assert.Equal(t, "Test", projected["title"])
+	assert.Equal(t, "pending", projected["status"])
+	assert.NotContains(t, projected, "description")
+}
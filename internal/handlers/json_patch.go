@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+// jsonPatchContentType is the RFC 6902 JSON Patch media type
+const jsonPatchContentType = "application/json-patch+json"
+
+// jsonPatchOps are the operations a task patch may contain
+var jsonPatchOps = map[string]bool{
+	"add":     true,
+	"replace": true,
+	"remove":  true,
+	"test":    true,
+	"copy":    true,
+	"move":    true,
+}
+
+// jsonPatchFields are the task fields a patch may touch. Fields marked false
+// cannot be removed because a task always has them.
+var jsonPatchFields = map[string]bool{
+	"title":       false,
+	"status":      false,
+	"description": true,
+	"assignee":    true,
+	"source":      true,
+	"external_id": true,
+}
+
+// immutableTaskFields are set by the server and rejected with a clearer
+// message than unknown paths
+var immutableTaskFields = map[string]bool{
+	"id":         true,
+	"created_at": true,
+	"updated_at": true,
+}
+
+// parseJSONPatch decodes an RFC 6902 JSON Patch document and checks every
+// operation against the allowed operations and paths. It returns the fields
+// the patch may change.
+func parseJSONPatch(body []byte) (jsonpatch.Patch, []string, error) {
+	patch, err := jsonpatch.DecodePatch(body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid json patch: %w", err)
+	}
+
+	var touched []string
+	for i, op := range patch {
+		fields, err := jsonPatchFieldsOf(op)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid json patch operation %d: %w", i, err)
+		}
+		touched = append(touched, fields...)
+	}
+	return patch, touched, nil
+}
+
+// applyJSONPatch applies a parsed patch to the current task and converts the
+// result into an update request. Operations are applied in order to the
+// task's JSON, so remove, test, copy and move see its real values and fail
+// on fields it does not have. Only the touched fields are read back.
+func applyJSONPatch(patch jsonpatch.Patch, touched []string, task *models.Task) (*models.UpdateTaskRequest, error) {
+	current, err := json.Marshal(task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode task: %w", err)
+	}
+	patched, err := patch.Apply(current)
+	if err != nil {
+		return nil, fmt.Errorf("invalid json patch: %w", err)
+	}
+
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(patched, &doc); err != nil {
+		return nil, fmt.Errorf("invalid json patch: %w", err)
+	}
+
+	req := &models.UpdateTaskRequest{}
+	for _, field := range touched {
+		raw, present := doc[field]
+		removed := !present || bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+		if removed && !jsonPatchFields[field] {
+			return nil, fmt.Errorf("%s cannot be removed", field)
+		}
+
+		if field == "status" {
+			var status models.TaskStatus
+			if err := json.Unmarshal(raw, &status); err != nil {
+				return nil, fmt.Errorf("invalid value for %s: %w", field, err)
+			}
+			req.Status = &status
+			continue
+		}
+
+		var value string
+		if !removed {
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return nil, fmt.Errorf("invalid value for %s: %w", field, err)
+			}
+		}
+
+		switch field {
+		case "title":
+			req.Title = &value
+		case "description":
+			req.Description = &value
+		case "assignee":
+			req.Assignee = &value
+		case "source":
+			req.Source = &value
+		case "external_id":
+			req.ExternalID = &value
+		}
+	}
+
+	return req, nil
+}
+
+// jsonPatchFieldsOf validates an operation against the allowed operations
+// and paths and returns the task fields it may change: none for test, the
+// target for the others, and also the source for move
+func jsonPatchFieldsOf(op jsonpatch.Operation) ([]string, error) {
+	kind := op.Kind()
+	if !jsonPatchOps[kind] {
+		return nil, fmt.Errorf("unsupported op %q", kind)
+	}
+
+	path, err := op.Path()
+	if err != nil {
+		return nil, err
+	}
+	field, err := jsonPatchField(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case "remove":
+		return []string{field}, nil
+	case "copy", "move":
+		from, err := op.From()
+		if err != nil {
+			return nil, err
+		}
+		source, err := jsonPatchField(from)
+		if err != nil {
+			return nil, err
+		}
+		if kind == "move" {
+			return []string{source, field}, nil
+		}
+		return []string{field}, nil
+	}
+
+	if _, hasValue := op["value"]; !hasValue {
+		return nil, errors.New("missing value")
+	}
+	if kind == "test" {
+		return nil, nil
+	}
+	return []string{field}, nil
+}
+
+// jsonPatchField returns the task field a patch path points at, rejecting
+// nested, immutable and unknown paths
+func jsonPatchField(path string) (string, error) {
+	field, ok := strings.CutPrefix(path, "/")
+	if !ok || strings.Contains(field, "/") {
+		return "", fmt.Errorf("unsupported path %q", path)
+	}
+	if immutableTaskFields[field] {
+		return "", fmt.Errorf("%s is immutable", field)
+	}
+	if _, allowed := jsonPatchFields[field]; !allowed {
+		return "", fmt.Errorf("unsupported path %q", path)
+	}
+	return field, nil
+}
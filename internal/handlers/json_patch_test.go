@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// patchTask parses patch and applies it to task
+func patchTask(patch string, task *models.Task) (*models.UpdateTaskRequest, error) {
+	parsed, touched, err := parseJSONPatch([]byte(patch))
+	if err != nil {
+		return nil, err
+	}
+	return applyJSONPatch(parsed, touched, task)
+}
+
+func TestJSONPatch(t *testing.T) {
+	newTask := func() *models.Task {
+		return models.NewTask("Title", "Desc", "user@example.com", models.TaskStatusPending)
+	}
+
+	t.Run("Add and replace fields", func(t *testing.T) {
+		req, err := patchTask(`[
+			{"op":"replace","path":"/title","value":"New"},
+			{"op":"add","path":"/status","value":"In Progress"},
+			{"op":"add","path":"/external_id","value":"PROJ-1"}
+		]`, newTask())
+		require.NoError(t, err)
+		assert.Equal(t, "New", *req.Title)
+		assert.Equal(t, models.TaskStatusInProgress, *req.Status)
+		assert.Equal(t, "PROJ-1", *req.ExternalID)
+		assert.Nil(t, req.Description)
+		assert.Nil(t, req.Assignee)
+	})
+
+	t.Run("Operations apply in order", func(t *testing.T) {
+		req, err := patchTask(`[
+			{"op":"replace","path":"/assignee","value":"a@example.com"},
+			{"op":"remove","path":"/assignee"},
+			{"op":"replace","path":"/description","value":"first"},
+			{"op":"replace","path":"/description","value":"second"}
+		]`, newTask())
+		require.NoError(t, err)
+		assert.Equal(t, "", *req.Assignee)
+		assert.Equal(t, "second", *req.Description)
+	})
+
+	t.Run("Test compares against the task", func(t *testing.T) {
+		req, err := patchTask(`[
+			{"op":"test","path":"/assignee","value":"user@example.com"},
+			{"op":"replace","path":"/assignee","value":"other@example.com"}
+		]`, newTask())
+		require.NoError(t, err)
+		assert.Equal(t, "other@example.com", *req.Assignee)
+		assert.Nil(t, req.Title)
+
+		_, err = patchTask(`[{"op":"test","path":"/assignee","value":""}]`, newTask())
+		assert.ErrorIs(t, err, jsonpatch.ErrTestFailed)
+	})
+
+	t.Run("Copy and move use the task's values", func(t *testing.T) {
+		task := newTask()
+		source := "jira"
+		task.Source = &source
+
+		req, err := patchTask(`[{"op":"copy","from":"/title","path":"/description"}]`, task)
+		require.NoError(t, err)
+		assert.Equal(t, "Title", *req.Description)
+		assert.Nil(t, req.Title)
+
+		req, err = patchTask(`[{"op":"move","from":"/source","path":"/external_id"}]`, task)
+		require.NoError(t, err)
+		assert.Equal(t, "jira", *req.ExternalID)
+		assert.Equal(t, "", *req.Source)
+	})
+
+	t.Run("Fields the task lacks", func(t *testing.T) {
+		for name, patch := range map[string]string{
+			"Remove":    `[{"op":"remove","path":"/source"}]`,
+			"Copy from": `[{"op":"copy","from":"/external_id","path":"/description"}]`,
+			"Test":      `[{"op":"test","path":"/source","value":""}]`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := patchTask(patch, newTask())
+				assert.Error(t, err)
+			})
+		}
+	})
+
+	t.Run("Rejected operations", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			patch string
+			want  string
+		}{
+			{"Immutable id", `[{"op":"replace","path":"/id","value":"x"}]`, "id is immutable"},
+			{"Immutable created_at", `[{"op":"remove","path":"/created_at"}]`, "created_at is immutable"},
+			{"Copy from immutable", `[{"op":"copy","from":"/id","path":"/description"}]`, "id is immutable"},
+			{"Unknown path", `[{"op":"add","path":"/priority","value":1}]`, "unsupported path"},
+			{"Nested path", `[{"op":"add","path":"/title/0","value":"x"}]`, "unsupported path"},
+			{"Unsupported op", `[{"op":"swap","path":"/title"}]`, "unsupported op"},
+			{"Missing value", `[{"op":"replace","path":"/title"}]`, "missing value"},
+			{"Remove required field", `[{"op":"remove","path":"/title"}]`, "title cannot be removed"},
+			{"Move required field", `[{"op":"move","from":"/title","path":"/description"}]`, "title cannot be removed"},
+			{"Wrong value type", `[{"op":"replace","path":"/assignee","value":5}]`, "invalid value for assignee"},
+			{"Not an array", `{"op":"replace"}`, "invalid json patch"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := patchTask(tt.patch, newTask())
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.want)
+			})
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+)
+
+// mergePatchContentType is the RFC 7386 JSON Merge Patch media type
+const mergePatchContentType = "application/merge-patch+json"
+
+// decodeMergePatch converts a JSON Merge Patch document into an update
+// request. Absent members leave a field unchanged and null clears it; title
+// and status cannot be cleared because a task always has both. Unknown members
+// are ignored unless strict is set.
+func decodeMergePatch(body []byte, strict bool) (*models.UpdateTaskRequest, error) {
+	var patch map[string]json.RawMessage
+	if err := json.Unmarshal(body, &patch); err != nil {
+		return nil, fmt.Errorf("invalid merge patch: %w", err)
+	}
+	if patch == nil {
+		return nil, errors.New("invalid merge patch: document must be an object")
+	}
+
+	req := &models.UpdateTaskRequest{}
+	empty := ""
+	for field, raw := range patch {
+		isNull := bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+
+		var err error
+		switch field {
+		case "title":
+			if isNull {
+				return nil, errors.New("title cannot be removed")
+			}
+			err = json.Unmarshal(raw, &req.Title)
+		case "status":
+			if isNull {
+				return nil, errors.New("status cannot be removed")
+			}
+			err = json.Unmarshal(raw, &req.Status)
+		case "description":
+			if isNull {
+				req.Description = &empty
+				continue
+			}
+			err = json.Unmarshal(raw, &req.Description)
+		case "assignee":
+			if isNull {
+				req.Assignee = &empty
+				continue
+			}
+			err = json.Unmarshal(raw, &req.Assignee)
+		case "source":
+			if isNull {
+				req.Source = &empty
+				continue
+			}
+			err = json.Unmarshal(raw, &req.Source)
+		case "external_id":
+			if isNull {
+				req.ExternalID = &empty
+				continue
+			}
+			err = json.Unmarshal(raw, &req.ExternalID)
+		default:
+			if strict {
+				return nil, fmt.Errorf("invalid merge patch: unknown field %q", field)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %w", field, err)
+		}
+	}
+
+	return req, nil
+}
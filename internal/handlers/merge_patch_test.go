@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeMergePatch(t *testing.T) {
+	t.Run("Set fields", func(t *testing.T) {
+		req, err := decodeMergePatch([]byte(`{"title":"New","status":"In Progress"}`), false)
+		require.NoError(t, err)
+		assert.Equal(t, "New", *req.Title)
+		assert.Equal(t, models.TaskStatusInProgress, *req.Status)
+		assert.Nil(t, req.Description)
+		assert.Nil(t, req.Assignee)
+	})
+
+	t.Run("Clear via null", func(t *testing.T) {
+		req, err := decodeMergePatch([]byte(`{"description":null,"assignee":null,"source":null,"external_id":null}`), false)
+		require.NoError(t, err)
+		assert.Equal(t, "", *req.Description)
+		assert.Equal(t, "", *req.Assignee)
+		assert.Equal(t, "", *req.Source)
+		assert.Equal(t, "", *req.ExternalID)
+		assert.Nil(t, req.Title)
+		assert.Nil(t, req.Status)
+	})
+
+	t.Run("Required fields cannot be cleared", func(t *testing.T) {
+		_, err := decodeMergePatch([]byte(`{"title":null}`), false)
+		assert.Error(t, err)
+
+		_, err = decodeMergePatch([]byte(`{"status":null}`), false)
+		assert.Error(t, err)
+	})
+
+	t.Run("Unknown members", func(t *testing.T) {
+		req, err := decodeMergePatch([]byte(`{"titel":"New"}`), false)
+		require.NoError(t, err)
+		assert.Nil(t, req.Title)
+
+		_, err = decodeMergePatch([]byte(`{"titel":"New"}`), true)
+		assert.Error(t, err)
+	})
+
+	t.Run("Not an object", func(t *testing.T) {
+		_, err := decodeMergePatch([]byte(`null`), false)
+		assert.Error(t, err)
+
+		_, err = decodeMergePatch([]byte(`["title"]`), false)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// render writes a JSON response, converting its keys to camelCase when the
+// handler is configured for camelCase field names and indenting it when the
+// client asked for ?pretty=true and pretty-printing is allowed
+func (h *TaskHandler) render(c *gin.Context, status int, body interface{}) {
+	if h.camelCaseFields {
+		camel, err := camelCaseKeys(body)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		body = camel
+	}
+
+	if h.prettyJSON {
+		if pretty, _ := strconv.ParseBool(c.Query("pretty")); pretty {
+			c.IndentedJSON(status, body)
+			return
+		}
+	}
+	c.JSON(status, body)
+}
+
+// camelCaseKeys round-trips body through JSON and rewrites the field names of
+// every object from snake_case to camelCase. Keys of typed maps such as
+// BoardResponse.Columns are data, not field names, and are left unchanged.
+func camelCaseKeys(body interface{}) (interface{}, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return rewriteKeys(value, reflect.ValueOf(body)), nil
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// rewriteKeys recursively converts the object keys in a decoded JSON value.
+// source is the Go value it was encoded from, used to tell struct fields and
+// ad-hoc objects such as gin.H, whose keys are rewritten, from typed maps,
+// whose keys are kept. Without a source every key is rewritten.
+func rewriteKeys(value interface{}, source reflect.Value) interface{} {
+	for source.IsValid() && (source.Kind() == reflect.Pointer || source.Kind() == reflect.Interface) {
+		source = source.Elem()
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		dataKeyed := source.IsValid() && source.Kind() == reflect.Map && source.Type().Elem().Kind() != reflect.Interface
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted := rewriteKeys(item, jsonChild(source, key))
+			if !dataKeyed {
+				key = snakeToCamel(key)
+			}
+			out[key] = converted
+		}
+		return out
+	case []interface{}:
+		for i, item := range v {
+			var elem reflect.Value
+			if source.IsValid() && (source.Kind() == reflect.Slice || source.Kind() == reflect.Array) && i < source.Len() {
+				elem = source.Index(i)
+			}
+			v[i] = rewriteKeys(item, elem)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// jsonChild returns the value encoded under key in source, or the zero
+// Value when it cannot be found
+func jsonChild(source reflect.Value, key string) reflect.Value {
+	if !source.IsValid() {
+		return reflect.Value{}
+	}
+
+	switch source.Kind() {
+	case reflect.Map:
+		if source.Type().Key().Kind() != reflect.String {
+			return reflect.Value{}
+		}
+		return source.MapIndex(reflect.ValueOf(key).Convert(source.Type().Key()))
+	case reflect.Struct:
+		t := source.Type()
+		if t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) {
+			return reflect.Value{}
+		}
+		index, ok := jsonFields(t)[key]
+		if !ok {
+			return reflect.Value{}
+		}
+		field, err := source.FieldByIndexErr(index)
+		if err != nil {
+			return reflect.Value{}
+		}
+		return field
+	default:
+		return reflect.Value{}
+	}
+}
+
+// jsonFields maps the JSON names of t's fields, including those promoted
+// from embedded structs, to their field index
+func jsonFields(t reflect.Type) map[string][]int {
+	fields := make(map[string][]int)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		embedded := f.Type
+		if embedded.Kind() == reflect.Pointer {
+			embedded = embedded.Elem()
+		}
+		if f.Anonymous && name == "" && embedded.Kind() == reflect.Struct {
+			for promoted, index := range jsonFields(embedded) {
+				if _, ok := fields[promoted]; !ok {
+					fields[promoted] = append([]int{i}, index...)
+				}
+			}
+			continue
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = []int{i}
+	}
+	return fields
+}
+
+// snakeToCamel converts a snake_case name such as created_at to createdAt
+func snakeToCamel(name string) string {
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnakeToCamel(t *testing.T) {
+	assert.Equal(t, "createdAt", snakeToCamel("created_at"))
+	assert.Equal(t, "nextPageToken", snakeToCamel("next_page_token"))
+	assert.Equal(t, "id", snakeToCamel("id"))
+}
+
+func TestCamelCaseKeys(t *testing.T) {
+	body := map[string]interface{}{
+		"total_pages": 3,
+		"tasks": []map[string]interface{}{
+			{"created_at": "2025-11-01T10:00:00Z"},
+		},
+	}
+
+	converted, err := camelCaseKeys(body)
+	require.NoError(t, err)
+
+	object := converted.(map[string]interface{})
+	assert.Contains(t, object, "totalPages")
+	assert.Contains(t, object["tasks"].([]interface{})[0], "createdAt")
+}
+
+func TestCamelCaseKeys_KeepsTypedMapKeys(t *testing.T) {
+	body := gin.H{
+		"completion_stats": map[string]int{"john_doe@example.com": 2},
+		"board": models.BoardResponse{Columns: map[models.TaskStatus]models.BoardColumn{
+			models.TaskStatusInProgress: {Tasks: []models.Task{}, Total: 0},
+		}},
+	}
+
+	converted, err := camelCaseKeys(body)
+	require.NoError(t, err)
+
+	object := converted.(map[string]interface{})
+	assert.Contains(t, object["completionStats"], "john_doe@example.com")
+	columns := object["board"].(map[string]interface{})["columns"].(map[string]interface{})
+	assert.Contains(t, columns, "in_progress")
+	assert.Contains(t, columns["in_progress"], "tasks")
+}
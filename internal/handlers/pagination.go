@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// setPaginationHeaders mirrors the pagination details of a list response in
+// X-Total-Count, X-Page, X-Page-Size and X-Total-Pages, plus an RFC 8288 Link
+// header pointing at the previous and next pages
+func setPaginationHeaders(c *gin.Context, response *models.TaskListResponse) {
+	c.Header("X-Total-Count", strconv.Itoa(response.Total))
+	c.Header("X-Page", strconv.Itoa(response.Page))
+	c.Header("X-Page-Size", strconv.Itoa(response.PageSize))
+	c.Header("X-Total-Pages", strconv.Itoa(response.TotalPages))
+
+	var links []string
+	if response.Page > 1 {
+		links = append(links, pageLink(c, response.Page-1, response.PageSize, "prev"))
+	}
+	if response.Page < response.TotalPages {
+		links = append(links, pageLink(c, response.Page+1, response.PageSize, "next"))
+	}
+	if len(links) > 0 {
+		c.Header("Link", strings.Join(links, ", "))
+	}
+}
+
+// pageLink builds a Link header entry for page of the current request,
+// keeping its filters. A page token would override the page, so it is dropped.
+func pageLink(c *gin.Context, page, pageSize int, rel string) string {
+	query := c.Request.URL.Query()
+	query.Del("page_token")
+	query.Set("page", strconv.Itoa(page))
+	query.Set("page_size", strconv.Itoa(pageSize))
+	return fmt.Sprintf(`<%s?%s>; rel="%s"`, c.Request.URL.Path, query.Encode(), rel)
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/Ali-Gorgani/task-manager/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListTasks_PaginationHeaders(t *testing.T) {
+	t.Run("Middle page links both ways", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		mockRepo.On("GetAll", mock.Anything, mock.Anything).Return([]models.Task{}, 25, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks?status=pending&page=2&page_size=10", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "25", w.Header().Get("X-Total-Count"))
+		assert.Equal(t, "2", w.Header().Get("X-Page"))
+		assert.Equal(t, "10", w.Header().Get("X-Page-Size"))
+		assert.Equal(t, "3", w.Header().Get("X-Total-Pages"))
+
+		links := parseLinks(t, w.Header().Get("Link"))
+		require.Contains(t, links, "prev")
+		require.Contains(t, links, "next")
+		assert.Equal(t, "/api/v1/tasks", links["next"].Path)
+		assert.Equal(t, "3", links["next"].Query().Get("page"))
+		assert.Equal(t, "10", links["next"].Query().Get("page_size"))
+		assert.Equal(t, "pending", links["next"].Query().Get("status"))
+		assert.Equal(t, "1", links["prev"].Query().Get("page"))
+	})
+
+	t.Run("Single page has no links", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		mockRepo.On("GetAll", mock.Anything, mock.Anything).Return([]models.Task{}, 3, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "3", w.Header().Get("X-Total-Count"))
+		assert.Equal(t, "1", w.Header().Get("X-Total-Pages"))
+		assert.Empty(t, w.Header().Get("Link"))
+	})
+}
+
+func TestListTasks_LinksOnlyResponse(t *testing.T) {
+	tasks := []models.Task{*models.NewTask("Task", "", "", models.TaskStatusPending)}
+
+	t.Run("Body is the bare tasks array", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil), WithLinksOnlyResponse(true))
+
+		mockRepo.On("GetAll", mock.Anything, mock.Anything).Return(tasks, 25, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks?page=1&page_size=10", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var body []models.Task
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		require.Len(t, body, 1)
+		assert.Equal(t, tasks[0].ID, body[0].ID)
+
+		assert.Equal(t, "25", w.Header().Get("X-Total-Count"))
+		assert.Equal(t, "3", w.Header().Get("X-Total-Pages"))
+		assert.NotEmpty(t, w.Header().Get("X-Next-Page-Token"))
+		assert.Contains(t, parseLinks(t, w.Header().Get("Link")), "next")
+	})
+
+	t.Run("Field selection projects the array", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil), WithLinksOnlyResponse(true))
+
+		mockRepo.On("GetAll", mock.Anything, mock.Anything).Return(tasks, 1, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks?fields=title", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var body []map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		require.Len(t, body, 1)
+		assert.Equal(t, map[string]interface{}{"id": tasks[0].ID, "title": "Task"}, body[0])
+		assert.Empty(t, w.Header().Get("X-Next-Page-Token"))
+	})
+}
+
+// parseLinks splits a Link header into its targets keyed by rel
+func parseLinks(t *testing.T, header string) map[string]*url.URL {
+	links := map[string]*url.URL{}
+	for _, part := range strings.Split(header, ", ") {
+		target, params, ok := strings.Cut(part, ">; ")
+		require.True(t, ok, part)
+		u, err := url.Parse(strings.TrimPrefix(target, "<"))
+		require.NoError(t, err)
+		links[strings.Trim(strings.TrimPrefix(params, "rel="), `"`)] = u
+	}
+	return links
+}
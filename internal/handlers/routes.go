@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// ListRoutes godoc
+// @Summary List registered routes
+// @Description List every route the server handles with its method, path and handler. Only available in development.
+// @Tags debug
+// @Produce json
+// @Success 200 {object} models.RoutesResponse
+// @Router /debug/routes [get]
+func ListRoutes(router *gin.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		routes := []models.RouteInfo{}
+		for _, route := range router.Routes() {
+			routes = append(routes, models.RouteInfo{
+				Method:  route.Method,
+				Path:    route.Path,
+				Handler: route.Handler,
+			})
+		}
+		sort.Slice(routes, func(i, j int) bool {
+			if routes[i].Path != routes[j].Path {
+				return routes[i].Path < routes[j].Path
+			}
+			return routes[i].Method < routes[j].Method
+		})
+		c.JSON(http.StatusOK, models.RoutesResponse{Routes: routes})
+	}
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListRoutes(t *testing.T) {
+	router := gin.New()
+	router.GET("/debug/routes", ListRoutes(router))
+	router.POST("/api/v1/tasks", func(c *gin.Context) {})
+	router.GET("/api/v1/tasks", func(c *gin.Context) {})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/debug/routes", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.RoutesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Routes, 3)
+	assert.Equal(t, "GET", response.Routes[0].Method)
+	assert.Equal(t, "/api/v1/tasks", response.Routes[0].Path)
+	assert.Equal(t, "POST", response.Routes[1].Method)
+	assert.Equal(t, "/debug/routes", response.Routes[2].Path)
+	assert.NotEmpty(t, response.Routes[2].Handler)
+}
@@ -2,49 +2,282 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/Ali-Gorgani/task-manager/internal/health"
+	"github.com/Ali-Gorgani/task-manager/internal/middleware"
 	"github.com/Ali-Gorgani/task-manager/internal/models"
 	"github.com/Ali-Gorgani/task-manager/internal/repository"
 	"github.com/Ali-Gorgani/task-manager/internal/service"
+	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/gin-gonic/gin"
 )
 
 // TaskHandler handles HTTP requests for tasks
 type TaskHandler struct {
-	service *service.TaskService
+	service          *service.TaskService
+	envelopeResponse bool
+	linksOnly        bool
+	camelCaseFields  bool
+	strictJSON       bool
+	validateIDs      bool
+	prettyJSON       bool
+	healthMonitor    *health.Monitor
+	version          string
+	maintenance      *middleware.Maintenance
+}
+
+// Option configures optional TaskHandler behaviour
+type Option func(*TaskHandler)
+
+// WithEnvelopeResponse wraps list responses as {"data": [...], "meta": {...}}
+func WithEnvelopeResponse(enabled bool) Option {
+	return func(h *TaskHandler) {
+		h.envelopeResponse = enabled
+	}
+}
+
+// WithLinksOnlyResponse renders list responses as a bare tasks array, for
+// clients that follow the Link and X-* pagination headers instead of reading
+// pagination from the body
+func WithLinksOnlyResponse(enabled bool) Option {
+	return func(h *TaskHandler) {
+		h.linksOnly = enabled
+	}
+}
+
+// WithCamelCaseFields renders response keys in camelCase (createdAt) instead
+// of the default snake_case (created_at)
+func WithCamelCaseFields(enabled bool) Option {
+	return func(h *TaskHandler) {
+		h.camelCaseFields = enabled
+	}
+}
+
+// WithStrictJSON rejects request bodies containing fields the endpoint does
+// not recognise with 400 instead of silently ignoring them
+func WithStrictJSON(enabled bool) Option {
+	return func(h *TaskHandler) {
+		h.strictJSON = enabled
+	}
+}
+
+// WithTaskIDValidation rejects :id path values that are not well-formed task
+// IDs with 400 instead of looking them up
+func WithTaskIDValidation(enabled bool) Option {
+	return func(h *TaskHandler) {
+		h.validateIDs = enabled
+	}
+}
+
+// WithPrettyJSON lets clients request indented JSON with ?pretty=true. It is
+// meant for debugging and should stay off in production.
+func WithPrettyJSON(enabled bool) Option {
+	return func(h *TaskHandler) {
+		h.prettyJSON = enabled
+	}
+}
+
+// WithHealthMonitor answers readiness probes from m's last result instead of
+// pinging dependencies on every request
+func WithHealthMonitor(m *health.Monitor) Option {
+	return func(h *TaskHandler) {
+		h.healthMonitor = m
+	}
+}
+
+// WithServiceVersion reports version in readiness responses
+func WithServiceVersion(version string) Option {
+	return func(h *TaskHandler) {
+		h.version = version
+	}
+}
+
+// WithMaintenance reports m's state on the health endpoints and lets the
+// admin maintenance endpoints toggle it
+func WithMaintenance(m *middleware.Maintenance) Option {
+	return func(h *TaskHandler) {
+		h.maintenance = m
+	}
 }
 
 // NewTaskHandler creates a new task handler
-func NewTaskHandler(service *service.TaskService) *TaskHandler {
-	return &TaskHandler{service: service}
+func NewTaskHandler(service *service.TaskService, opts ...Option) *TaskHandler {
+	h := &TaskHandler{service: service}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 // CreateTask godoc
 // @Summary Create a new task
-// @Description Create a new task with the provided information
+// @Description Create a new task with the provided information. With if_not_exists=external_id, a task with the same source and external_id is returned with 200 instead of creating a duplicate.
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Param task body models.CreateTaskRequest true "Task creation request"
+// @Param if_not_exists query string false "Return the existing task with the same source and external_id instead of creating one" Enums(external_id)
+// @Success 200 {object} models.Task "Existing task (if_not_exists=external_id)"
 // @Success 201 {object} models.Task
 // @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/v1/tasks [post]
 func (h *TaskHandler) CreateTask(c *gin.Context) {
 	var req models.CreateTaskRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := h.bindJSON(c, &req); err != nil {
+		respondBindError(c, err)
 		return
 	}
 
-	task, err := h.service.CreateTask(c.Request.Context(), &req)
+	var task *models.Task
+	var err error
+	created := true
+	switch c.Query("if_not_exists") {
+	case "":
+		task, err = h.service.CreateTask(c.Request.Context(), &req)
+	case "external_id":
+		task, created, err = h.service.CreateTaskIfNotExists(c.Request.Context(), &req)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "if_not_exists must be external_id"})
+		return
+	}
 	if err != nil {
+		if respondDuplicate(c, err) {
+			return
+		}
+		if respondMissingFields(c, err) {
+			return
+		}
+		if errors.Is(err, repository.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, task)
+	if !created {
+		h.render(c, http.StatusOK, task)
+		return
+	}
+	h.render(c, http.StatusCreated, task)
+}
+
+// ValidateTask godoc
+// @Summary Validate a task without creating it
+// @Description Run the checks a create applies (required title and fields, status, external reference) and report every failing field. Nothing is stored, so the result does not cover uniqueness. An invalid task is still a 200 with valid false; only a malformed body is a 400.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param task body models.CreateTaskRequest true "Task creation request"
+// @Success 200 {object} models.ValidationResult
+// @Failure 400 {object} map[string]string
+// @Router /api/v1/tasks/validate [post]
+func (h *TaskHandler) ValidateTask(c *gin.Context) {
+	var req models.CreateTaskRequest
+	if err := h.decodeJSON(c, &req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	h.render(c, http.StatusOK, h.service.ValidateTask(req))
+}
+
+// ImportTasks godoc
+// @Summary Import tasks with their original timestamps
+// @Description Create tasks migrated from another system, keeping the created_at and updated_at given in the request. updated_at defaults to created_at. Timestamps may not lie in the future beyond the configured clock skew. By default the import is atomic: if any task is invalid, none are created. With batch_size, tasks are imported in batches that succeed or fail independently; the response lists the outcome of each batch and is 207 when any failed.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param tasks body models.ImportTasksRequest true "Tasks to import"
+// @Success 201 {object} models.ImportTasksResponse
+// @Success 207 {object} models.ImportTasksResponse "Some batches failed"
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/tasks/import [post]
+func (h *TaskHandler) ImportTasks(c *gin.Context) {
+	var req models.ImportTasksRequest
+	if err := h.bindJSON(c, &req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	response, err := h.service.ImportTasks(c.Request.Context(), &req)
+	if err != nil {
+		if respondDuplicate(c, err) {
+			return
+		}
+		if respondMissingFields(c, err) {
+			return
+		}
+		if errors.Is(err, repository.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Some batches of a batched import failed
+	if response.Failed > 0 {
+		h.render(c, http.StatusMultiStatus, response)
+		return
+	}
+	h.render(c, http.StatusCreated, response)
+}
+
+// UpsertTaskByExternalID godoc
+// @Summary Create or replace a task by external reference
+// @Description Idempotently sync an item from an external system. The task with the given source and external ID is created if missing, otherwise its title, description, status and assignee are overwritten. Source and external ID in the body are ignored.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param source path string true "External system, e.g. jira"
+// @Param external_id path string true "ID of the item in the external system"
+// @Param task body models.CreateTaskRequest true "Task contents"
+// @Success 200 {object} models.Task "Existing task updated"
+// @Success 201 {object} models.Task "Task created"
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/tasks/external/{source}/{external_id} [put]
+func (h *TaskHandler) UpsertTaskByExternalID(c *gin.Context) {
+	var req models.CreateTaskRequest
+	if err := h.bindJSON(c, &req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	req.Source = c.Param("source")
+	req.ExternalID = c.Param("external_id")
+
+	task, created, err := h.service.UpsertTaskByExternalID(c.Request.Context(), &req)
+	if err != nil {
+		if respondDuplicate(c, err) {
+			return
+		}
+		if respondMissingFields(c, err) {
+			return
+		}
+		if errors.Is(err, repository.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	h.render(c, status, task)
 }
 
 // GetTask godoc
@@ -54,24 +287,88 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Task ID"
+// @Param fields query string false "Comma-separated task fields to return (id is always included)"
 // @Success 200 {object} models.Task
+// @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/v1/tasks/{id} [get]
 func (h *TaskHandler) GetTask(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := h.taskID(c)
+	if !ok {
+		return
+	}
+
+	fields, err := parseFieldSelection(c.Query("fields"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	task, err := h.service.GetTask(c.Request.Context(), id)
 	if err != nil {
 		if errors.Is(err, repository.ErrTaskNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+			respondTaskNotFound(c, id)
 			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, task)
+	c.Header("ETag", task.ETag())
+	c.Header("Last-Modified", task.UpdatedAt.UTC().Format(http.TimeFormat))
+
+	if fields != nil {
+		projected, err := projectTask(task, fields)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		h.render(c, http.StatusOK, projected)
+		return
+	}
+
+	h.render(c, http.StatusOK, task)
+}
+
+// HeadTask godoc
+// @Summary Check a task exists
+// @Description Report whether a task exists, with its ETag and Last-Modified headers, without loading or returning the task
+// @Tags tasks
+// @Param id path string true "Task ID"
+// @Param If-None-Match header string false "ETag from a previous GET of the task"
+// @Success 200 "Task exists"
+// @Header 200 {string} ETag "Entity tag of the task's current state"
+// @Header 200 {string} Last-Modified "When the task was last updated"
+// @Success 304 "Not Modified (If-None-Match matched the task ETag)"
+// @Failure 400 "Malformed task ID when VALIDATE_TASK_IDS is set"
+// @Failure 404 "Task not found"
+// @Failure 500 "Internal error"
+// @Router /api/v1/tasks/{id} [head]
+func (h *TaskHandler) HeadTask(c *gin.Context) {
+	id, ok := h.taskID(c)
+	if !ok {
+		return
+	}
+
+	updatedAt, err := h.service.GetTaskUpdatedAt(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrTaskNotFound) {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	etag := models.TaskETag(id, updatedAt)
+	c.Header("ETag", etag)
+	c.Header("Last-Modified", updatedAt.UTC().Format(http.TimeFormat))
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Status(http.StatusOK)
 }
 
 // ListTasks godoc
@@ -82,10 +379,40 @@ func (h *TaskHandler) GetTask(c *gin.Context) {
 // @Produce json
 // @Param status query string false "Filter by status" Enums(pending, in_progress, completed, cancelled)
 // @Param assignee query string false "Filter by assignee email"
+// @Param source query string false "Filter by external source system"
+// @Param external_id query string false "Filter by external ID"
+// @Param has_description query bool false "Filter by whether the task has a non-empty description"
+// @Param search query string false "Case-insensitive substring matched against the configured search fields"
+// @Param title_prefix query string false "Case-insensitive title prefix, for autocomplete"
+// @Param stale query bool false "Only open tasks (not completed or cancelled) not updated for stale_days"
+// @Param stale_days query int false "Days without an update before an open task is stale (default: 14, requires stale=true)"
+// @Param sort query string false "Field to sort by" Enums(created_at, updated_at, title)
+// @Param order query string false "Sort direction (asc or desc, default: desc)"
 // @Param page query int false "Page number (default: 1)"
 // @Param page_size query int false "Page size (default: 10, max: 100)"
-// @Success 200 {object} models.TaskListResponse
+// @Param strict_page_size query bool false "Respond 400 when page_size exceeds the maximum instead of clamping it"
+// @Param applied_filters query bool false "Include the filters the list was served with, after normalization and defaults"
+// @Param ids query string false "Comma-separated task IDs to fetch; overrides all other filters and pagination"
+// @Param strict query bool false "With ids, respond 404 if any requested ID does not exist"
+// @Param page_token query string false "Opaque token from next_page_token; overrides page and page_size"
+// @Param fields query string false "Comma-separated task fields to return (id is always included)"
+// @Param id_only query bool false "Return only the IDs of all matching tasks (up to 10000) as {ids, total}; pagination is ignored. Cannot be combined with fields or ids"
+// @Param If-None-Match header string false "ETag from a previous list response"
+// @Success 200 {object} models.TaskListResponse "Flat shape (default)"
+// @Success 200 {object} models.TaskListEnvelope "Envelope shape when LIST_RESPONSE_FORMAT=envelope"
+// @Success 200 {array} models.Task "Bare tasks array when LIST_RESPONSE_FORMAT=links"
+// @Header 200 {integer} X-Total-Count "Total number of matching tasks"
+// @Header 200 {integer} X-Page "Current page"
+// @Header 200 {integer} X-Page-Size "Page size"
+// @Header 200 {integer} X-Total-Pages "Total number of pages"
+// @Header 200 {string} Link "RFC 8288 links to the prev and next pages"
+// @Header 200 {string} X-Next-Page-Token "Token for the next page when LIST_RESPONSE_FORMAT=links"
+// @Header 200 {string} Deprecation "Set when page_size was clamped, which is deprecated"
+// @Header 200 {string} Sunset "When the deprecated form stops working"
+// @Header 200 {string} Warning "299 warning describing the deprecated form"
+// @Success 304 "Not Modified (If-None-Match matched the list ETag)"
 // @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]interface{} "Missing IDs when strict=true"
 // @Failure 500 {object} map[string]string
 // @Router /api/v1/tasks [get]
 func (h *TaskHandler) ListTasks(c *gin.Context) {
@@ -95,68 +422,563 @@ func (h *TaskHandler) ListTasks(c *gin.Context) {
 		return
 	}
 
-	response, err := h.service.ListTasks(c.Request.Context(), &filter)
+	fields, err := parseFieldSelection(c.Query("fields"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if c.Query("id_only") == "true" {
+		if fields != nil || c.Query("ids") != "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "id_only cannot be combined with fields or ids"})
+			return
+		}
+		response, err := h.service.ListTaskIDs(c.Request.Context(), &filter)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.Header("X-Total-Count", strconv.Itoa(response.Total))
+		h.render(c, http.StatusOK, response)
+		return
+	}
+
+	var response *models.TaskListResponse
+	if rawIDs := c.Query("ids"); rawIDs != "" {
+		// An explicit ID list wins over every other filter and pagination
+		response, err = h.batchListResponse(c, rawIDs)
+		if err != nil {
+			return
+		}
+	} else {
+		requestedPageSize := filter.PageSize
+		response, err = h.service.ListTasks(c.Request.Context(), &filter)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if filter.PageToken == "" && requestedPageSize > response.PageSize {
+			deprecate(c, pageSizeClampDeprecation)
+		}
+	}
+
+	setPaginationHeaders(c, response)
+
+	etag := response.ETag()
+	c.Header("ETag", etag)
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	if h.linksOnly {
+		h.renderLinksOnlyList(c, response, fields)
+		return
+	}
+
+	if fields != nil {
+		h.renderProjectedList(c, response, fields)
+		return
+	}
+
+	if h.envelopeResponse {
+		h.render(c, http.StatusOK, response.Envelope())
+		return
+	}
+
+	h.render(c, http.StatusOK, response)
+}
+
+// renderLinksOnlyList writes just the tasks array, projected to fields when
+// given. Pagination is left to the headers; the next page token moves to
+// X-Next-Page-Token. Applied filters are not reported in this mode.
+func (h *TaskHandler) renderLinksOnlyList(c *gin.Context, response *models.TaskListResponse, fields []string) {
+	if response.NextPageToken != "" {
+		c.Header("X-Next-Page-Token", response.NextPageToken)
+	}
+
+	if fields == nil {
+		h.render(c, http.StatusOK, response.Tasks)
+		return
+	}
+	tasks, err := projectTasks(response.Tasks, fields)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	h.render(c, http.StatusOK, tasks)
+}
+
+// batchListResponse fetches the tasks named in the ids query value. It writes
+// the error response itself and returns a non-nil error when it does.
+func (h *TaskHandler) batchListResponse(c *gin.Context, rawIDs string) (*models.TaskListResponse, error) {
+	ids := []string{}
+	seen := make(map[string]bool)
+	for _, id := range strings.Split(rawIDs, ",") {
+		id = strings.TrimSpace(id)
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	tasks, missing, err := h.service.GetTasksByIDs(c.Request.Context(), ids)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, err
+	}
+
+	if len(missing) > 0 && c.Query("strict") == "true" {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "tasks not found",
+			"code":  "task_not_found",
+			"ids":   missing,
+		})
+		return nil, repository.ErrTaskNotFound
+	}
+
+	return &models.TaskListResponse{
+		Tasks:      tasks,
+		Total:      len(tasks),
+		Page:       1,
+		PageSize:   len(tasks),
+		TotalPages: 1,
+	}, nil
+}
+
+// renderProjectedList writes a list response containing only the selected task fields
+func (h *TaskHandler) renderProjectedList(c *gin.Context, response *models.TaskListResponse, fields []string) {
+	tasks, err := projectTasks(response.Tasks, fields)
 	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if h.envelopeResponse {
+		h.render(c, http.StatusOK, gin.H{
+			"data": tasks,
+			"meta": response.Envelope().Meta,
+		})
+		return
+	}
+
+	body := gin.H{
+		"tasks":       tasks,
+		"total":       response.Total,
+		"page":        response.Page,
+		"page_size":   response.PageSize,
+		"total_pages": response.TotalPages,
+	}
+	if response.NextPageToken != "" {
+		body["next_page_token"] = response.NextPageToken
+	}
+	if response.AppliedFilters != nil {
+		body["applied_filters"] = response.AppliedFilters
+	}
+	h.render(c, http.StatusOK, body)
+}
+
+// ListTaskChanges godoc
+// @Summary List tasks changed since a timestamp
+// @Description Get tasks updated after the given RFC 3339 timestamp, oldest change first, for incremental sync. Pass next_cursor back as cursor to fetch the next page; unlike next_since it never skips tasks that share an updated_at.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param since query string false "RFC 3339 timestamp of the last sync; required unless cursor is set"
+// @Param cursor query string false "next_cursor from a previous response"
+// @Param limit query int false "Maximum number of tasks (default: 100, max: 1000)"
+// @Success 200 {object} models.TaskChangesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/tasks/changes [get]
+func (h *TaskHandler) ListTaskChanges(c *gin.Context) {
+	var query models.TaskChangesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if query.Cursor == "" && query.Since.IsZero() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "since or cursor is required"})
+		return
+	}
+
+	response, err := h.service.ListChangesSince(c.Request.Context(), query.Since, query.Cursor, query.Limit)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 
-	c.JSON(http.StatusOK, response)
+	h.render(c, http.StatusOK, response)
+}
+
+// GetBoard godoc
+// @Summary Get tasks grouped by status
+// @Description Get a board of tasks with one column per status, for kanban views. The list filters apply to every column, and sort and order apply within each column. Every column holds at most limit tasks and is paginated on its own with offset[<status>], e.g. offset[pending]=20. A status filter returns only that column.
+// @Tags tasks
+// @Produce json
+// @Param limit query int false "Maximum number of tasks per column (default: 20, max: 100)"
+// @Param offset query object false "Offset per column, as offset[<status>]=<n>"
+// @Param status query string false "Return only this column"
+// @Param assignee query []string false "Filter by assignee; repeat to match any of several" collectionFormat(multi)
+// @Param source query string false "Filter by external system"
+// @Param search query string false "Free-text search"
+// @Param title_prefix query string false "Filter by title prefix"
+// @Param sort query string false "Sort field within each column"
+// @Param order query string false "Sort order (asc or desc)"
+// @Success 200 {object} models.BoardResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/tasks/board [get]
+func (h *TaskHandler) GetBoard(c *gin.Context) {
+	var query models.BoardQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	offsets := map[models.TaskStatus]int{}
+	for status, raw := range c.QueryMap("offset") {
+		offset, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid offset for %s: %s", status, raw)})
+			return
+		}
+		offsets[models.NormalizeStatus(status)] = offset
+	}
+
+	response, err := h.service.GetBoard(c.Request.Context(), &query, offsets)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.render(c, http.StatusOK, response)
+}
+
+// ListRecentTasks godoc
+// @Summary List recently updated tasks
+// @Description Get the most recently updated tasks, newest first, for recent activity views
+// @Tags tasks
+// @Produce json
+// @Param limit query int false "Maximum number of tasks (default: 10, max: 100)"
+// @Success 200 {object} models.RecentTasksResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/tasks/recent [get]
+func (h *TaskHandler) ListRecentTasks(c *gin.Context) {
+	var query models.RecentTasksQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.ListRecentTasks(c.Request.Context(), query.Limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.render(c, http.StatusOK, response)
+}
+
+// GetCompletionStats godoc
+// @Summary Count completed tasks in a time window
+// @Description Count tasks completed between since and until, optionally for one assignee and grouped per assignee. Completion time is approximated by updated_at, so a completed task edited later counts in the window of that edit.
+// @Tags tasks
+// @Produce json
+// @Param since query string true "RFC 3339 start of the window (inclusive)"
+// @Param until query string false "RFC 3339 end of the window (exclusive, default: now)"
+// @Param assignee query string false "Only count tasks of this assignee"
+// @Param group_by query string false "Break the count down per assignee" Enums(assignee)
+// @Success 200 {object} models.CompletionStatsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/tasks/stats/completions [get]
+func (h *TaskHandler) GetCompletionStats(c *gin.Context) {
+	var query models.CompletionStatsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.GetCompletionStats(c.Request.Context(), &query)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.render(c, http.StatusOK, response)
+}
+
+// GetTrend godoc
+// @Summary Count created and completed tasks per interval
+// @Description Count the tasks created and completed per day, week or month between from and to, for burn-down charts. Every bucket in the range is returned, empty ones included. Completion time is approximated by updated_at.
+// @Tags tasks
+// @Produce json
+// @Param from query string true "RFC 3339 start of the range (inclusive)"
+// @Param to query string false "RFC 3339 end of the range (exclusive, default: now)"
+// @Param interval query string false "Bucket size (default: day)" Enums(day, week, month)
+// @Success 200 {object} models.TrendResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/tasks/stats/trend [get]
+func (h *TaskHandler) GetTrend(c *gin.Context) {
+	var query models.TrendQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.GetTrend(c.Request.Context(), &query)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.render(c, http.StatusOK, response)
 }
 
 // UpdateTask godoc
 // @Summary Update a task
-// @Description Update an existing task with new information
+// @Description Update an existing task with new information. With Content-Type application/merge-patch+json the body is an RFC 7386 merge patch where null clears description, assignee, source or external_id. With application/json-patch+json it is an RFC 6902 list of add, replace, remove, test, copy and move operations on those fields plus title and status, applied to the task's current JSON; a failed test is a 409.
 // @Tags tasks
 // @Accept json
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
 // @Produce json
 // @Param id path string true "Task ID"
 // @Param task body models.UpdateTaskRequest true "Task update request"
 // @Success 200 {object} models.Task
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/v1/tasks/{id} [put]
 func (h *TaskHandler) UpdateTask(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := h.taskID(c)
+	if !ok {
+		return
+	}
+
+	var req *models.UpdateTaskRequest
+	if c.ContentType() == jsonPatchContentType {
+		if req, ok = h.jsonPatchRequest(c, id); !ok {
+			return
+		}
+	} else {
+		var err error
+		if req, err = h.updateRequest(c); err != nil {
+			respondBindError(c, err)
+			return
+		}
+	}
+
+	task, err := h.service.UpdateTask(c.Request.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, repository.ErrTaskNotFound) {
+			respondTaskNotFound(c, id)
+			return
+		}
+		if respondDuplicate(c, err) {
+			return
+		}
+		if respondMissingFields(c, err) {
+			return
+		}
+		if errors.Is(err, repository.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.render(c, http.StatusOK, task)
+}
+
+// jsonPatchRequest applies a JSON Patch body to the current state of task id
+// and returns the resulting update. It writes the error response and returns
+// false when the patch is invalid, the task is missing or a test fails.
+func (h *TaskHandler) jsonPatchRequest(c *gin.Context, id string) (*models.UpdateTaskRequest, bool) {
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	patch, touched, err := parseJSONPatch(body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	current, err := h.service.GetTask(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrTaskNotFound) {
+			respondTaskNotFound(c, id)
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
 
-	var req models.UpdateTaskRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	req, err := applyJSONPatch(patch, touched, current)
+	if err != nil {
+		if errors.Is(err, jsonpatch.ErrTestFailed) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return nil, false
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return req, true
+}
+
+// updateRequest decodes an update body according to its content type: a JSON
+// Merge Patch or a plain UpdateTaskRequest. JSON Patch bodies need the
+// current task and go through jsonPatchRequest instead.
+func (h *TaskHandler) updateRequest(c *gin.Context) (*models.UpdateTaskRequest, error) {
+	switch c.ContentType() {
+	case mergePatchContentType:
+		body, err := c.GetRawData()
+		if err != nil {
+			return nil, err
+		}
+		return decodeMergePatch(body, h.strictJSON)
+	default:
+		req := &models.UpdateTaskRequest{}
+		if err := h.bindJSON(c, req); err != nil {
+			return nil, err
+		}
+		return req, nil
+	}
+}
+
+// AssignTask godoc
+// @Summary Reassign a task
+// @Description Change only the assignee of a task
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param request body models.AssignTaskRequest true "New assignee"
+// @Success 200 {object} models.Task
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/tasks/{id}/assign [post]
+func (h *TaskHandler) AssignTask(c *gin.Context) {
+	id, ok := h.taskID(c)
+	if !ok {
+		return
+	}
+
+	var req models.AssignTaskRequest
+	if err := h.bindJSON(c, &req); err != nil {
+		respondBindError(c, err)
 		return
 	}
 
-	task, err := h.service.UpdateTask(c.Request.Context(), id, &req)
+	task, err := h.service.AssignTask(c.Request.Context(), id, req.Assignee)
 	if err != nil {
 		if errors.Is(err, repository.ErrTaskNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+			respondTaskNotFound(c, id)
+			return
+		}
+		if respondDuplicate(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.render(c, http.StatusOK, task)
+}
+
+// ReassignTasks godoc
+// @Summary Reassign all tasks of an assignee
+// @Description Move every task assigned to from, optionally only those in one status, to another assignee in a single transaction
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param request body models.ReassignTasksRequest true "Current and new assignee"
+// @Success 200 {object} models.ReassignTasksResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string "The new assignee already has a task with the same title"
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/tasks/reassign [post]
+func (h *TaskHandler) ReassignTasks(c *gin.Context) {
+	var req models.ReassignTasksRequest
+	if err := h.bindJSON(c, &req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	reassigned, err := h.service.ReassignTasks(c.Request.Context(), &req)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if respondDuplicate(c, err) {
 			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, task)
+	h.render(c, http.StatusOK, models.ReassignTasksResponse{Reassigned: reassigned})
 }
 
 // DeleteTask godoc
 // @Summary Delete a task
-// @Description Delete a task by its ID
+// @Description Delete a task by its ID. With If-Match the task is only deleted if its ETag still matches.
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Param id path string true "Task ID"
+// @Param If-Match header string false "ETag from a previous GET of the task"
 // @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Malformed task ID when VALIDATE_TASK_IDS is set"
 // @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string "Task changed since the If-Match ETag was issued"
 // @Failure 500 {object} map[string]string
 // @Router /api/v1/tasks/{id} [delete]
 func (h *TaskHandler) DeleteTask(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := h.taskID(c)
+	if !ok {
+		return
+	}
 
-	err := h.service.DeleteTask(c.Request.Context(), id)
+	var err error
+	if ifMatch := c.GetHeader("If-Match"); ifMatch != "" {
+		err = h.service.DeleteTaskIfMatch(c.Request.Context(), id, ifMatch)
+	} else {
+		err = h.service.DeleteTask(c.Request.Context(), id)
+	}
 	if err != nil {
 		if errors.Is(err, repository.ErrTaskNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+			respondTaskNotFound(c, id)
+			return
+		}
+		if errors.Is(err, repository.ErrTaskModified) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -166,17 +988,260 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// PurgeCompletedTasks godoc
+// @Summary Purge old completed tasks
+// @Description Delete completed tasks last updated before the given cutoff
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body models.PurgeTasksRequest true "Purge cutoff"
+// @Param dry_run query bool false "Report the tasks that would be purged, listing at most 100 IDs, without deleting them"
+// @Success 200 {object} models.PurgeTasksResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/admin/tasks/purge [post]
+func (h *TaskHandler) PurgeCompletedTasks(c *gin.Context) {
+	var req models.PurgeTasksRequest
+	if err := h.bindJSON(c, &req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	if c.Query("dry_run") == "true" {
+		ids, total, err := h.service.PreviewPurgeCompletedTasks(c.Request.Context(), req.Before)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		h.render(c, http.StatusOK, models.PurgeTasksResponse{
+			Purged:       total,
+			DryRun:       true,
+			IDs:          ids,
+			IDsTruncated: len(ids) < total,
+		})
+		return
+	}
+
+	purged, err := h.service.PurgeCompletedTasks(c.Request.Context(), req.Before)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.render(c, http.StatusOK, models.PurgeTasksResponse{Purged: purged})
+}
+
+// GetMaintenance godoc
+// @Summary Get maintenance mode
+// @Description Report whether maintenance mode is on. While it is, POST, PUT, PATCH and DELETE requests are rejected with 503 and reads are still served.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} models.MaintenanceResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/v1/admin/maintenance [get]
+func (h *TaskHandler) GetMaintenance(c *gin.Context) {
+	if h.maintenance == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "maintenance mode is not available"})
+		return
+	}
+
+	h.render(c, http.StatusOK, models.MaintenanceResponse{Enabled: h.maintenance.Enabled()})
+}
+
+// SetMaintenance godoc
+// @Summary Turn maintenance mode on or off
+// @Description Turn maintenance mode on or off at runtime. While it is on, POST, PUT, PATCH and DELETE requests other than this one are rejected with 503 and a Retry-After header, and reads are still served.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body models.MaintenanceRequest true "Maintenance mode state"
+// @Success 200 {object} models.MaintenanceResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/v1/admin/maintenance [put]
+func (h *TaskHandler) SetMaintenance(c *gin.Context) {
+	if h.maintenance == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "maintenance mode is not available"})
+		return
+	}
+
+	var req models.MaintenanceRequest
+	if err := h.bindJSON(c, &req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	h.maintenance.SetEnabled(*req.Enabled)
+	h.render(c, http.StatusOK, models.MaintenanceResponse{Enabled: *req.Enabled})
+}
+
+// ListStatuses godoc
+// @Summary List task statuses
+// @Description List the valid task statuses and the transitions allowed from each
+// @Tags meta
+// @Produce json
+// @Success 200 {object} models.StatusesResponse
+// @Router /api/v1/meta/statuses [get]
+func (h *TaskHandler) ListStatuses(c *gin.Context) {
+	statuses := models.AllStatuses()
+	transitions := make(map[models.TaskStatus][]models.TaskStatus, len(statuses))
+	for _, status := range statuses {
+		transitions[status] = models.AllowedTransitions(status)
+	}
+
+	h.render(c, http.StatusOK, models.StatusesResponse{
+		Statuses:    statuses,
+		Transitions: transitions,
+	})
+}
+
 // HealthCheck godoc
 // @Summary Health check endpoint
-// @Description Returns the health status of the service
+// @Description Returns the health status of the service and whether maintenance mode is on
 // @Tags health
 // @Accept json
 // @Produce json
-// @Success 200 {object} map[string]string
+// @Success 200 {object} map[string]interface{}
 // @Router /health [get]
 func (h *TaskHandler) HealthCheck(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
-		"status":  "healthy",
-		"service": "task-manager",
+		"status":      "healthy",
+		"service":     "task-manager",
+		"maintenance": h.inMaintenance(),
+	})
+}
+
+// ReadinessCheck godoc
+// @Summary Readiness check endpoint
+// @Description Reports the reachability and latency of the database and cache, and the service version. The status is healthy, degraded (cache down, requests still served, 200) or unhealthy (database down, 503). With a background health monitor the last cached result is returned instantly, and a result older than three check intervals is reported as stale (503); otherwise dependencies are pinged on each request.
+// @Tags health
+// @Produce json
+// @Success 200 {object} models.ReadinessResponse
+// @Failure 503 {object} models.ReadinessResponse
+// @Router /health/ready [get]
+func (h *TaskHandler) ReadinessCheck(c *gin.Context) {
+	var result health.Result
+	if h.healthMonitor != nil {
+		result = h.healthMonitor.Last()
+	} else {
+		result = health.Result{Dependencies: h.service.CheckDependencies(c.Request.Context()), CheckedAt: time.Now()}
+	}
+
+	if !result.Checked() {
+		c.JSON(http.StatusServiceUnavailable, models.ReadinessResponse{Status: "starting", Version: h.version, Maintenance: h.inMaintenance()})
+		return
+	}
+	if h.healthMonitor != nil && h.healthMonitor.Stale() {
+		// The monitor stopped checking, so its last result says nothing
+		// about the dependencies now
+		c.JSON(http.StatusServiceUnavailable, models.ReadinessResponse{
+			Status:      "stale",
+			Version:     h.version,
+			Maintenance: h.inMaintenance(),
+			CheckedAt:   result.CheckedAt,
+			Error:       "health check result is stale",
+		})
+		return
+	}
+
+	response := models.ReadinessResponse{
+		Status:       string(result.Status()),
+		Version:      h.version,
+		Maintenance:  h.inMaintenance(),
+		CheckedAt:    result.CheckedAt,
+		Dependencies: make(map[string]models.DependencyHealth, len(result.Dependencies)),
+	}
+	if err := result.Err(); err != nil {
+		response.Error = err.Error()
+	}
+	for _, dep := range result.Dependencies {
+		entry := models.DependencyHealth{
+			Status:    "up",
+			LatencyMS: float64(dep.Latency.Microseconds()) / 1000,
+		}
+		if dep.Err != nil {
+			entry.Status, entry.Error = "down", dep.Err.Error()
+		}
+		response.Dependencies[dep.Name] = entry
+	}
+
+	// A degraded service still serves requests, so it stays in rotation
+	code := http.StatusOK
+	if result.Status() == health.StatusUnhealthy {
+		code = http.StatusServiceUnavailable
+	}
+	c.JSON(code, response)
+}
+
+// inMaintenance reports whether maintenance mode is on. Reads are still
+// served in maintenance, so it does not affect readiness.
+func (h *TaskHandler) inMaintenance() bool {
+	return h.maintenance != nil && h.maintenance.Enabled()
+}
+
+// taskID returns the :id path value. With ID validation enabled it writes a
+// 400 and returns false when the value is not a well-formed task ID.
+func (h *TaskHandler) taskID(c *gin.Context) (string, bool) {
+	id := c.Param("id")
+	if h.validateIDs && !models.IsValidTaskID(id) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid task ID",
+			"code":  "invalid_task_id",
+			"id":    id,
+		})
+		return "", false
+	}
+	return id, true
+}
+
+// respondTaskNotFound writes a 404 body that echoes the requested ID
+func respondTaskNotFound(c *gin.Context, id string) {
+	c.JSON(http.StatusNotFound, gin.H{
+		"error": "task not found",
+		"code":  "task_not_found",
+		"id":    id,
+	})
+}
+
+// respondDuplicate writes a 409 when err is a uniqueness violation and
+// reports whether it did
+func respondDuplicate(c *gin.Context, err error) bool {
+	for _, duplicate := range []error{repository.ErrDuplicateTask, repository.ErrDuplicateExternalID} {
+		if errors.Is(err, duplicate) {
+			c.JSON(http.StatusConflict, gin.H{"error": duplicate.Error()})
+			return true
+		}
+	}
+	return false
+}
+
+// respondMissingFields writes a 400 listing the missing fields when err is a
+// required field violation and reports whether it did
+func respondMissingFields(c *gin.Context, err error) bool {
+	var missing *service.MissingFieldsError
+	if !errors.As(err, &missing) {
+		return false
+	}
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error":  missing.Error(),
+		"code":   "missing_required_fields",
+		"fields": missing.Fields,
 	})
+	return true
+}
+
+// etagMatches reports whether an If-None-Match header value matches the etag.
+// Comparison is weak, as recommended for If-None-Match by RFC 9110.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	target := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == target {
+			return true
+		}
+	}
+	return false
 }
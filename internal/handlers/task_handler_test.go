@@ -3,18 +3,28 @@ package handlers
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/Ali-Gorgani/task-manager/internal/cache"
+	"github.com/Ali-Gorgani/task-manager/internal/health"
+	"github.com/Ali-Gorgani/task-manager/internal/middleware"
 	"github.com/Ali-Gorgani/task-manager/internal/models"
 	"github.com/Ali-Gorgani/task-manager/internal/repository"
 	"github.com/Ali-Gorgani/task-manager/internal/service"
 	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redismock/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockTaskRepository is a mock implementation for testing
@@ -35,6 +45,11 @@ func (m *MockTaskRepository) GetByID(ctx context.Context, id string) (*models.Ta
 	return args.Get(0).(*models.Task), args.Error(1)
 }
 
+func (m *MockTaskRepository) GetByIDs(ctx context.Context, ids []string) ([]models.Task, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
 func (m *MockTaskRepository) GetAll(ctx context.Context, filter *models.TaskFilter) ([]models.Task, int, error) {
 	args := m.Called(ctx, filter)
 	return args.Get(0).([]models.Task), args.Int(1), args.Error(2)
@@ -45,37 +60,192 @@ func (m *MockTaskRepository) Update(ctx context.Context, task *models.Task) erro
 	return args.Error(0)
 }
 
+func (m *MockTaskRepository) UpdateAssignee(ctx context.Context, id, assignee string, updatedAt time.Time) (*models.Task, string, error) {
+	args := m.Called(ctx, id, assignee, updatedAt)
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+	return args.Get(0).(*models.Task), args.String(1), args.Error(2)
+}
+
+func (m *MockTaskRepository) UpsertByExternalID(ctx context.Context, task *models.Task) (*models.Task, bool, error) {
+	args := m.Called(ctx, task)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).(*models.Task), args.Bool(1), args.Error(2)
+}
+
 func (m *MockTaskRepository) Delete(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
+func (m *MockTaskRepository) DeleteIfUnmodified(ctx context.Context, id string, updatedAt time.Time) error {
+	args := m.Called(ctx, id, updatedAt)
+	return args.Error(0)
+}
+
 func (m *MockTaskRepository) Count(ctx context.Context) (int, error) {
 	args := m.Called(ctx)
 	return args.Int(0), args.Error(1)
 }
 
-func setupRouter(taskService *service.TaskService) *gin.Engine {
+func (m *MockTaskRepository) CompletionsByAssignee(ctx context.Context, since, until time.Time, assignee *string) (map[string]int, error) {
+	args := m.Called(ctx, since, until, assignee)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *MockTaskRepository) ReassignAll(ctx context.Context, from, to string, status *models.TaskStatus, updatedAt time.Time) ([]models.Task, error) {
+	args := m.Called(ctx, from, to, status, updatedAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) TransitionStale(ctx context.Context, from, to models.TaskStatus, before, updatedAt time.Time) (int, error) {
+	args := m.Called(ctx, from, to, before, updatedAt)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetUpdatedAt(ctx context.Context, id string) (time.Time, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetRecentlyUpdated(ctx context.Context, limit int) ([]models.Task, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) CreateMany(ctx context.Context, tasks []*models.Task) error {
+	args := m.Called(ctx, tasks)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) GetBoard(ctx context.Context, filter *models.TaskFilter, pages map[models.TaskStatus]models.BoardPage) (map[models.TaskStatus]models.BoardColumn, error) {
+	args := m.Called(ctx, filter, pages)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[models.TaskStatus]models.BoardColumn), args.Error(1)
+}
+
+func (m *MockTaskRepository) Trend(ctx context.Context, interval string, from, to time.Time) ([]models.TrendBucket, error) {
+	args := m.Called(ctx, interval, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TrendBucket), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetIDs(ctx context.Context, filter *models.TaskFilter, limit int) ([]string, int, error) {
+	args := m.Called(ctx, filter, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]string), args.Int(1), args.Error(2)
+}
+
+func (m *MockTaskRepository) CreateIfNotExists(ctx context.Context, task *models.Task) (*models.Task, bool, error) {
+	args := m.Called(ctx, task)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).(*models.Task), args.Bool(1), args.Error(2)
+}
+
+func (m *MockTaskRepository) OldestOpenByStatus(ctx context.Context) (map[models.TaskStatus]time.Time, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[models.TaskStatus]time.Time), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetChangedSince(ctx context.Context, since time.Time, afterID string, limit int) ([]models.Task, error) {
+	args := m.Called(ctx, since, afterID, limit)
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) PurgeCompletedBefore(ctx context.Context, before time.Time) (int, error) {
+	args := m.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) ListCompletedBefore(ctx context.Context, before time.Time, limit int) ([]string, int, error) {
+	args := m.Called(ctx, before, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]string), args.Int(1), args.Error(2)
+}
+
+func (m *MockTaskRepository) Ping(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func setupRouter(taskService *service.TaskService, opts ...Option) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
-	handler := NewTaskHandler(taskService)
+	handler := NewTaskHandler(taskService, opts...)
 
 	router.GET("/health", handler.HealthCheck)
+	router.GET("/health/ready", handler.ReadinessCheck)
 	v1 := router.Group("/api/v1")
 	{
 		tasks := v1.Group("/tasks")
 		{
 			tasks.POST("", handler.CreateTask)
+			tasks.POST("/import", handler.ImportTasks)
+			tasks.POST("/validate", handler.ValidateTask)
 			tasks.GET("", handler.ListTasks)
+			tasks.GET("/changes", handler.ListTaskChanges)
+			tasks.GET("/recent", handler.ListRecentTasks)
+			tasks.GET("/board", handler.GetBoard)
+			tasks.GET("/stats/completions", handler.GetCompletionStats)
+			tasks.GET("/stats/trend", handler.GetTrend)
 			tasks.GET("/:id", handler.GetTask)
+			tasks.HEAD("/:id", handler.HeadTask)
 			tasks.PUT("/:id", handler.UpdateTask)
+			tasks.PUT("/external/:source/:external_id", handler.UpsertTaskByExternalID)
+			tasks.POST("/:id/assign", handler.AssignTask)
+			tasks.POST("/reassign", handler.ReassignTasks)
 			tasks.DELETE("/:id", handler.DeleteTask)
 		}
+
+		admin := v1.Group("/admin")
+		{
+			admin.POST("/tasks/purge", handler.PurgeCompletedTasks)
+			admin.GET("/maintenance", handler.GetMaintenance)
+			admin.PUT("/maintenance", handler.SetMaintenance)
+		}
+
+		meta := v1.Group("/meta")
+		{
+			meta.GET("/statuses", handler.ListStatuses)
+		}
 	}
 
 	return router
 }
 
+func assertTaskNotFoundBody(t *testing.T, w *httptest.ResponseRecorder, id string) {
+	t.Helper()
+
+	var response map[string]string
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "task not found", response["error"])
+	assert.Equal(t, "task_not_found", response["code"])
+	assert.Equal(t, id, response["id"])
+}
+
 func TestHealthCheck(t *testing.T) {
 	mockService := &service.TaskService{}
 	router := setupRouter(mockService)
@@ -86,10 +256,208 @@ func TestHealthCheck(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, w.Code)
 
-	var response map[string]string
+	var response map[string]interface{}
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
 	assert.Equal(t, "healthy", response["status"])
+	assert.Equal(t, false, response["maintenance"])
+}
+
+func TestMaintenance_Handler(t *testing.T) {
+	maintenance := middleware.NewMaintenance(false, 30*time.Second)
+	router := setupRouter(&service.TaskService{}, WithMaintenance(maintenance))
+
+	setMaintenance := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/admin/maintenance", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := setMaintenance(`{"enabled": true}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"enabled": true}`, w.Body.String())
+	assert.True(t, maintenance.Enabled())
+
+	w = httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/health", nil)
+	router.ServeHTTP(w, req)
+	var status map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+	assert.Equal(t, true, status["maintenance"])
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/v1/admin/maintenance", nil)
+	router.ServeHTTP(w, req)
+	assert.JSONEq(t, `{"enabled": true}`, w.Body.String())
+
+	assert.Equal(t, http.StatusBadRequest, setMaintenance(`{}`).Code)
+
+	w = setMaintenance(`{"enabled": false}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.False(t, maintenance.Enabled())
+
+	t.Run("Not configured", func(t *testing.T) {
+		router := setupRouter(&service.TaskService{})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/admin/maintenance", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestReadinessCheck(t *testing.T) {
+	probe := func(router *gin.Engine) (*httptest.ResponseRecorder, models.ReadinessResponse) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/health/ready", nil)
+		router.ServeHTTP(w, req)
+
+		var response models.ReadinessResponse
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		return w, response
+	}
+
+	t.Run("Pings without a monitor", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		mockRepo.On("Ping", mock.Anything).Return(errors.New("connection refused")).Once()
+
+		w, response := probe(router)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, "unhealthy", response.Status)
+		assert.Contains(t, response.Error, "connection refused")
+		assert.Equal(t, "down", response.Dependencies["database"].Status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Degraded when cache is down", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		db, redisMock := redismock.NewClientMock()
+		router := setupRouter(service.NewTaskService(mockRepo, cache.NewRedisCache(db)), WithServiceVersion("1.4.0"))
+
+		mockRepo.On("Ping", mock.Anything).Return(nil).Once()
+		redisMock.ExpectPing().SetErr(errors.New("connection refused"))
+
+		w, response := probe(router)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "degraded", response.Status)
+		assert.Equal(t, "1.4.0", response.Version)
+		assert.Equal(t, "up", response.Dependencies["database"].Status)
+		assert.Equal(t, "down", response.Dependencies["cache"].Status)
+		assert.Contains(t, response.Dependencies["cache"].Error, "connection refused")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Serves cached monitor result", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		taskService := service.NewTaskService(mockRepo, nil)
+		monitor := health.NewMonitor(taskService.CheckDependencies, time.Second)
+		router := setupRouter(taskService, WithHealthMonitor(monitor))
+
+		w, response := probe(router)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, "starting", response.Status)
+
+		mockRepo.On("Ping", mock.Anything).Return(nil).Once()
+		checked := monitor.Check(context.Background())
+
+		// Probes reuse the last result instead of pinging again
+		for i := 0; i < 3; i++ {
+			w, response = probe(router)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "healthy", response.Status)
+			assert.True(t, checked.CheckedAt.Equal(response.CheckedAt))
+			assert.Contains(t, response.Dependencies, "database")
+		}
+		mockRepo.AssertNumberOfCalls(t, "Ping", 1)
+	})
+
+	t.Run("Stale monitor result", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		taskService := service.NewTaskService(mockRepo, nil)
+		monitor := health.NewMonitor(taskService.CheckDependencies, time.Millisecond)
+		router := setupRouter(taskService, WithHealthMonitor(monitor))
+
+		// Run checks once and returns, leaving a result that goes stale
+		mockRepo.On("Ping", mock.Anything).Return(nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		monitor.Run(ctx, time.Millisecond)
+		require.Eventually(t, monitor.Stale, time.Second, time.Millisecond)
+
+		w, response := probe(router)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, "stale", response.Status)
+	})
+}
+
+func TestGetBoard_Handler(t *testing.T) {
+	t.Run("Filters and offsets", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		task := models.NewTask("Card", "", "alice@example.com", models.TaskStatusInProgress)
+		mockRepo.On("GetBoard", mock.Anything, mock.MatchedBy(func(f *models.TaskFilter) bool {
+			return assert.ObjectsAreEqual([]string{"alice@example.com"}, f.Assignees)
+		}), mock.MatchedBy(func(pages map[models.TaskStatus]models.BoardPage) bool {
+			return pages[models.TaskStatusInProgress] == models.BoardPage{Offset: 5, Limit: 5} &&
+				pages[models.TaskStatusPending] == models.BoardPage{Limit: 5}
+		})).Return(map[models.TaskStatus]models.BoardColumn{
+			models.TaskStatusInProgress: {Tasks: []models.Task{*task}, Total: 6, Offset: 5, Limit: 5},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks/board?assignee=alice@example.com&limit=5&offset[in_progress]=5", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.BoardResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		column := response.Columns[models.TaskStatusInProgress]
+		assert.Equal(t, 6, column.Total)
+		require.Len(t, column.Tasks, 1)
+		assert.Equal(t, task.ID, column.Tasks[0].ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid offset", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks/board?offset[pending]=abc", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockRepo.AssertNotCalled(t, "GetBoard", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("CamelCase keeps status keys", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil), WithCamelCaseFields(true))
+
+		task := models.NewTask("Card", "", "alice@example.com", models.TaskStatusInProgress)
+		mockRepo.On("GetBoard", mock.Anything, mock.Anything, mock.Anything).Return(map[models.TaskStatus]models.BoardColumn{
+			models.TaskStatusInProgress: {Tasks: []models.Task{*task}, Total: 1, Limit: 20},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks/board?status=in_progress", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Columns map[string]map[string]interface{} `json:"columns"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Contains(t, response.Columns, "in_progress")
+		assert.NotContains(t, response.Columns, "inProgress")
+		tasks := response.Columns["in_progress"]["tasks"].([]interface{})
+		assert.Contains(t, tasks[0], "createdAt")
+	})
 }
 
 func TestCreateTask_Handler(t *testing.T) {
@@ -126,6 +494,16 @@ func TestCreateTask_Handler(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
+	t.Run("Numeric Status", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/tasks", bytes.NewBufferString(`{"title":"Test","status":1}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "status must be a string")
+	})
+
 	t.Run("Service Error", func(t *testing.T) {
 		mockRepo2 := new(MockTaskRepository)
 		mockService2 := service.NewTaskService(mockRepo2, nil)
@@ -150,101 +528,1007 @@ func TestCreateTask_Handler(t *testing.T) {
 	})
 }
 
-func TestGetTask_Handler(t *testing.T) {
-	mockRepo := new(MockTaskRepository)
-	mockService := service.NewTaskService(mockRepo, nil)
-	router := setupRouter(mockService)
+func TestCreateTask_Handler_StrictJSON(t *testing.T) {
+	const typo = `{"titel":"Test Task","status":"pending"}`
 
-	t.Run("Success", func(t *testing.T) {
-		task := models.NewTask("Test Task", "Description", "test@example.com", models.TaskStatusPending)
-		mockRepo.On("GetByID", mock.Anything, task.ID).Return(task, nil)
+	t.Run("Lenient by default", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("GET", "/api/v1/tasks/"+task.ID, nil)
+		req, _ := http.NewRequest("POST", "/api/v1/tasks", bytes.NewBufferString(typo))
+		req.Header.Set("Content-Type", "application/json")
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, http.StatusOK, w.Code)
-		mockRepo.AssertExpectations(t)
+		// The unknown field is ignored, so only the missing title is reported
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.NotContains(t, w.Body.String(), "unknown field")
 	})
 
-	t.Run("Not Found", func(t *testing.T) {
-		mockRepo2 := new(MockTaskRepository)
-		mockService2 := service.NewTaskService(mockRepo2, nil)
-		router2 := setupRouter(mockService2)
-
-		mockRepo2.On("GetByID", mock.Anything, "nonexistent").Return(nil, repository.ErrTaskNotFound)
+	t.Run("Unknown field rejected", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil), WithStrictJSON(true))
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("GET", "/api/v1/tasks/nonexistent", nil)
-		router2.ServeHTTP(w, req)
+		req, _ := http.NewRequest("POST", "/api/v1/tasks", bytes.NewBufferString(typo))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
 
-		assert.Equal(t, http.StatusNotFound, w.Code)
-		mockRepo2.AssertExpectations(t)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `unknown field \"titel\"`)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 	})
 
-	t.Run("Internal Error", func(t *testing.T) {
-		mockRepo3 := new(MockTaskRepository)
-		mockService3 := service.NewTaskService(mockRepo3, nil)
-		router3 := setupRouter(mockService3)
+	t.Run("Known fields accepted", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil), WithStrictJSON(true))
 
-		mockRepo3.On("GetByID", mock.Anything, "error-id").Return(nil, errors.New("database error"))
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Task")).Return(nil)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("GET", "/api/v1/tasks/error-id", nil)
-		router3.ServeHTTP(w, req)
+		req, _ := http.NewRequest("POST", "/api/v1/tasks", bytes.NewBufferString(`{"title":"Test Task","status":"pending"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
 
-		assert.Equal(t, http.StatusInternalServerError, w.Code)
-		mockRepo3.AssertExpectations(t)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown merge patch member rejected", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil), WithStrictJSON(true))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/tasks/some-id", bytes.NewBufferString(`{"titel":"New"}`))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 	})
 }
 
-func TestListTasks_Handler(t *testing.T) {
+func TestCreateTask_Handler_BodyTooLarge(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockService := service.NewTaskService(mockRepo, nil)
+	router := gin.New()
+	router.Use(middleware.BodyLimit(16))
+	router.POST("/api/v1/tasks", NewTaskHandler(mockService).CreateTask)
+
+	// No Content-Length, so the limit is only hit while decoding
+	body := io.NopCloser(strings.NewReader(`{"title":"A title well past the body limit"}`))
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/tasks", body)
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Contains(t, w.Body.String(), "request body too large")
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCreateTask_Handler_DuplicateTitle(t *testing.T) {
 	mockRepo := new(MockTaskRepository)
 	mockService := service.NewTaskService(mockRepo, nil)
 	router := setupRouter(mockService)
 
-	t.Run("Success", func(t *testing.T) {
-		tasks := []models.Task{
-			*models.NewTask("Task 1", "Desc 1", "user1@example.com", models.TaskStatusPending),
-		}
-		mockRepo.On("GetAll", mock.Anything, mock.AnythingOfType("*models.TaskFilter")).Return(tasks, 1, nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Task")).Return(repository.ErrDuplicateTask)
 
-		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("GET", "/api/v1/tasks", nil)
-		router.ServeHTTP(w, req)
+	body, _ := json.Marshal(models.CreateTaskRequest{Title: "Task", Assignee: "user@example.com"})
 
-		assert.Equal(t, http.StatusOK, w.Code)
-		mockRepo.AssertExpectations(t)
-	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/tasks", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
 
-	t.Run("With Filters", func(t *testing.T) {
-		mockRepo2 := new(MockTaskRepository)
-		mockService2 := service.NewTaskService(mockRepo2, nil)
-		router2 := setupRouter(mockService2)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockRepo.AssertExpectations(t)
+}
 
-		tasks := []models.Task{}
-		mockRepo2.On("GetAll", mock.Anything, mock.AnythingOfType("*models.TaskFilter")).Return(tasks, 0, nil)
+func TestImportTasks_Handler(t *testing.T) {
+	t.Run("Created with original timestamps", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		mockRepo.On("CreateMany", mock.Anything, mock.AnythingOfType("[]*models.Task")).Return(nil)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("GET", "/api/v1/tasks?status=pending&page=1&page_size=10", nil)
-		router2.ServeHTTP(w, req)
+		req, _ := http.NewRequest("POST", "/api/v1/tasks/import", bytes.NewBufferString(
+			`{"tasks":[{"title":"Legacy","status":"completed","created_at":"2023-03-01T09:30:00Z","updated_at":"2023-04-15T16:00:00Z"}]}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
 
-		assert.Equal(t, http.StatusOK, w.Code)
-		mockRepo2.AssertExpectations(t)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var resp models.ImportTasksResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, 1, resp.Imported)
+		assert.Equal(t, time.Date(2023, 3, 1, 9, 30, 0, 0, time.UTC), resp.Tasks[0].CreatedAt.UTC())
+		assert.Equal(t, time.Date(2023, 4, 15, 16, 0, 0, 0, time.UTC), resp.Tasks[0].UpdatedAt.UTC())
+		mockRepo.AssertExpectations(t)
 	})
 
-	t.Run("Invalid Status", func(t *testing.T) {
-		mockRepo3 := new(MockTaskRepository)
-		mockService3 := service.NewTaskService(mockRepo3, nil)
-		router3 := setupRouter(mockService3)
+	t.Run("Missing created_at", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("GET", "/api/v1/tasks?status=invalid_status", nil)
-		router3.ServeHTTP(w, req)
+		req, _ := http.NewRequest("POST", "/api/v1/tasks/import", bytes.NewBufferString(`{"tasks":[{"title":"Legacy"}]}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockRepo.AssertNotCalled(t, "CreateMany", mock.Anything, mock.Anything)
 	})
-}
+
+	t.Run("Future timestamp", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		future := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/tasks/import", bytes.NewBufferString(
+			`{"tasks":[{"title":"Legacy","created_at":"`+future+`"}]}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "tasks[0]")
+		mockRepo.AssertNotCalled(t, "CreateMany", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Batched with failures", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		mockRepo.On("CreateMany", mock.Anything, mock.Anything).Return(nil).Once()
+		mockRepo.On("CreateMany", mock.Anything, mock.Anything).Return(repository.ErrDuplicateTask).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/tasks/import", bytes.NewBufferString(
+			`{"batch_size":1,"tasks":[{"title":"One","created_at":"2023-03-01T09:30:00Z"},{"title":"Two","created_at":"2023-03-01T09:30:00Z"}]}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusMultiStatus, w.Code)
+		var resp models.ImportTasksResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, 1, resp.Imported)
+		assert.Equal(t, 1, resp.Failed)
+		require.Len(t, resp.Batches, 2)
+		assert.NotEmpty(t, resp.Batches[1].Error)
+	})
+
+	t.Run("Batched with an invalid task", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		mockRepo.On("CreateMany", mock.Anything, mock.Anything).Return(nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/tasks/import", bytes.NewBufferString(
+			`{"batch_size":1,"tasks":[{"title":"One","created_at":"2023-03-01T09:30:00Z"},{"created_at":"2023-03-01T09:30:00Z"},{"title":"Three"}]}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusMultiStatus, w.Code)
+		var resp models.ImportTasksResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, 1, resp.Imported)
+		require.Len(t, resp.Batches, 3)
+		assert.Equal(t, "tasks[1]: invalid input: title is required", resp.Batches[1].Error)
+		assert.Equal(t, "tasks[2]: invalid input: created_at is required", resp.Batches[2].Error)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		mockRepo.On("CreateMany", mock.Anything, mock.Anything).Return(repository.ErrDuplicateTask)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/tasks/import", bytes.NewBufferString(
+			`{"tasks":[{"title":"Legacy","created_at":"2023-03-01T09:30:00Z"}]}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestGetTask_Handler(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockService := service.NewTaskService(mockRepo, nil)
+	router := setupRouter(mockService)
+
+	t.Run("Success", func(t *testing.T) {
+		task := models.NewTask("Test Task", "Description", "test@example.com", models.TaskStatusPending)
+		mockRepo.On("GetByID", mock.Anything, task.ID).Return(task, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks/"+task.ID, nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Sparse Fields", func(t *testing.T) {
+		task := models.NewTask("Sparse Task", "Description", "test@example.com", models.TaskStatusPending)
+		mockRepo.On("GetByID", mock.Anything, task.ID).Return(task, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks/"+task.ID+"?fields=title,status", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response, 3)
+		assert.Equal(t, task.ID, response["id"])
+		assert.Equal(t, "Sparse Task", response["title"])
+	})
+
+	t.Run("Unknown Field", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks/some-id?fields=bogus", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockRepo2 := new(MockTaskRepository)
+		mockService2 := service.NewTaskService(mockRepo2, nil)
+		router2 := setupRouter(mockService2)
+
+		mockRepo2.On("GetByID", mock.Anything, "nonexistent").Return(nil, repository.ErrTaskNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks/nonexistent", nil)
+		router2.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assertTaskNotFoundBody(t, w, "nonexistent")
+		mockRepo2.AssertExpectations(t)
+	})
+
+	t.Run("Internal Error", func(t *testing.T) {
+		mockRepo3 := new(MockTaskRepository)
+		mockService3 := service.NewTaskService(mockRepo3, nil)
+		router3 := setupRouter(mockService3)
+
+		mockRepo3.On("GetByID", mock.Anything, "error-id").Return(nil, errors.New("database error"))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks/error-id", nil)
+		router3.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		mockRepo3.AssertExpectations(t)
+	})
+}
+
+func TestHeadTask_Handler(t *testing.T) {
+	id := "550e8400-e29b-41d4-a716-446655440000"
+	updatedAt := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Exists", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+		mockRepo.On("GetUpdatedAt", mock.Anything, id).Return(updatedAt, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("HEAD", "/api/v1/tasks/"+id, nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Body.String())
+		assert.Equal(t, models.TaskETag(id, updatedAt), w.Header().Get("ETag"))
+		assert.Equal(t, "Sat, 01 Nov 2025 12:00:00 GMT", w.Header().Get("Last-Modified"))
+		mockRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Matches the GET ETag", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+		task := &models.Task{ID: id, Title: "Task", Status: models.TaskStatusPending, UpdatedAt: updatedAt}
+		mockRepo.On("GetByID", mock.Anything, id).Return(task, nil)
+		mockRepo.On("GetUpdatedAt", mock.Anything, id).Return(updatedAt, nil)
+
+		get := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks/"+id, nil)
+		router.ServeHTTP(get, req)
+
+		head := httptest.NewRecorder()
+		req, _ = http.NewRequest("HEAD", "/api/v1/tasks/"+id, nil)
+		req.Header.Set("If-None-Match", get.Header().Get("ETag"))
+		router.ServeHTTP(head, req)
+
+		assert.Equal(t, http.StatusNotModified, head.Code)
+		assert.Equal(t, get.Header().Get("Last-Modified"), head.Header().Get("Last-Modified"))
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+		mockRepo.On("GetUpdatedAt", mock.Anything, "missing").Return(time.Time{}, repository.ErrTaskNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("HEAD", "/api/v1/tasks/missing", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Empty(t, w.Body.String())
+	})
+}
+
+func TestListTasks_Handler(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockService := service.NewTaskService(mockRepo, nil)
+	router := setupRouter(mockService)
+
+	t.Run("Success", func(t *testing.T) {
+		tasks := []models.Task{
+			*models.NewTask("Task 1", "Desc 1", "user1@example.com", models.TaskStatusPending),
+		}
+		mockRepo.On("GetAll", mock.Anything, mock.AnythingOfType("*models.TaskFilter")).Return(tasks, 1, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("With Filters", func(t *testing.T) {
+		mockRepo2 := new(MockTaskRepository)
+		mockService2 := service.NewTaskService(mockRepo2, nil)
+		router2 := setupRouter(mockService2)
+
+		tasks := []models.Task{}
+		mockRepo2.On("GetAll", mock.Anything, mock.AnythingOfType("*models.TaskFilter")).Return(tasks, 0, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks?status=pending&page=1&page_size=10", nil)
+		router2.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepo2.AssertExpectations(t)
+	})
+
+	t.Run("ETag Not Modified", func(t *testing.T) {
+		mockRepoTag := new(MockTaskRepository)
+		mockServiceTag := service.NewTaskService(mockRepoTag, nil)
+		routerTag := setupRouter(mockServiceTag)
+
+		tasks := []models.Task{
+			*models.NewTask("Task 1", "Desc 1", "user1@example.com", models.TaskStatusPending),
+		}
+		mockRepoTag.On("GetAll", mock.Anything, mock.AnythingOfType("*models.TaskFilter")).Return(tasks, 1, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks", nil)
+		routerTag.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		etag := w.Header().Get("ETag")
+		assert.True(t, strings.HasPrefix(etag, `W/"`))
+
+		w2 := httptest.NewRecorder()
+		req2, _ := http.NewRequest("GET", "/api/v1/tasks", nil)
+		req2.Header.Set("If-None-Match", etag)
+		routerTag.ServeHTTP(w2, req2)
+
+		assert.Equal(t, http.StatusNotModified, w2.Code)
+		assert.Empty(t, w2.Body.String())
+		assert.Equal(t, etag, w2.Header().Get("ETag"))
+	})
+
+	t.Run("Sparse Fields", func(t *testing.T) {
+		mockRepoFields := new(MockTaskRepository)
+		mockServiceFields := service.NewTaskService(mockRepoFields, nil)
+		routerFields := setupRouter(mockServiceFields)
+
+		tasks := []models.Task{
+			*models.NewTask("Task 1", "Desc 1", "user1@example.com", models.TaskStatusPending),
+		}
+		mockRepoFields.On("GetAll", mock.Anything, mock.AnythingOfType("*models.TaskFilter")).Return(tasks, 1, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks?fields=status", nil)
+		routerFields.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Tasks []map[string]interface{} `json:"tasks"`
+			Total int                      `json:"total"`
+		}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, response.Total)
+		assert.Len(t, response.Tasks, 1)
+		assert.Len(t, response.Tasks[0], 2)
+		assert.Equal(t, "pending", response.Tasks[0]["status"])
+	})
+
+	t.Run("ID only", func(t *testing.T) {
+		mockRepoIDs := new(MockTaskRepository)
+		routerIDs := setupRouter(service.NewTaskService(mockRepoIDs, nil))
+
+		mockRepoIDs.On("GetIDs", mock.Anything, mock.MatchedBy(func(f *models.TaskFilter) bool {
+			return f.Status != nil && *f.Status == models.TaskStatusPending
+		}), 10000).Return([]string{"id-1", "id-2"}, 2, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks?status=pending&id_only=true", nil)
+		routerIDs.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"ids": ["id-1", "id-2"], "total": 2}`, w.Body.String())
+		assert.Equal(t, "2", w.Header().Get("X-Total-Count"))
+		mockRepoIDs.AssertExpectations(t)
+
+		for _, query := range []string{"?id_only=true&fields=title", "?id_only=true&ids=id-1"} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/v1/tasks"+query, nil)
+			routerIDs.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+
+	t.Run("Batch IDs", func(t *testing.T) {
+		mockRepoBatch := new(MockTaskRepository)
+		mockServiceBatch := service.NewTaskService(mockRepoBatch, nil)
+		routerBatch := setupRouter(mockServiceBatch)
+
+		task1 := models.NewTask("Task 1", "Desc 1", "user1@example.com", models.TaskStatusPending)
+		task2 := models.NewTask("Task 2", "Desc 2", "user2@example.com", models.TaskStatusPending)
+		ids := []string{task2.ID, "missing", task1.ID}
+		mockRepoBatch.On("GetByIDs", mock.Anything, ids).Return([]models.Task{*task1, *task2}, nil).Twice()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks?status=completed&page=3&ids="+strings.Join(ids, ","), nil)
+		routerBatch.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response models.TaskListResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, response.Total)
+		assert.Equal(t, task2.ID, response.Tasks[0].ID, "results follow the requested order")
+		assert.Equal(t, task1.ID, response.Tasks[1].ID)
+
+		w2 := httptest.NewRecorder()
+		req2, _ := http.NewRequest("GET", "/api/v1/tasks?strict=true&ids="+strings.Join(ids, ","), nil)
+		routerBatch.ServeHTTP(w2, req2)
+
+		assert.Equal(t, http.StatusNotFound, w2.Code)
+		assert.Contains(t, w2.Body.String(), `"ids":["missing"]`)
+		mockRepoBatch.AssertExpectations(t)
+	})
+
+	t.Run("Envelope Shape", func(t *testing.T) {
+		mockRepoEnv := new(MockTaskRepository)
+		mockServiceEnv := service.NewTaskService(mockRepoEnv, nil)
+		routerEnv := setupRouter(mockServiceEnv, WithEnvelopeResponse(true))
+
+		tasks := []models.Task{
+			*models.NewTask("Task 1", "Desc 1", "user1@example.com", models.TaskStatusPending),
+		}
+		mockRepoEnv.On("GetAll", mock.Anything, mock.AnythingOfType("*models.TaskFilter")).Return(tasks, 1, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks", nil)
+		routerEnv.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response models.TaskListEnvelope
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 1)
+		assert.Equal(t, 1, response.Meta.Total)
+		assert.Equal(t, 1, response.Meta.Page)
+		mockRepoEnv.AssertExpectations(t)
+	})
+
+	t.Run("CamelCase Fields", func(t *testing.T) {
+		mockRepoCamel := new(MockTaskRepository)
+		mockServiceCamel := service.NewTaskService(mockRepoCamel, nil)
+		routerCamel := setupRouter(mockServiceCamel, WithCamelCaseFields(true))
+
+		tasks := []models.Task{
+			*models.NewTask("Task 1", "Desc 1", "user1@example.com", models.TaskStatusPending),
+		}
+		mockRepoCamel.On("GetAll", mock.Anything, mock.AnythingOfType("*models.TaskFilter")).Return(tasks, 1, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks", nil)
+		routerCamel.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Contains(t, response, "totalPages")
+		assert.Contains(t, response, "pageSize")
+		assert.NotContains(t, response, "total_pages")
+		task := response["tasks"].([]interface{})[0].(map[string]interface{})
+		assert.Contains(t, task, "createdAt")
+		assert.Contains(t, task, "updatedAt")
+	})
+
+	t.Run("Pretty JSON", func(t *testing.T) {
+		tasks := []models.Task{
+			*models.NewTask("Task 1", "Desc 1", "user1@example.com", models.TaskStatusPending),
+		}
+
+		mockRepoPretty := new(MockTaskRepository)
+		mockRepoPretty.On("GetAll", mock.Anything, mock.AnythingOfType("*models.TaskFilter")).Return(tasks, 1, nil)
+		routerPretty := setupRouter(service.NewTaskService(mockRepoPretty, nil), WithPrettyJSON(true))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks?pretty=true", nil)
+		routerPretty.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "\n    \"tasks\": [")
+
+		// Without the option the query parameter is ignored
+		mockRepoCompact := new(MockTaskRepository)
+		mockRepoCompact.On("GetAll", mock.Anything, mock.AnythingOfType("*models.TaskFilter")).Return(tasks, 1, nil)
+		routerCompact := setupRouter(service.NewTaskService(mockRepoCompact, nil))
+
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest("GET", "/api/v1/tasks?pretty=true", nil)
+		routerCompact.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "\n")
+	})
+
+	t.Run("Empty Status Means No Filter", func(t *testing.T) {
+		mockRepoEmpty := new(MockTaskRepository)
+		routerEmpty := setupRouter(service.NewTaskService(mockRepoEmpty, nil))
+
+		mockRepoEmpty.On("GetAll", mock.Anything, mock.MatchedBy(func(f *models.TaskFilter) bool {
+			return f.Status == nil
+		})).Return([]models.Task{}, 0, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks?status=", nil)
+		routerEmpty.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepoEmpty.AssertExpectations(t)
+	})
+
+	t.Run("Repeated Assignee", func(t *testing.T) {
+		mockRepoMulti := new(MockTaskRepository)
+		routerMulti := setupRouter(service.NewTaskService(mockRepoMulti, nil))
+
+		mockRepoMulti.On("GetAll", mock.Anything, mock.MatchedBy(func(f *models.TaskFilter) bool {
+			return assert.ObjectsAreEqual([]string{"alice@example.com", "bob@example.com"}, f.Assignees)
+		})).Return([]models.Task{}, 0, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks?assignee=bob@example.com&assignee=alice@example.com", nil)
+		routerMulti.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepoMulti.AssertExpectations(t)
+	})
+
+	t.Run("Empty Assignee Means Unassigned", func(t *testing.T) {
+		mockRepoBlank := new(MockTaskRepository)
+		routerBlank := setupRouter(service.NewTaskService(mockRepoBlank, nil))
+
+		mockRepoBlank.On("GetAll", mock.Anything, mock.MatchedBy(func(f *models.TaskFilter) bool {
+			return assert.ObjectsAreEqual([]string{""}, f.Assignees)
+		})).Return([]models.Task{}, 0, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks?assignee=&applied_filters=true", nil)
+		routerBlank.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"assignee":""`)
+		mockRepoBlank.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Status", func(t *testing.T) {
+		mockRepo3 := new(MockTaskRepository)
+		mockService3 := service.NewTaskService(mockRepo3, nil)
+		router3 := setupRouter(mockService3)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks?status=invalid_status", nil)
+		router3.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestTaskIDValidation_Handler(t *testing.T) {
+	t.Run("Malformed ID rejected before lookup", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil), WithTaskIDValidation(true))
+
+		for _, method := range []string{"GET", "PUT", "DELETE"} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(method, "/api/v1/tasks/not-a-uuid", bytes.NewBufferString(`{"title":"x"}`))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, method)
+			assert.Contains(t, w.Body.String(), "invalid_task_id", method)
+		}
+		mockRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Well-formed ID looked up", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil), WithTaskIDValidation(true))
+
+		id := "550e8400-e29b-41d4-a716-446655440000"
+		mockRepo.On("GetByID", mock.Anything, id).Return(nil, repository.ErrTaskNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks/"+id, nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Any ID looked up by default", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		mockRepo.On("GetByID", mock.Anything, "not-a-uuid").Return(nil, repository.ErrTaskNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks/not-a-uuid", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestListTaskChanges_Handler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockService := service.NewTaskService(mockRepo, nil)
+		router := setupRouter(mockService)
+
+		since := time.Date(2025, 11, 1, 10, 0, 0, 0, time.UTC)
+		task := models.NewTask("Task 1", "Desc 1", "user1@example.com", models.TaskStatusPending)
+		mockRepo.On("GetChangedSince", mock.Anything, mock.MatchedBy(func(t time.Time) bool {
+			return t.Equal(since)
+		}), "", 101).Return([]models.Task{*task}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks/changes?since=2025-11-01T10:00:00Z", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response models.TaskChangesResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Tasks, 1)
+		assert.False(t, response.HasMore)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Missing Since", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockService := service.NewTaskService(mockRepo, nil)
+		router := setupRouter(mockService)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks/changes", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Invalid Since", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockService := service.NewTaskService(mockRepo, nil)
+		router := setupRouter(mockService)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks/changes?since=yesterday", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Cursor Without Since", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		stamp := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
+		mockRepo.On("GetChangedSince", mock.Anything, mock.MatchedBy(func(t time.Time) bool {
+			return t.Equal(stamp)
+		}), "task-1", 101).Return([]models.Task{}, nil)
+
+		cursor := base64.RawURLEncoding.EncodeToString([]byte(`{"t":"2025-11-01T12:00:00Z","id":"task-1"}`))
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks/changes?cursor="+cursor, nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response models.TaskChangesResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, cursor, response.NextCursor)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Cursor", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks/changes?cursor=garbage!", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestCreateTask_InvalidStatus(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/tasks", bytes.NewBufferString(`{"title": "Task", "status": "bogus"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid status")
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestImportTasks_MissingRequiredFields(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	router := setupRouter(service.NewTaskService(mockRepo, nil, service.WithRequiredFields([]string{"assignee"})))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/tasks/import", bytes.NewBufferString(
+		`{"tasks":[{"title":"Legacy","created_at":"2023-03-01T09:30:00Z"}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "missing_required_fields", response["code"])
+	assert.Equal(t, []interface{}{"assignee"}, response["fields"])
+	mockRepo.AssertNotCalled(t, "CreateMany", mock.Anything, mock.Anything)
+}
+
+func TestCreateTask_MissingRequiredFields(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	router := setupRouter(service.NewTaskService(mockRepo, nil, service.WithRequiredFields([]string{"title", "assignee"})))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/tasks", bytes.NewBufferString(`{"title": "Task"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "missing_required_fields", response["code"])
+	assert.Equal(t, []interface{}{"assignee"}, response["fields"])
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestListRecentTasks_Handler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		task := models.NewTask("Task 1", "Desc 1", "user1@example.com", models.TaskStatusPending)
+		mockRepo.On("GetRecentlyUpdated", mock.Anything, 5).Return([]models.Task{*task}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks/recent?limit=5", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response models.RecentTasksResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Tasks, 1)
+		assert.Equal(t, task.ID, response.Tasks[0].ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Limit", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks/recent?limit=many", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGetCompletionStats_Handler(t *testing.T) {
+	t.Run("Grouped by assignee", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockService := service.NewTaskService(mockRepo, nil)
+		router := setupRouter(mockService)
+
+		since := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+		until := time.Date(2025, 11, 8, 0, 0, 0, 0, time.UTC)
+		mockRepo.On("CompletionsByAssignee", mock.Anything, since, until, (*string)(nil)).
+			Return(map[string]int{"a@example.com": 2, "b@example.com": 5}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks/stats/completions?since=2025-11-01T00:00:00Z&until=2025-11-08T00:00:00Z&group_by=assignee", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response models.CompletionStatsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, 7, response.Completed)
+		assert.True(t, response.Approximate)
+		assert.Equal(t, []models.AssigneeCompletions{
+			{Assignee: "b@example.com", Completed: 5},
+			{Assignee: "a@example.com", Completed: 2},
+		}, response.ByAssignee)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Single assignee", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockService := service.NewTaskService(mockRepo, nil)
+		router := setupRouter(mockService)
+
+		mockRepo.On("CompletionsByAssignee", mock.Anything, mock.Anything, mock.Anything, mock.MatchedBy(func(a *string) bool {
+			return a != nil && *a == "a@example.com"
+		})).Return(map[string]int{"a@example.com": 3}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks/stats/completions?since=2025-11-01T00:00:00Z&assignee=a@example.com", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response models.CompletionStatsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, 3, response.Completed)
+		assert.Empty(t, response.ByAssignee)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid window", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockService := service.NewTaskService(mockRepo, nil)
+		router := setupRouter(mockService)
+
+		for _, query := range []string{
+			"",
+			"?since=2025-11-08T00:00:00Z&until=2025-11-01T00:00:00Z",
+			"?since=2025-11-01T00:00:00Z&group_by=status",
+		} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/v1/tasks/stats/completions"+query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+		mockRepo.AssertNotCalled(t, "CompletionsByAssignee", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestValidateTask_Handler(t *testing.T) {
+	validate := func(router *gin.Engine, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/tasks/validate", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		w := validate(router, `{"title": "Write docs", "status": "pending"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"valid": true, "errors": []}`, w.Body.String())
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Reports every invalid field", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil, service.WithRequiredFields([]string{"title", "assignee"})))
+
+		w := validate(router, `{"status": "bogus", "external_id": "PROJ-1"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var result models.ValidationResult
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		assert.False(t, result.Valid)
+		assert.Equal(t, []models.FieldError{
+			{Field: "title", Message: "is required"},
+			{Field: "status", Message: "invalid status"},
+			{Field: "external_id", Message: "requires a source"},
+			{Field: "assignee", Message: "is required"},
+		}, result.Errors)
+	})
+
+	t.Run("Malformed body", func(t *testing.T) {
+		router := setupRouter(service.NewTaskService(new(MockTaskRepository), nil))
+
+		assert.Equal(t, http.StatusBadRequest, validate(router, `{"title": `).Code)
+	})
+}
+
+func TestGetTrend_Handler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		from := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+		mockRepo.On("Trend", mock.Anything, "month", from, to).
+			Return([]models.TrendBucket{{Start: from, Created: 9, Completed: 6}}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/tasks/stats/trend?from=2025-11-01T00:00:00Z&to=2025-12-01T00:00:00Z&interval=month", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response models.TrendResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "month", response.Interval)
+		assert.Equal(t, []models.TrendBucket{{Start: from, Created: 9, Completed: 6}}, response.Buckets)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid query", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		for _, query := range []string{
+			"",
+			"?from=2025-11-01T00:00:00Z&interval=year",
+			"?from=2020-01-01T00:00:00Z&to=2025-01-01T00:00:00Z",
+		} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/v1/tasks/stats/trend"+query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+		mockRepo.AssertNotCalled(t, "Trend", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
 
 func TestUpdateTask_Handler(t *testing.T) {
 	mockRepo := new(MockTaskRepository)
@@ -288,9 +1572,120 @@ func TestUpdateTask_Handler(t *testing.T) {
 		router2.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusNotFound, w.Code)
+		assertTaskNotFoundBody(t, w, "nonexistent")
 		mockRepo2.AssertExpectations(t)
 	})
 
+	t.Run("Invalid status", func(t *testing.T) {
+		bodies := map[string]string{
+			"application/json":             `{"status":"bogus"}`,
+			"application/merge-patch+json": `{"status":"bogus"}`,
+			"application/json-patch+json":  `[{"op":"replace","path":"/status","value":"bogus"}]`,
+		}
+		for contentType, body := range bodies {
+			mockRepoStatus := new(MockTaskRepository)
+			routerStatus := setupRouter(service.NewTaskService(mockRepoStatus, nil))
+
+			task := models.NewTask("Title", "Desc", "user@example.com", models.TaskStatusPending)
+			mockRepoStatus.On("GetByID", mock.Anything, task.ID).Return(task, nil)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("PUT", "/api/v1/tasks/"+task.ID, bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", contentType)
+			routerStatus.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, contentType)
+			assert.Contains(t, w.Body.String(), "invalid status", contentType)
+			mockRepoStatus.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("Merge Patch", func(t *testing.T) {
+		mockRepoPatch := new(MockTaskRepository)
+		mockServicePatch := service.NewTaskService(mockRepoPatch, nil)
+		routerPatch := setupRouter(mockServicePatch)
+
+		task := models.NewTask("Title", "Desc", "user@example.com", models.TaskStatusPending)
+		mockRepoPatch.On("GetByID", mock.Anything, task.ID).Return(task, nil)
+		mockRepoPatch.On("Update", mock.Anything, mock.AnythingOfType("*models.Task")).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/tasks/"+task.ID, bytes.NewBufferString(`{"assignee":null,"title":"Renamed"}`))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		routerPatch.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response models.Task
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "Renamed", response.Title)
+		assert.Equal(t, "", response.Assignee)
+		assert.Equal(t, "Desc", response.Description)
+		mockRepoPatch.AssertExpectations(t)
+	})
+
+	t.Run("JSON Patch", func(t *testing.T) {
+		mockRepoPatch := new(MockTaskRepository)
+		routerPatch := setupRouter(service.NewTaskService(mockRepoPatch, nil))
+
+		task := models.NewTask("Title", "Desc", "user@example.com", models.TaskStatusPending)
+		mockRepoPatch.On("GetByID", mock.Anything, task.ID).Return(task, nil)
+		mockRepoPatch.On("Update", mock.Anything, mock.AnythingOfType("*models.Task")).Return(nil)
+
+		body := `[
+			{"op":"replace","path":"/status","value":"in_progress"},
+			{"op":"remove","path":"/description"}
+		]`
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/tasks/"+task.ID, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json-patch+json")
+		routerPatch.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response models.Task
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, models.TaskStatusInProgress, response.Status)
+		assert.Equal(t, "", response.Description)
+		assert.Equal(t, "Title", response.Title)
+		mockRepoPatch.AssertExpectations(t)
+	})
+
+	t.Run("JSON Patch failed test", func(t *testing.T) {
+		mockRepoPatch := new(MockTaskRepository)
+		routerPatch := setupRouter(service.NewTaskService(mockRepoPatch, nil))
+
+		task := models.NewTask("Title", "Desc", "user@example.com", models.TaskStatusPending)
+		mockRepoPatch.On("GetByID", mock.Anything, task.ID).Return(task, nil)
+
+		body := `[
+			{"op":"test","path":"/status","value":"completed"},
+			{"op":"replace","path":"/status","value":"cancelled"}
+		]`
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/tasks/"+task.ID, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json-patch+json")
+		routerPatch.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		mockRepoPatch.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("JSON Patch on immutable field", func(t *testing.T) {
+		mockRepoPatch := new(MockTaskRepository)
+		routerPatch := setupRouter(service.NewTaskService(mockRepoPatch, nil))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/tasks/some-id", bytes.NewBufferString(`[{"op":"replace","path":"/id","value":"other"}]`))
+		req.Header.Set("Content-Type", "application/json-patch+json")
+		routerPatch.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "id is immutable")
+		mockRepoPatch.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
 	t.Run("Invalid JSON", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest("PUT", "/api/v1/tasks/some-id", bytes.NewBufferString("invalid"))
@@ -322,6 +1717,244 @@ func TestUpdateTask_Handler(t *testing.T) {
 	})
 }
 
+func TestUpsertTaskByExternalID_Handler(t *testing.T) {
+	stored := models.NewTask("Synced", "", "", models.TaskStatusPending)
+	source, externalID := "jira", "PROJ-1"
+	stored.Source, stored.ExternalID = &source, &externalID
+
+	for _, tc := range []struct {
+		name    string
+		created bool
+		code    int
+	}{
+		{"Created", true, http.StatusCreated},
+		{"Updated", false, http.StatusOK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+			mockRepo.On("UpsertByExternalID", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+				return *task.Source == "jira" && *task.ExternalID == "PROJ-1"
+			})).Return(stored, tc.created, nil)
+
+			// Path values win over the body
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("PUT", "/api/v1/tasks/external/jira/PROJ-1",
+				bytes.NewBufferString(`{"title":"Synced","source":"github","external_id":"7"}`))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.code, w.Code)
+
+			var response models.Task
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "PROJ-1", *response.ExternalID)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("Invalid status", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/tasks/external/jira/PROJ-1",
+			bytes.NewBufferString(`{"title":"Synced","status":"bogus"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockRepo.AssertNotCalled(t, "UpsertByExternalID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Duplicate on create", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Task")).Return(repository.ErrDuplicateExternalID)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/tasks",
+			bytes.NewBufferString(`{"title":"Synced","source":"jira","external_id":"PROJ-1"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "external ID")
+	})
+
+	t.Run("External ID without source", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/tasks", bytes.NewBufferString(`{"title":"Synced","external_id":"PROJ-1"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestCreateTask_Handler_IfNotExists(t *testing.T) {
+	stored := models.NewTask("Synced", "", "", models.TaskStatusPending)
+	source, externalID := "jira", "PROJ-1"
+	stored.Source, stored.ExternalID = &source, &externalID
+
+	for _, tc := range []struct {
+		name    string
+		created bool
+		code    int
+	}{
+		{"Created", true, http.StatusCreated},
+		{"Existing", false, http.StatusOK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+			mockRepo.On("CreateIfNotExists", mock.Anything, mock.AnythingOfType("*models.Task")).Return(stored, tc.created, nil)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/v1/tasks?if_not_exists=external_id",
+				bytes.NewBufferString(`{"title":"Synced","source":"jira","external_id":"PROJ-1"}`))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.code, w.Code)
+
+			var response models.Task
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, stored.ID, response.ID)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("Unknown key", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/tasks?if_not_exists=title",
+			bytes.NewBufferString(`{"title":"Synced","source":"jira","external_id":"PROJ-1"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockRepo.AssertNotCalled(t, "CreateIfNotExists", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestReassignTasks_Handler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		task := models.NewTask("Task", "Desc", "new@example.com", models.TaskStatusPending)
+		mockRepo.On("ReassignAll", mock.Anything, "old@example.com", "new@example.com", (*models.TaskStatus)(nil), mock.AnythingOfType("time.Time")).
+			Return([]models.Task{*task}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/tasks/reassign", bytes.NewBufferString(`{"from":"old@example.com","to":"new@example.com"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response models.ReassignTasksResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, 1, response.Reassigned)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Same Assignee", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/tasks/reassign", bytes.NewBufferString(`{"from":"old@example.com","to":"old@example.com"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		mockRepo.On("ReassignAll", mock.Anything, "old@example.com", "new@example.com", (*models.TaskStatus)(nil), mock.AnythingOfType("time.Time")).
+			Return(nil, repository.ErrDuplicateTask)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/tasks/reassign", bytes.NewBufferString(`{"from":"old@example.com","to":"new@example.com"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestAssignTask_Handler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockService := service.NewTaskService(mockRepo, nil)
+		router := setupRouter(mockService)
+
+		task := models.NewTask("Task", "Desc", "new@example.com", models.TaskStatusPending)
+		mockRepo.On("UpdateAssignee", mock.Anything, task.ID, "new@example.com", mock.AnythingOfType("time.Time")).
+			Return(task, "old@example.com", nil)
+
+		body, _ := json.Marshal(models.AssignTaskRequest{Assignee: "new@example.com"})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/tasks/"+task.ID+"/assign", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response models.Task
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "new@example.com", response.Assignee)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Email", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockService := service.NewTaskService(mockRepo, nil)
+		router := setupRouter(mockService)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/tasks/some-id/assign", bytes.NewBufferString(`{"assignee":"not-an-email"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockRepo.AssertNotCalled(t, "UpdateAssignee")
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockService := service.NewTaskService(mockRepo, nil)
+		router := setupRouter(mockService)
+
+		mockRepo.On("UpdateAssignee", mock.Anything, "nonexistent", "new@example.com", mock.AnythingOfType("time.Time")).
+			Return(nil, "", repository.ErrTaskNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/tasks/nonexistent/assign", bytes.NewBufferString(`{"assignee":"new@example.com"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assertTaskNotFoundBody(t, w, "nonexistent")
+	})
+}
+
 func TestDeleteTask_Handler(t *testing.T) {
 	mockRepo := new(MockTaskRepository)
 	mockService := service.NewTaskService(mockRepo, nil)
@@ -351,6 +1984,7 @@ func TestDeleteTask_Handler(t *testing.T) {
 		router2.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusNotFound, w.Code)
+		assertTaskNotFoundBody(t, w, "nonexistent")
 		mockRepo2.AssertExpectations(t)
 	})
 
@@ -370,6 +2004,204 @@ func TestDeleteTask_Handler(t *testing.T) {
 	})
 }
 
+func TestDeleteTask_Handler_IfMatch(t *testing.T) {
+	task := models.NewTask("Task", "Desc", "user@example.com", models.TaskStatusPending)
+
+	t.Run("Matching ETag", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		mockRepo.On("GetByID", mock.Anything, task.ID).Return(task, nil)
+		mockRepo.On("DeleteIfUnmodified", mock.Anything, task.ID, task.UpdatedAt).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/api/v1/tasks/"+task.ID, nil)
+		req.Header.Set("If-Match", task.ETag())
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Stale ETag", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		mockRepo.On("GetByID", mock.Anything, task.ID).Return(task, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/api/v1/tasks/"+task.ID, nil)
+		req.Header.Set("If-Match", `"stale"`)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		mockRepo.AssertNotCalled(t, "DeleteIfUnmodified", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		mockRepo.On("GetByID", mock.Anything, "nonexistent").Return(nil, repository.ErrTaskNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/api/v1/tasks/nonexistent", nil)
+		req.Header.Set("If-Match", "*")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assertTaskNotFoundBody(t, w, "nonexistent")
+	})
+
+	t.Run("Modified Concurrently", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		mockRepo.On("GetByID", mock.Anything, task.ID).Return(task, nil)
+		mockRepo.On("DeleteIfUnmodified", mock.Anything, task.ID, task.UpdatedAt).Return(repository.ErrTaskModified)
+		mockRepo.On("GetUpdatedAt", mock.Anything, task.ID).Return(task.UpdatedAt.Add(time.Second), nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/api/v1/tasks/"+task.ID, nil)
+		req.Header.Set("If-Match", task.ETag())
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Deleted Concurrently", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		router := setupRouter(service.NewTaskService(mockRepo, nil))
+
+		mockRepo.On("GetByID", mock.Anything, task.ID).Return(task, nil)
+		mockRepo.On("DeleteIfUnmodified", mock.Anything, task.ID, task.UpdatedAt).Return(repository.ErrTaskModified)
+		mockRepo.On("GetUpdatedAt", mock.Anything, task.ID).Return(time.Time{}, repository.ErrTaskNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/api/v1/tasks/"+task.ID, nil)
+		req.Header.Set("If-Match", task.ETag())
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assertTaskNotFoundBody(t, w, task.ID)
+	})
+}
+
+func TestPurgeCompletedTasks_Handler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockService := service.NewTaskService(mockRepo, nil)
+		router := setupRouter(mockService)
+
+		cutoff := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+		mockRepo.On("PurgeCompletedBefore", mock.Anything, cutoff).Return(3, nil)
+
+		body, _ := json.Marshal(models.PurgeTasksRequest{Before: cutoff})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/admin/tasks/purge", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response models.PurgeTasksResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, response.Purged)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Dry Run", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockService := service.NewTaskService(mockRepo, nil)
+		router := setupRouter(mockService)
+
+		cutoff := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+		mockRepo.On("ListCompletedBefore", mock.Anything, cutoff, 100).Return([]string{"a", "b"}, 2, nil)
+
+		body, _ := json.Marshal(models.PurgeTasksRequest{Before: cutoff})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/admin/tasks/purge?dry_run=true", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response models.PurgeTasksResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.True(t, response.DryRun)
+		assert.Equal(t, 2, response.Purged)
+		assert.Equal(t, []string{"a", "b"}, response.IDs)
+		assert.False(t, response.IDsTruncated)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "PurgeCompletedBefore", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Dry Run Truncated", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockService := service.NewTaskService(mockRepo, nil)
+		router := setupRouter(mockService)
+
+		cutoff := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+		ids := make([]string, 100)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("task-%d", i)
+		}
+		mockRepo.On("ListCompletedBefore", mock.Anything, cutoff, 100).Return(ids, 250, nil)
+
+		body, _ := json.Marshal(models.PurgeTasksRequest{Before: cutoff})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/admin/tasks/purge?dry_run=true", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response models.PurgeTasksResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, 250, response.Purged)
+		assert.Len(t, response.IDs, 100)
+		assert.True(t, response.IDsTruncated)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Missing Cutoff", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		mockService := service.NewTaskService(mockRepo, nil)
+		router := setupRouter(mockService)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/admin/tasks/purge", bytes.NewBufferString("{}"))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestListStatuses_Handler(t *testing.T) {
+	router := setupRouter(service.NewTaskService(new(MockTaskRepository), nil))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/meta/statuses", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response models.StatusesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, models.AllStatuses(), response.Statuses)
+	assert.Len(t, response.Transitions, 4)
+	assert.NotContains(t, response.Transitions[models.TaskStatusPending], models.TaskStatusPending)
+	assert.Contains(t, response.Transitions[models.TaskStatusPending], models.TaskStatusCompleted)
+}
+
 func TestNewTaskHandler(t *testing.T) {
 	mockService := &service.TaskService{}
 	handler := NewTaskHandler(mockService)
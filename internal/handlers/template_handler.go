@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/Ali-Gorgani/task-manager/internal/repository"
+	"github.com/Ali-Gorgani/task-manager/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// TemplateHandler handles HTTP requests for task templates
+type TemplateHandler struct {
+	service *service.TemplateService
+	tasks   *TaskHandler
+}
+
+// NewTemplateHandler creates a new template handler. Request binding and
+// response rendering follow the options of tasks, so templates and the tasks
+// created from them are encoded the same way.
+func NewTemplateHandler(service *service.TemplateService, tasks *TaskHandler) *TemplateHandler {
+	return &TemplateHandler{service: service, tasks: tasks}
+}
+
+// CreateTemplate godoc
+// @Summary Create a task template
+// @Description Create a reusable template. Title and description may contain {{name}} placeholders, filled in when the template is instantiated.
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param template body models.CreateTemplateRequest true "Template object"
+// @Success 201 {object} models.TaskTemplate
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/templates [post]
+func (h *TemplateHandler) CreateTemplate(c *gin.Context) {
+	var req models.CreateTemplateRequest
+	if err := h.tasks.bindJSON(c, &req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	template, err := h.service.CreateTemplate(c.Request.Context(), &req)
+	if err != nil {
+		respondTemplateError(c, "", err)
+		return
+	}
+
+	h.tasks.render(c, http.StatusCreated, template)
+}
+
+// ListTemplates godoc
+// @Summary List task templates
+// @Description Get every task template, ordered by name
+// @Tags templates
+// @Produce json
+// @Success 200 {object} models.TemplateListResponse
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/templates [get]
+func (h *TemplateHandler) ListTemplates(c *gin.Context) {
+	response, err := h.service.ListTemplates(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.tasks.render(c, http.StatusOK, response)
+}
+
+// GetTemplate godoc
+// @Summary Get a task template by ID
+// @Tags templates
+// @Produce json
+// @Param id path string true "Template ID"
+// @Success 200 {object} models.TaskTemplate
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/templates/{id} [get]
+func (h *TemplateHandler) GetTemplate(c *gin.Context) {
+	id := c.Param("id")
+	template, err := h.service.GetTemplate(c.Request.Context(), id)
+	if err != nil {
+		respondTemplateError(c, id, err)
+		return
+	}
+
+	h.tasks.render(c, http.StatusOK, template)
+}
+
+// UpdateTemplate godoc
+// @Summary Update a task template
+// @Description Update the fields of a template present in the body
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID"
+// @Param template body models.UpdateTemplateRequest true "Fields to update"
+// @Success 200 {object} models.TaskTemplate
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/templates/{id} [put]
+func (h *TemplateHandler) UpdateTemplate(c *gin.Context) {
+	id := c.Param("id")
+	var req models.UpdateTemplateRequest
+	if err := h.tasks.bindJSON(c, &req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	template, err := h.service.UpdateTemplate(c.Request.Context(), id, &req)
+	if err != nil {
+		respondTemplateError(c, id, err)
+		return
+	}
+
+	h.tasks.render(c, http.StatusOK, template)
+}
+
+// DeleteTemplate godoc
+// @Summary Delete a task template
+// @Description Delete a template. Tasks created from it are kept.
+// @Tags templates
+// @Param id path string true "Template ID"
+// @Success 204 "No Content"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/templates/{id} [delete]
+func (h *TemplateHandler) DeleteTemplate(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.service.DeleteTemplate(c.Request.Context(), id); err != nil {
+		respondTemplateError(c, id, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// InstantiateTemplate godoc
+// @Summary Create a task from a template
+// @Description Create a task from a template. variables fill the {{name}} placeholders in the template's title and description ({{date}} defaults to today's UTC date); title, description, status and assignee replace the template's values. The body is optional.
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID"
+// @Param overrides body models.InstantiateTemplateRequest false "Variables and overrides"
+// @Success 201 {object} models.Task
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/templates/{id}/instantiate [post]
+func (h *TemplateHandler) InstantiateTemplate(c *gin.Context) {
+	id := c.Param("id")
+	var req models.InstantiateTemplateRequest
+	if c.Request.ContentLength != 0 {
+		if err := h.tasks.bindJSON(c, &req); err != nil && !errors.Is(err, io.EOF) {
+			respondBindError(c, err)
+			return
+		}
+	}
+
+	task, err := h.service.InstantiateTemplate(c.Request.Context(), id, &req)
+	if err != nil {
+		respondTemplateError(c, id, err)
+		return
+	}
+
+	h.tasks.render(c, http.StatusCreated, task)
+}
+
+// respondTemplateError maps a template service error onto its status code,
+// including the task errors returned when a template is instantiated
+func respondTemplateError(c *gin.Context, id string, err error) {
+	if respondDuplicate(c, err) || respondMissingFields(c, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, repository.ErrTemplateNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "template not found",
+			"code":  "template_not_found",
+			"id":    id,
+		})
+	case errors.Is(err, repository.ErrDuplicateTemplate):
+		c.JSON(http.StatusConflict, gin.H{"error": repository.ErrDuplicateTemplate.Error()})
+	case errors.Is(err, repository.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/Ali-Gorgani/task-manager/internal/repository"
+	"github.com/Ali-Gorgani/task-manager/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTemplateRepository is a mock implementation for testing
+type MockTemplateRepository struct {
+	mock.Mock
+}
+
+func (m *MockTemplateRepository) Create(ctx context.Context, template *models.TaskTemplate) error {
+	args := m.Called(ctx, template)
+	return args.Error(0)
+}
+
+func (m *MockTemplateRepository) GetByID(ctx context.Context, id string) (*models.TaskTemplate, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTemplateRepository) List(ctx context.Context) ([]models.TaskTemplate, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTemplateRepository) Update(ctx context.Context, template *models.TaskTemplate) error {
+	args := m.Called(ctx, template)
+	return args.Error(0)
+}
+
+func (m *MockTemplateRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func setupTemplateRouter(templateRepo *MockTemplateRepository, taskRepo *MockTaskRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	taskService := service.NewTaskService(taskRepo, nil)
+	handler := NewTemplateHandler(service.NewTemplateService(templateRepo, taskService), NewTaskHandler(taskService))
+
+	templates := router.Group("/api/v1/templates")
+	{
+		templates.POST("", handler.CreateTemplate)
+		templates.GET("", handler.ListTemplates)
+		templates.GET("/:id", handler.GetTemplate)
+		templates.PUT("/:id", handler.UpdateTemplate)
+		templates.DELETE("/:id", handler.DeleteTemplate)
+		templates.POST("/:id/instantiate", handler.InstantiateTemplate)
+	}
+	return router
+}
+
+func TestCreateTemplate_Handler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		templateRepo := new(MockTemplateRepository)
+		router := setupTemplateRouter(templateRepo, new(MockTaskRepository))
+
+		templateRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.TaskTemplate")).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/templates", bytes.NewBufferString(`{"name": "weekly-report", "title": "Weekly report {{date}}"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var template models.TaskTemplate
+		err := json.Unmarshal(w.Body.Bytes(), &template)
+		assert.NoError(t, err)
+		assert.Equal(t, "weekly-report", template.Name)
+		assert.NotEmpty(t, template.ID)
+	})
+
+	t.Run("Duplicate Name", func(t *testing.T) {
+		templateRepo := new(MockTemplateRepository)
+		router := setupTemplateRouter(templateRepo, new(MockTaskRepository))
+
+		templateRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.TaskTemplate")).Return(repository.ErrDuplicateTemplate)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/templates", bytes.NewBufferString(`{"name": "weekly-report", "title": "Weekly report"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Missing Title", func(t *testing.T) {
+		templateRepo := new(MockTemplateRepository)
+		router := setupTemplateRouter(templateRepo, new(MockTaskRepository))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/templates", bytes.NewBufferString(`{"name": "weekly-report"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		templateRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetTemplate_NotFound(t *testing.T) {
+	templateRepo := new(MockTemplateRepository)
+	router := setupTemplateRouter(templateRepo, new(MockTaskRepository))
+
+	templateRepo.On("GetByID", mock.Anything, "missing").Return(nil, repository.ErrTemplateNotFound)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/templates/missing", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "template_not_found")
+}
+
+func TestDeleteTemplate_Handler(t *testing.T) {
+	templateRepo := new(MockTemplateRepository)
+	router := setupTemplateRouter(templateRepo, new(MockTaskRepository))
+
+	templateRepo.On("Delete", mock.Anything, "template-id").Return(nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/api/v1/templates/template-id", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	templateRepo.AssertExpectations(t)
+}
+
+func TestInstantiateTemplate_Handler(t *testing.T) {
+	template := models.NewTaskTemplate("onboarding", "Onboard {{name}}", "", "hr@example.com", "")
+
+	t.Run("Success", func(t *testing.T) {
+		templateRepo := new(MockTemplateRepository)
+		taskRepo := new(MockTaskRepository)
+		router := setupTemplateRouter(templateRepo, taskRepo)
+
+		templateRepo.On("GetByID", mock.Anything, template.ID).Return(template, nil)
+		taskRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Task")).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/templates/"+template.ID+"/instantiate",
+			bytes.NewBufferString(`{"variables": {"name": "Sam"}, "assignee": "it@example.com"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var task models.Task
+		err := json.Unmarshal(w.Body.Bytes(), &task)
+		assert.NoError(t, err)
+		assert.Equal(t, "Onboard Sam", task.Title)
+		assert.Equal(t, "it@example.com", task.Assignee)
+		assert.Equal(t, models.TaskStatusPending, task.Status)
+		taskRepo.AssertExpectations(t)
+	})
+
+	t.Run("Missing Variable Without Body", func(t *testing.T) {
+		templateRepo := new(MockTemplateRepository)
+		taskRepo := new(MockTaskRepository)
+		router := setupTemplateRouter(templateRepo, taskRepo)
+
+		templateRepo.On("GetByID", mock.Anything, template.ID).Return(template, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/templates/"+template.ID+"/instantiate", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "name")
+		taskRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Duplicate Task", func(t *testing.T) {
+		templateRepo := new(MockTemplateRepository)
+		taskRepo := new(MockTaskRepository)
+		router := setupTemplateRouter(templateRepo, taskRepo)
+
+		templateRepo.On("GetByID", mock.Anything, template.ID).Return(template, nil)
+		taskRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Task")).Return(repository.ErrDuplicateTask)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/templates/"+template.ID+"/instantiate",
+			bytes.NewBufferString(`{"variables": {"name": "Sam"}}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), repository.ErrDuplicateTask.Error())
+	})
+}
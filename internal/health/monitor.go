@@ -0,0 +1,148 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Status summarizes a check across all dependencies
+type Status string
+
+const (
+	// StatusHealthy means every dependency is reachable
+	StatusHealthy Status = "healthy"
+	// StatusDegraded means only non-critical dependencies are down, so the
+	// service still serves requests
+	StatusDegraded Status = "degraded"
+	// StatusUnhealthy means a critical dependency is down
+	StatusUnhealthy Status = "unhealthy"
+)
+
+// Dependency is the outcome of checking a single dependency
+type Dependency struct {
+	Name     string
+	Critical bool
+	Err      error
+	Latency  time.Duration
+}
+
+// Probe runs ping against the dependency called name and times it
+func Probe(ctx context.Context, name string, critical bool, ping func(ctx context.Context) error) Dependency {
+	start := time.Now()
+	err := ping(ctx)
+	return Dependency{Name: name, Critical: critical, Err: err, Latency: time.Since(start)}
+}
+
+// Result is the outcome of the most recent dependency check
+type Result struct {
+	Dependencies []Dependency
+	CheckedAt    time.Time
+}
+
+// Checked reports whether a check has completed yet
+func (r Result) Checked() bool {
+	return !r.CheckedAt.IsZero()
+}
+
+// Status is unhealthy when a critical dependency failed, degraded when any
+// other dependency failed and healthy otherwise
+func (r Result) Status() Status {
+	status := StatusHealthy
+	for _, dep := range r.Dependencies {
+		if dep.Err == nil {
+			continue
+		}
+		if dep.Critical {
+			return StatusUnhealthy
+		}
+		status = StatusDegraded
+	}
+	return status
+}
+
+// Err joins the failures of all dependencies, each prefixed with its name
+func (r Result) Err() error {
+	var errs []error
+	for _, dep := range r.Dependencies {
+		if dep.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", dep.Name, dep.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// staleIntervals is how many check intervals may pass without a completed
+// check before the last result is considered stale
+const staleIntervals = 3
+
+// Monitor runs a dependency check periodically and keeps the last result, so
+// readiness probes can be answered without pinging dependencies each time
+type Monitor struct {
+	check   func(ctx context.Context) []Dependency
+	timeout time.Duration
+
+	mu       sync.RWMutex
+	last     Result
+	interval time.Duration
+}
+
+// NewMonitor creates a monitor for check. Each check is given at most timeout.
+func NewMonitor(check func(ctx context.Context) []Dependency, timeout time.Duration) *Monitor {
+	return &Monitor{check: check, timeout: timeout}
+}
+
+// Check runs the dependency check once, records the result and returns it
+func (m *Monitor) Check(ctx context.Context) Result {
+	checkCtx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	result := Result{Dependencies: m.check(checkCtx), CheckedAt: time.Now()}
+
+	m.mu.Lock()
+	m.last = result
+	m.mu.Unlock()
+
+	return result
+}
+
+// Last returns the most recent result without running a check
+func (m *Monitor) Last() Result {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.last
+}
+
+// Stale reports whether the last result is older than three check intervals
+// plus the check timeout, which means Run has stopped or its checks are not
+// completing. It is always false before Run is called.
+func (m *Monitor) Stale() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.interval <= 0 || !m.last.Checked() {
+		return false
+	}
+	return time.Since(m.last.CheckedAt) > staleIntervals*m.interval+m.timeout
+}
+
+// Run checks immediately and then on every tick until ctx is cancelled
+func (m *Monitor) Run(ctx context.Context, interval time.Duration) {
+	m.mu.Lock()
+	m.interval = interval
+	m.mu.Unlock()
+
+	m.Check(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.Check(ctx)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonitor_Check(t *testing.T) {
+	failing := errors.New("database down")
+	var healthy atomic.Bool
+	monitor := NewMonitor(func(ctx context.Context) []Dependency {
+		return []Dependency{Probe(ctx, "database", true, func(ctx context.Context) error {
+			if healthy.Load() {
+				return nil
+			}
+			return failing
+		})}
+	}, time.Second)
+
+	assert.False(t, monitor.Last().Checked())
+
+	result := monitor.Check(context.Background())
+	assert.ErrorIs(t, result.Err(), failing)
+	assert.ErrorContains(t, result.Err(), "database: database down")
+	assert.True(t, result.Checked())
+	assert.Equal(t, result, monitor.Last())
+
+	healthy.Store(true)
+	result = monitor.Check(context.Background())
+	assert.NoError(t, result.Err())
+	assert.NoError(t, monitor.Last().Err())
+}
+
+func TestResult_Status(t *testing.T) {
+	down := errors.New("connection refused")
+	tests := []struct {
+		name     string
+		database error
+		cache    error
+		want     Status
+	}{
+		{"All up", nil, nil, StatusHealthy},
+		{"Cache down", nil, down, StatusDegraded},
+		{"Database down", down, nil, StatusUnhealthy},
+		{"Both down", down, down, StatusUnhealthy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Result{Dependencies: []Dependency{
+				{Name: "database", Critical: true, Err: tt.database},
+				{Name: "cache", Err: tt.cache},
+			}}
+			assert.Equal(t, tt.want, result.Status())
+		})
+	}
+}
+
+func TestProbe(t *testing.T) {
+	dep := Probe(context.Background(), "cache", false, func(ctx context.Context) error {
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	})
+	assert.Equal(t, "cache", dep.Name)
+	assert.False(t, dep.Critical)
+	assert.NoError(t, dep.Err)
+	assert.GreaterOrEqual(t, dep.Latency, 5*time.Millisecond)
+}
+
+func TestMonitor_CheckTimeout(t *testing.T) {
+	monitor := NewMonitor(func(ctx context.Context) []Dependency {
+		<-ctx.Done()
+		return []Dependency{{Name: "database", Critical: true, Err: ctx.Err()}}
+	}, 10*time.Millisecond)
+
+	result := monitor.Check(context.Background())
+	assert.ErrorIs(t, result.Err(), context.DeadlineExceeded)
+}
+
+func TestMonitor_Run(t *testing.T) {
+	var checks atomic.Int32
+	monitor := NewMonitor(func(ctx context.Context) []Dependency {
+		checks.Add(1)
+		return nil
+	}, time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		monitor.Run(ctx, 5*time.Millisecond)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool { return checks.Load() >= 3 }, time.Second, time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after cancellation")
+	}
+	assert.True(t, monitor.Last().Checked())
+}
+
+func TestMonitor_Stale(t *testing.T) {
+	monitor := NewMonitor(func(ctx context.Context) []Dependency {
+		return nil
+	}, time.Millisecond)
+
+	monitor.Check(context.Background())
+	assert.False(t, monitor.Stale(), "not stale before Run")
+
+	// A cancelled context makes Run check once and return, as if it stopped
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	monitor.Run(ctx, time.Millisecond)
+	assert.False(t, monitor.Stale())
+	assert.Eventually(t, monitor.Stale, time.Second, time.Millisecond)
+}
@@ -0,0 +1,53 @@
+package lifecycle
+
+import (
+	"context"
+	"sync"
+)
+
+// Group runs background workers that share a cancellable context and can be
+// stopped together during shutdown
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewGroup creates a worker group whose context derives from parent
+func NewGroup(parent context.Context) *Group {
+	ctx, cancel := context.WithCancel(parent)
+	return &Group{ctx: ctx, cancel: cancel}
+}
+
+// Context returns the context passed to workers; it is cancelled on Shutdown
+func (g *Group) Context() context.Context {
+	return g.ctx
+}
+
+// Go starts fn in a new goroutine. fn must return once ctx is cancelled.
+func (g *Group) Go(fn func(ctx context.Context)) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		fn(g.ctx)
+	}()
+}
+
+// Shutdown cancels all workers and waits for them to return, giving up when
+// ctx is done
+func (g *Group) Shutdown(ctx context.Context) error {
+	g.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,46 @@
+package lifecycle
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroup_Shutdown(t *testing.T) {
+	group := NewGroup(context.Background())
+
+	var stopped int32
+	for i := 0; i < 3; i++ {
+		group.Go(func(ctx context.Context) {
+			<-ctx.Done()
+			atomic.AddInt32(&stopped, 1)
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	err := group.Shutdown(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&stopped))
+	assert.Error(t, group.Context().Err())
+}
+
+func TestGroup_ShutdownTimeout(t *testing.T) {
+	group := NewGroup(context.Background())
+
+	release := make(chan struct{})
+	defer close(release)
+	group.Go(func(ctx context.Context) {
+		<-release
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := group.Shutdown(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
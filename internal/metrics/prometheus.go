@@ -1,12 +1,15 @@
 package metrics
 
 import (
+	"net/http"
 	"strconv"
 	"time"
 
+	"github.com/Ali-Gorgani/task-manager/internal/models"
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 var (
@@ -29,6 +32,16 @@ var (
 		[]string{"method", "endpoint"},
 	)
 
+	// ResponseSizeHistogram measures the size of HTTP response bodies
+	ResponseSizeHistogram = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "http_response_size_bytes",
+			Help:    "Histogram of HTTP response body sizes in bytes",
+			Buckets: prometheus.ExponentialBuckets(100, 4, 8),
+		},
+		[]string{"endpoint"},
+	)
+
 	// TasksCount tracks the current number of tasks
 	TasksCount = promauto.NewGauge(
 		prometheus.GaugeOpts{
@@ -36,8 +49,68 @@ var (
 			Help: "Current number of tasks in the system",
 		},
 	)
+
+	// OldestTaskAge tracks the age of the oldest open task per status
+	OldestTaskAge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tasks_oldest_age_seconds",
+			Help: "Age in seconds of the oldest task in each open status",
+		},
+		[]string{"status"},
+	)
+
+	// InFlightRequests tracks the requests currently held by the concurrency limiter
+	InFlightRequests = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "http_requests_in_flight",
+			Help: "Number of requests currently being handled under the concurrency limit",
+		},
+	)
+
+	// CacheOperationDuration measures the latency of Redis cache operations
+	CacheOperationDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "cache_operation_duration_seconds",
+			Help:    "Histogram of cache operation latencies in seconds",
+			Buckets: prometheus.ExponentialBuckets(0.0005, 2, 12),
+		},
+		[]string{"operation"},
+	)
+
+	// CacheCorruptTotal counts cached values that failed to decode and were
+	// discarded, by kind of value
+	CacheCorruptTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_corrupt_total",
+			Help: "Total number of corrupt cache values discarded",
+		},
+		[]string{"kind"},
+	)
+
+	// CacheWriteRetriesTotal counts background retries of failed cache
+	// writes by operation and outcome: queued, dropped (queue full),
+	// skipped (an invalidation made the value stale), succeeded or failed
+	// (attempts exhausted)
+	CacheWriteRetriesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_write_retries_total",
+			Help: "Total number of failed cache writes retried in the background, by outcome",
+		},
+		[]string{"operation", "outcome"},
+	)
 )
 
+// Handler serves the default registry, negotiating OpenMetrics for scrapers
+// that send Accept: application/openmetrics-text
+func Handler() http.Handler {
+	return promhttp.InstrumentMetricHandler(
+		prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{
+			EnableOpenMetrics: true,
+		}),
+	)
+}
+
 // PrometheusMiddleware is a Gin middleware that collects metrics
 func PrometheusMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -66,6 +139,13 @@ func PrometheusMiddleware() gin.HandlerFunc {
 			c.Request.Method,
 			endpoint,
 		).Observe(duration)
+
+		// Size is -1 when nothing was written, e.g. for 204 and 304 responses
+		size := c.Writer.Size()
+		if size < 0 {
+			size = 0
+		}
+		ResponseSizeHistogram.WithLabelValues(endpoint).Observe(float64(size))
 	}
 }
 
@@ -73,3 +153,11 @@ func PrometheusMiddleware() gin.HandlerFunc {
 func UpdateTasksCount(count int) {
 	TasksCount.Set(float64(count))
 }
+
+// UpdateOldestTaskAges replaces the oldest-task-age gauges. Statuses absent
+// from ages have no open tasks and are reported as zero.
+func UpdateOldestTaskAges(ages map[models.TaskStatus]time.Duration) {
+	for _, status := range []models.TaskStatus{models.TaskStatusPending, models.TaskStatusInProgress} {
+		OldestTaskAge.WithLabelValues(string(status)).Set(ages[status].Seconds())
+	}
+}
@@ -4,8 +4,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/Ali-Gorgani/task-manager/internal/models"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -104,3 +109,56 @@ func TestPrometheusMiddleware_ErrorStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateOldestTaskAges(t *testing.T) {
+	UpdateOldestTaskAges(map[models.TaskStatus]time.Duration{
+		models.TaskStatusPending: 90 * time.Second,
+	})
+
+	assert.Equal(t, 90.0, testutil.ToFloat64(OldestTaskAge.WithLabelValues("pending")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(OldestTaskAge.WithLabelValues("in_progress")))
+}
+
+func TestPrometheusMiddleware_ResponseSize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(PrometheusMiddleware())
+
+	router.GET("/size", func(c *gin.Context) {
+		c.String(http.StatusOK, "0123456789")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/size", nil)
+	router.ServeHTTP(w, req)
+
+	var metric dto.Metric
+	err := ResponseSizeHistogram.WithLabelValues("/size").(prometheus.Metric).Write(&metric)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), metric.GetHistogram().GetSampleCount())
+	assert.Equal(t, 10.0, metric.GetHistogram().GetSampleSum())
+}
+
+func TestHandler_ContentNegotiation(t *testing.T) {
+	handler := Handler()
+
+	t.Run("Prometheus text by default", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/metrics", nil)
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "text/plain")
+	})
+
+	t.Run("OpenMetrics when accepted", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/metrics", nil)
+		req.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "application/openmetrics-text")
+		assert.Contains(t, w.Body.String(), "# EOF")
+	})
+}
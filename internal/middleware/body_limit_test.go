@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBodyLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(BodyLimit(8))
+
+	var readErr error
+	router.POST("/test", func(c *gin.Context) {
+		_, readErr = io.ReadAll(c.Request.Body)
+		var tooLarge *http.MaxBytesError
+		if errors.As(readErr, &tooLarge) {
+			c.Status(http.StatusRequestEntityTooLarge)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	t.Run("Within limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/test", strings.NewReader("12345678"))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, readErr)
+	})
+
+	t.Run("Declared length over limit", func(t *testing.T) {
+		readErr = nil
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/test", strings.NewReader("123456789"))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Contains(t, w.Body.String(), "request body too large")
+	})
+
+	t.Run("Chunked body over limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/test", io.NopCloser(strings.NewReader("123456789")))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Error(t, readErr)
+	})
+}
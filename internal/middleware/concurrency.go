@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ali-Gorgani/task-manager/internal/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// ConcurrencyLimit is a Gin middleware that caps the number of requests
+// handled at once at max, protecting the database and cache from spikes.
+// Requests beyond the cap are rejected immediately with 503 and a
+// Retry-After of retryAfter instead of queueing. Paths starting with any of
+// the exempt prefixes, such as health checks and metrics, are never limited.
+func ConcurrencyLimit(max int, retryAfter time.Duration, exempt ...string) gin.HandlerFunc {
+	slots := make(chan struct{}, max)
+	retryAfterSeconds := strconv.Itoa(int(retryAfter.Round(time.Second).Seconds()))
+
+	return func(c *gin.Context) {
+		for _, prefix := range exempt {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				c.Next()
+				return
+			}
+		}
+
+		select {
+		case slots <- struct{}{}:
+		default:
+			c.Header("Retry-After", retryAfterSeconds)
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "server is at capacity, retry later"})
+			return
+		}
+
+		metrics.InFlightRequests.Inc()
+		defer func() {
+			metrics.InFlightRequests.Dec()
+			<-slots
+		}()
+
+		c.Next()
+	}
+}
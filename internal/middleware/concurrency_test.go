@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ali-Gorgani/task-manager/internal/metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrencyLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+
+	router := gin.New()
+	router.Use(ConcurrencyLimit(2, 3*time.Second, "/health"))
+	router.GET("/slow", func(c *gin.Context) {
+		entered <- struct{}{}
+		<-release
+		c.Status(http.StatusOK)
+	})
+	router.GET("/health", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	// Fill both slots with requests that block until released
+	var wg sync.WaitGroup
+	codes := make([]int, 2)
+	for i := range codes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/slow", nil)
+			router.ServeHTTP(w, req)
+			codes[i] = w.Code
+		}()
+		<-entered
+	}
+	assert.Equal(t, float64(2), testutil.ToFloat64(metrics.InFlightRequests))
+
+	// A third request is rejected instead of waiting
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/slow", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "3", w.Header().Get("Retry-After"))
+
+	// Exempt paths still get through while saturated
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/health", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK}, codes)
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.InFlightRequests))
+
+	// Freed slots are reusable
+	go func() { <-entered }()
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/slow", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+}
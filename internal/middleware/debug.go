@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	redactedValue   = "[REDACTED]"
+	maxLoggedBodyKB = 64
+)
+
+// bodyLogWriter captures the response body while still writing it to the client
+type bodyLogWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *bodyLogWriter) Write(b []byte) (int, error) {
+	if w.body.Len() < maxLoggedBodyKB*1024 {
+		w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyLogWriter) WriteString(s string) (int, error) {
+	if w.body.Len() < maxLoggedBodyKB*1024 {
+		w.body.WriteString(s)
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+// DebugBodyLogger is a Gin middleware that logs JSON request and response
+// bodies. Values of fields named in redactFields are masked at any depth.
+// It is meant for development only and should not be enabled in production.
+// Register it after BodyLimit so the buffered body is bounded; a body over
+// the limit is rejected with 413 before the handler runs.
+func DebugBodyLogger(redactFields []string) gin.HandlerFunc {
+	redact := make(map[string]struct{}, len(redactFields))
+	for _, field := range redactFields {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field != "" {
+			redact[field] = struct{}{}
+		}
+	}
+
+	return func(c *gin.Context) {
+		// Buffer and restore the request body so handlers can still read it
+		var reqBody []byte
+		if c.Request.Body != nil {
+			data, err := io.ReadAll(c.Request.Body)
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "request body too large"})
+				return
+			}
+			if err != nil {
+				log.Printf("[DEBUG] failed to read request body: %v", err)
+			}
+			reqBody = data
+			c.Request.Body = io.NopCloser(bytes.NewReader(data))
+		}
+
+		writer := &bodyLogWriter{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+		c.Writer = writer
+
+		c.Next()
+
+		if isJSON(c.ContentType()) && len(reqBody) > 0 {
+			log.Printf("[DEBUG] %s %s request: %s", c.Request.Method, c.Request.URL.RequestURI(), redactJSON(reqBody, redact))
+		}
+		if isJSON(writer.Header().Get("Content-Type")) && writer.body.Len() > 0 {
+			log.Printf("[DEBUG] %s %s response %d: %s", c.Request.Method, c.Request.URL.RequestURI(), writer.Status(), redactJSON(writer.body.Bytes(), redact))
+		}
+	}
+}
+
+func isJSON(contentType string) bool {
+	return strings.Contains(strings.ToLower(contentType), "json")
+}
+
+// redactJSON masks sensitive fields in a JSON document. Bodies that are not
+// valid JSON are not logged verbatim since they cannot be redacted.
+func redactJSON(data []byte, redact map[string]struct{}) string {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return "<unparseable body omitted>"
+	}
+
+	out, err := json.Marshal(redactValue(doc, redact))
+	if err != nil {
+		return "<unserializable body omitted>"
+	}
+	return string(out)
+}
+
+func redactValue(v interface{}, redact map[string]struct{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for key, inner := range val {
+			if _, ok := redact[strings.ToLower(key)]; ok {
+				val[key] = redactedValue
+				continue
+			}
+			val[key] = redactValue(inner, redact)
+		}
+		return val
+	case []interface{}:
+		for i, inner := range val {
+			val[i] = redactValue(inner, redact)
+		}
+		return val
+	default:
+		return v
+	}
+}
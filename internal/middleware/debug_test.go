@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugBodyLogger(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	router := gin.New()
+	router.Use(DebugBodyLogger([]string{"secret"}))
+
+	var handlerBody string
+	router.POST("/test", func(c *gin.Context) {
+		data, _ := io.ReadAll(c.Request.Body)
+		handlerBody = string(data)
+		c.JSON(http.StatusCreated, gin.H{"id": "1", "secret": "response-secret"})
+	})
+
+	body := `{"title":"Test","secret":"request-secret"}`
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/test", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, body, handlerBody, "request body should be restored for handlers")
+	assert.Contains(t, w.Body.String(), "response-secret", "client response must not be redacted")
+
+	output := logs.String()
+	assert.Contains(t, output, `"title":"Test"`)
+	assert.Contains(t, output, `"id":"1"`)
+	assert.NotContains(t, output, "request-secret")
+	assert.NotContains(t, output, "response-secret")
+	assert.Contains(t, output, redactedValue)
+}
+
+func TestRedactJSON(t *testing.T) {
+	redact := map[string]struct{}{"password": {}}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Top level", `{"password":"x","name":"a"}`, `{"name":"a","password":"[REDACTED]"}`},
+		{"Nested", `{"user":{"Password":"x"}}`, `{"user":{"Password":"[REDACTED]"}}`},
+		{"Array", `[{"password":"x"}]`, `[{"password":"[REDACTED]"}]`},
+		{"Invalid JSON", `not json`, "<unparseable body omitted>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, redactJSON([]byte(tt.input), redact))
+		})
+	}
+}
+
+func TestDebugBodyLogger_BodyLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(BodyLimit(16), DebugBodyLogger(nil))
+
+	called := false
+	router.POST("/test", func(c *gin.Context) {
+		called = true
+		c.Status(http.StatusOK)
+	})
+
+	// Chunked body, so only the limited reader can catch it
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/test", io.NopCloser(strings.NewReader(`{"title":"far too long for the limit"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.False(t, called, "handler must not run for an oversized body")
+}
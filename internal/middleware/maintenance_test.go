@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenance(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	maintenance := NewMaintenance(false, 30*time.Second)
+	router := gin.New()
+	router.Use(maintenance.Middleware("/admin/maintenance"))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/tasks", ok)
+	router.POST("/tasks", ok)
+	router.PATCH("/tasks", ok)
+	router.DELETE("/tasks", ok)
+	router.PUT("/admin/maintenance", ok)
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Writes pass while maintenance mode is off
+	assert.Equal(t, http.StatusOK, serve("POST", "/tasks").Code)
+
+	maintenance.SetEnabled(true)
+	assert.True(t, maintenance.Enabled())
+	for _, method := range []string{"POST", "PATCH", "DELETE"} {
+		w := serve(method, "/tasks")
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code, method)
+		assert.Equal(t, "30", w.Header().Get("Retry-After"), method)
+	}
+
+	// Reads and exempt paths still get through
+	assert.Equal(t, http.StatusOK, serve("GET", "/tasks").Code)
+	assert.Equal(t, http.StatusOK, serve("PUT", "/admin/maintenance").Code)
+
+	maintenance.SetEnabled(false)
+	assert.Equal(t, http.StatusOK, serve("DELETE", "/tasks").Code)
+}
@@ -1,6 +1,12 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,6 +22,65 @@ const (
 	TaskStatusCancelled  TaskStatus = "cancelled"
 )
 
+// NormalizeStatus trims and lowercases a status value and accepts spaces or
+// hyphens in place of underscores, so "In-Progress" becomes "in_progress"
+func NormalizeStatus(raw string) TaskStatus {
+	normalized := strings.ToLower(strings.TrimSpace(raw))
+	normalized = strings.NewReplacer(" ", "_", "-", "_").Replace(normalized)
+	return TaskStatus(normalized)
+}
+
+// UnmarshalJSON accepts loosely formatted status strings and rejects
+// non-string JSON values with a clear message
+func (s *TaskStatus) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("status must be a string, one of: %s, %s, %s, %s",
+			TaskStatusPending, TaskStatusInProgress, TaskStatusCompleted, TaskStatusCancelled)
+	}
+	*s = NormalizeStatus(raw)
+	return nil
+}
+
+// SortOrder represents the direction of a list ordering
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+// NormalizeSortOrder maps the accepted spellings of a sort direction
+// (asc, ascending, desc, descending, in any case) onto a SortOrder. An empty
+// value yields the default descending order; ok is false for anything it
+// does not recognize, in which case the default is returned as well.
+func NormalizeSortOrder(raw string) (order SortOrder, ok bool) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "asc", "ascending":
+		return SortOrderAsc, true
+	case "", "desc", "descending":
+		return SortOrderDesc, true
+	default:
+		return SortOrderDesc, false
+	}
+}
+
+// sortableFields are the task fields a list may be ordered by. Each has a
+// supporting index so sorting never forces a sequential scan.
+var sortableFields = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+	"title":      true,
+}
+
+// DefaultSortField is the field lists are ordered by when none is given
+const DefaultSortField = "created_at"
+
+// IsSortableField reports whether a list may be ordered by field
+func IsSortableField(field string) bool {
+	return sortableFields[field]
+}
+
 // Task represents a to-do task
 type Task struct {
 	ID          string     `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
@@ -23,16 +88,82 @@ type Task struct {
 	Description string     `json:"description" example:"Write comprehensive README and API docs"`
 	Status      TaskStatus `json:"status" example:"pending"`
 	Assignee    string     `json:"assignee" example:"john.doe@example.com"`
+	Source      *string    `json:"source,omitempty" example:"jira"`
+	ExternalID  *string    `json:"external_id,omitempty" example:"PROJ-123"`
 	CreatedAt   time.Time  `json:"created_at" example:"2025-11-01T10:00:00Z"`
 	UpdatedAt   time.Time  `json:"updated_at" example:"2025-11-01T12:00:00Z"`
 }
 
+// ETag returns a strong entity tag for the task's current state. UpdatedAt is
+// truncated to the database's microsecond precision so tags computed before
+// and after a round trip through Postgres agree.
+func (t *Task) ETag() string {
+	return TaskETag(t.ID, t.UpdatedAt)
+}
+
+// TaskETag returns the entity tag of the task with the given ID last updated
+// at updatedAt, for callers that have not loaded the whole task
+func TaskETag(id string, updatedAt time.Time) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s|%d", id, updatedAt.Truncate(time.Microsecond).UnixNano())
+	return fmt.Sprintf(`"%s"`, hex.EncodeToString(h.Sum(nil))[:32])
+}
+
 // CreateTaskRequest represents the request body for creating a task
 type CreateTaskRequest struct {
 	Title       string     `json:"title" binding:"required" example:"Complete project documentation"`
 	Description string     `json:"description" example:"Write comprehensive README and API docs"`
 	Status      TaskStatus `json:"status" example:"pending"`
 	Assignee    string     `json:"assignee" example:"john.doe@example.com"`
+	Source      string     `json:"source" example:"jira"`
+	ExternalID  string     `json:"external_id" example:"PROJ-123"`
+}
+
+// FieldError describes why one field of a request is invalid
+type FieldError struct {
+	Field   string `json:"field" example:"status"`
+	Message string `json:"message" example:"invalid status"`
+}
+
+// ValidationResult reports whether a request passes the server's validation
+// rules and, if not, every field that fails them
+type ValidationResult struct {
+	Valid  bool         `json:"valid" example:"false"`
+	Errors []FieldError `json:"errors"`
+}
+
+// ImportTaskRequest represents a task migrated from another system, with its
+// original timestamps
+type ImportTaskRequest struct {
+	CreateTaskRequest
+	CreatedAt time.Time  `json:"created_at" binding:"required" example:"2023-03-01T09:30:00Z"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty" example:"2023-04-15T16:00:00Z"`
+}
+
+// ImportTasksRequest represents the request body for importing tasks. With a
+// batch size, batches are imported independently instead of all or nothing.
+// Tasks are validated by the service rather than on binding, so that an
+// invalid task fails only its own batch.
+type ImportTasksRequest struct {
+	Tasks     []ImportTaskRequest `json:"tasks" binding:"required"`
+	BatchSize int                 `json:"batch_size,omitempty" binding:"min=0" example:"100"`
+}
+
+// ImportBatchResult reports the outcome of importing tasks From through To
+// (inclusive, zero-based indexes into the request)
+type ImportBatchResult struct {
+	From     int    `json:"from" example:"0"`
+	To       int    `json:"to" example:"99"`
+	Imported int    `json:"imported" example:"100"`
+	Error    string `json:"error,omitempty" example:"tasks[42]: invalid input: timestamps may not be in the future"`
+}
+
+// ImportTasksResponse reports the tasks created by an import
+type ImportTasksResponse struct {
+	Imported int                 `json:"imported" example:"2"`
+	Failed   int                 `json:"failed,omitempty" example:"0"`
+	Tasks    []Task              `json:"tasks"`
+	Batches  []ImportBatchResult `json:"batches,omitempty"`
 }
 
 // UpdateTaskRequest represents the request body for updating a task
@@ -41,23 +172,345 @@ type UpdateTaskRequest struct {
 	Description *string     `json:"description,omitempty" example:"Updated description"`
 	Status      *TaskStatus `json:"status,omitempty" example:"in_progress"`
 	Assignee    *string     `json:"assignee,omitempty" example:"jane.doe@example.com"`
+	Source      *string     `json:"source,omitempty" example:"jira"`
+	ExternalID  *string     `json:"external_id,omitempty" example:"PROJ-123"`
 }
 
 // TaskFilter represents filtering options for tasks
 type TaskFilter struct {
-	Status   *TaskStatus `form:"status" example:"pending"`
-	Assignee *string     `form:"assignee" example:"john.doe@example.com"`
-	Page     int         `form:"page" example:"1"`
-	PageSize int         `form:"page_size" example:"10"`
+	Status         *TaskStatus `form:"status" example:"pending"`
+	Assignees      []string    `form:"assignee" example:"john.doe@example.com"`
+	Source         *string     `form:"source" example:"jira"`
+	ExternalID     *string     `form:"external_id" example:"PROJ-123"`
+	HasDescription *bool       `form:"has_description" example:"false"`
+	Search         string      `form:"search" example:"documentation"`
+	TitlePrefix    *string     `form:"title_prefix" example:"Compl"`
+	Stale          bool        `form:"stale" example:"false"`
+	StaleDays      int         `form:"stale_days" example:"14"`
+	Sort           string      `form:"sort" example:"created_at"`
+	Order          SortOrder   `form:"order" example:"desc"`
+	Page           int         `form:"page" example:"1"`
+	PageSize       int         `form:"page_size" example:"10"`
+	PageToken      string      `form:"page_token"`
+	// StrictPageSize rejects a page_size above the maximum instead of clamping it
+	StrictPageSize bool `form:"strict_page_size" example:"false"`
+	AppliedFilters bool `form:"applied_filters" example:"false"`
+}
+
+// AppliedFilters reports the filters a list was actually served with, after
+// normalization, defaults and clamping. A single assignee is reported under
+// the original assignee key and several under assignees.
+type AppliedFilters struct {
+	Status         *TaskStatus `json:"status,omitempty" example:"pending"`
+	Assignee       *string     `json:"assignee,omitempty" example:"john.doe@example.com"`
+	Assignees      []string    `json:"assignees,omitempty" example:"john.doe@example.com,jane.doe@example.com"`
+	Source         *string     `json:"source,omitempty" example:"jira"`
+	ExternalID     *string     `json:"external_id,omitempty" example:"PROJ-123"`
+	HasDescription *bool       `json:"has_description,omitempty" example:"false"`
+	Search         string      `json:"search,omitempty" example:"documentation"`
+	TitlePrefix    *string     `json:"title_prefix,omitempty" example:"Compl"`
+	StaleDays      int         `json:"stale_days,omitempty" example:"14"`
+	Sort           string      `json:"sort" example:"created_at"`
+	Order          SortOrder   `json:"order" example:"desc"`
+	Page           int         `json:"page" example:"1"`
+	PageSize       int         `json:"page_size" example:"10"`
+}
+
+// Applied returns the effective filters of a normalized filter
+func (f *TaskFilter) Applied() *AppliedFilters {
+	applied := &AppliedFilters{
+		Status:         f.Status,
+		Source:         f.Source,
+		ExternalID:     f.ExternalID,
+		HasDescription: f.HasDescription,
+		Search:         f.Search,
+		TitlePrefix:    f.TitlePrefix,
+		StaleDays:      f.StaleDays,
+		Sort:           f.Sort,
+		Order:          f.Order,
+		Page:           f.Page,
+		PageSize:       f.PageSize,
+	}
+	switch len(f.Assignees) {
+	case 0:
+	case 1:
+		applied.Assignee = &f.Assignees[0]
+	default:
+		applied.Assignees = f.Assignees
+	}
+	return applied
 }
 
 // TaskListResponse represents a paginated list of tasks
 type TaskListResponse struct {
-	Tasks      []Task `json:"tasks"`
-	Total      int    `json:"total" example:"100"`
-	Page       int    `json:"page" example:"1"`
-	PageSize   int    `json:"page_size" example:"10"`
-	TotalPages int    `json:"total_pages" example:"10"`
+	Tasks          []Task          `json:"tasks"`
+	Total          int             `json:"total" example:"100"`
+	Page           int             `json:"page" example:"1"`
+	PageSize       int             `json:"page_size" example:"10"`
+	TotalPages     int             `json:"total_pages" example:"10"`
+	NextPageToken  string          `json:"next_page_token,omitempty" example:"eyJzdCI6eyJ2IjoxLCJwIjoyfX0"`
+	AppliedFilters *AppliedFilters `json:"applied_filters,omitempty"`
+}
+
+// TaskIDListResponse lists the IDs of the tasks matching a filter, in list
+// order. Total counts every match, and exceeds len(IDs) when the IDs were
+// capped.
+type TaskIDListResponse struct {
+	IDs   []string `json:"ids" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Total int      `json:"total" example:"2"`
+}
+
+// ETag returns a weak entity tag derived from the task IDs, the latest
+// updated_at and the pagination details of the response
+func (r *TaskListResponse) ETag() string {
+	h := sha256.New()
+	var latest time.Time
+	for _, task := range r.Tasks {
+		h.Write([]byte(task.ID))
+		h.Write([]byte{0})
+		if task.UpdatedAt.After(latest) {
+			latest = task.UpdatedAt
+		}
+	}
+	fmt.Fprintf(h, "%d|%d|%d|%d", latest.UnixNano(), r.Total, r.Page, r.PageSize)
+	return fmt.Sprintf(`W/"%s"`, hex.EncodeToString(h.Sum(nil))[:32])
+}
+
+// TaskChangesQuery represents the query parameters for incremental sync.
+// Either Since or Cursor is required; Cursor wins when both are set.
+type TaskChangesQuery struct {
+	Since  time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00" example:"2025-11-01T10:00:00Z"`
+	Cursor string    `form:"cursor"`
+	Limit  int       `form:"limit" example:"100"`
+}
+
+// TaskChangesResponse represents tasks changed since a point in time.
+// NextSince is kept for older clients; it can skip tasks sharing one
+// updated_at across a page boundary, which NextCursor does not.
+type TaskChangesResponse struct {
+	Tasks      []Task    `json:"tasks"`
+	Since      time.Time `json:"since" example:"2025-11-01T10:00:00Z"`
+	NextSince  time.Time `json:"next_since" example:"2025-11-01T12:00:00Z"`
+	NextCursor string    `json:"next_cursor" example:"eyJ0IjoiMjAyNS0xMS0wMVQxMjowMDowMFoifQ"`
+	HasMore    bool      `json:"has_more" example:"false"`
+}
+
+// RecentTasksQuery represents the query parameters for the recent activity feed
+type RecentTasksQuery struct {
+	Limit int `form:"limit" example:"10"`
+}
+
+// RecentTasksResponse represents the most recently updated tasks, newest first
+type RecentTasksResponse struct {
+	Tasks []Task `json:"tasks"`
+}
+
+// BoardQuery represents the query parameters for the board view. The filters
+// apply to every column; limit caps the tasks returned per column.
+type BoardQuery struct {
+	TaskFilter
+	Limit int `form:"limit" example:"20"`
+}
+
+// BoardPage selects the slice of a board column to return
+type BoardPage struct {
+	Offset int
+	Limit  int
+}
+
+// BoardColumn is one page of the tasks in a status, with the number of tasks
+// in that status matching the filters
+type BoardColumn struct {
+	Tasks  []Task `json:"tasks"`
+	Total  int    `json:"total" example:"42"`
+	Offset int    `json:"offset" example:"0"`
+	Limit  int    `json:"limit" example:"20"`
+}
+
+// BoardResponse represents tasks grouped into columns by status
+type BoardResponse struct {
+	Columns map[TaskStatus]BoardColumn `json:"columns"`
+}
+
+// CompletionStatsQuery represents the query parameters for completion stats
+type CompletionStatsQuery struct {
+	Since    time.Time  `form:"since" time_format:"2006-01-02T15:04:05Z07:00" binding:"required" example:"2025-11-01T00:00:00Z"`
+	Until    *time.Time `form:"until" time_format:"2006-01-02T15:04:05Z07:00" example:"2025-11-08T00:00:00Z"`
+	Assignee *string    `form:"assignee" example:"john.doe@example.com"`
+	GroupBy  string     `form:"group_by" example:"assignee"`
+}
+
+// AssigneeCompletions is the number of tasks one assignee completed
+type AssigneeCompletions struct {
+	Assignee  string `json:"assignee" example:"john.doe@example.com"`
+	Completed int    `json:"completed" example:"7"`
+}
+
+// CompletionStatsResponse reports how many tasks were completed in a window.
+// Approximate is set because completion time is taken from updated_at.
+type CompletionStatsResponse struct {
+	Since       time.Time             `json:"since" example:"2025-11-01T00:00:00Z"`
+	Until       time.Time             `json:"until" example:"2025-11-08T00:00:00Z"`
+	Completed   int                   `json:"completed" example:"12"`
+	ByAssignee  []AssigneeCompletions `json:"by_assignee,omitempty"`
+	Approximate bool                  `json:"approximate" example:"true"`
+}
+
+// TrendQuery represents the query parameters for the task count trend
+type TrendQuery struct {
+	From     time.Time  `form:"from" time_format:"2006-01-02T15:04:05Z07:00" binding:"required" example:"2025-11-01T00:00:00Z"`
+	To       *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00" example:"2025-12-01T00:00:00Z"`
+	Interval string     `form:"interval" example:"day"`
+}
+
+// TrendBucket holds the tasks created and completed in one interval,
+// starting at Start (UTC)
+type TrendBucket struct {
+	Start     time.Time `json:"start" example:"2025-11-01T00:00:00Z"`
+	Created   int       `json:"created" example:"4"`
+	Completed int       `json:"completed" example:"3"`
+}
+
+// TrendResponse lists the trend buckets between From and To in order, with
+// empty intervals included. Approximate is set because completion time is
+// taken from updated_at.
+type TrendResponse struct {
+	From        time.Time     `json:"from" example:"2025-11-01T00:00:00Z"`
+	To          time.Time     `json:"to" example:"2025-12-01T00:00:00Z"`
+	Interval    string        `json:"interval" example:"day"`
+	Buckets     []TrendBucket `json:"buckets"`
+	Approximate bool          `json:"approximate" example:"true"`
+}
+
+// PaginationMeta holds pagination details for enveloped list responses
+type PaginationMeta struct {
+	Total          int             `json:"total" example:"100"`
+	Page           int             `json:"page" example:"1"`
+	PageSize       int             `json:"page_size" example:"10"`
+	TotalPages     int             `json:"total_pages" example:"10"`
+	NextPageToken  string          `json:"next_page_token,omitempty" example:"eyJzdCI6eyJ2IjoxLCJwIjoyfX0"`
+	AppliedFilters *AppliedFilters `json:"applied_filters,omitempty"`
+}
+
+// TaskListEnvelope represents a paginated list of tasks in {data, meta} form
+type TaskListEnvelope struct {
+	Data []Task         `json:"data"`
+	Meta PaginationMeta `json:"meta"`
+}
+
+// Envelope converts the flat list response into the {data, meta} shape
+func (r *TaskListResponse) Envelope() *TaskListEnvelope {
+	return &TaskListEnvelope{
+		Data: r.Tasks,
+		Meta: PaginationMeta{
+			Total:          r.Total,
+			Page:           r.Page,
+			PageSize:       r.PageSize,
+			TotalPages:     r.TotalPages,
+			NextPageToken:  r.NextPageToken,
+			AppliedFilters: r.AppliedFilters,
+		},
+	}
+}
+
+// AssigneeChangedEvent describes a task being reassigned
+type AssigneeChangedEvent struct {
+	Event       string    `json:"event" example:"task.assignee_changed"`
+	TaskID      string    `json:"task_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OldAssignee string    `json:"old_assignee" example:"john.doe@example.com"`
+	NewAssignee string    `json:"new_assignee" example:"jane.doe@example.com"`
+	Task        Task      `json:"task"`
+	ChangedAt   time.Time `json:"changed_at" example:"2025-11-01T12:00:00Z"`
+}
+
+// AssigneesReassignedEvent describes a bulk reassignment moving every listed
+// task from one assignee to another
+type AssigneesReassignedEvent struct {
+	Event        string      `json:"event" example:"task.assignees_reassigned"`
+	OldAssignee  string      `json:"old_assignee" example:"john.doe@example.com"`
+	NewAssignee  string      `json:"new_assignee" example:"jane.doe@example.com"`
+	Status       *TaskStatus `json:"status,omitempty" example:"pending"`
+	TaskIDs      []string    `json:"task_ids"`
+	ReassignedAt time.Time   `json:"reassigned_at" example:"2025-11-01T12:00:00Z"`
+}
+
+// AssignTaskRequest represents the request body for reassigning a task
+type AssignTaskRequest struct {
+	Assignee string `json:"assignee" binding:"required,email" example:"jane.doe@example.com"`
+}
+
+// ReassignTasksRequest represents the request body for moving every task of
+// one assignee to another, optionally only those in one status
+type ReassignTasksRequest struct {
+	From   string      `json:"from" binding:"required,email" example:"john.doe@example.com"`
+	To     string      `json:"to" binding:"required,email" example:"jane.doe@example.com"`
+	Status *TaskStatus `json:"status,omitempty" example:"pending"`
+}
+
+// ReassignTasksResponse represents the result of a bulk reassignment
+type ReassignTasksResponse struct {
+	Reassigned int `json:"reassigned" example:"8"`
+}
+
+// PurgeTasksRequest represents the request body for purging completed tasks
+type PurgeTasksRequest struct {
+	Before time.Time `json:"before" binding:"required" example:"2025-10-01T00:00:00Z"`
+}
+
+// MaintenanceRequest turns maintenance mode on or off
+type MaintenanceRequest struct {
+	Enabled *bool `json:"enabled" binding:"required" example:"true"`
+}
+
+// MaintenanceResponse reports whether maintenance mode is on
+type MaintenanceResponse struct {
+	Enabled bool `json:"enabled" example:"true"`
+}
+
+// RouteInfo describes one registered HTTP route
+type RouteInfo struct {
+	Method  string `json:"method" example:"GET"`
+	Path    string `json:"path" example:"/api/v1/tasks/:id"`
+	Handler string `json:"handler" example:"github.com/Ali-Gorgani/task-manager/internal/handlers.(*TaskHandler).GetTask-fm"`
+}
+
+// RoutesResponse lists the routes the server handles
+type RoutesResponse struct {
+	Routes []RouteInfo `json:"routes"`
+}
+
+// PurgeTasksResponse represents the result of a purge operation. A dry run
+// lists the IDs of the oldest purgeable tasks only, and sets IDsTruncated
+// when Purged counts more tasks than IDs holds.
+type PurgeTasksResponse struct {
+	Purged       int      `json:"purged" example:"12"`
+	DryRun       bool     `json:"dry_run,omitempty" example:"false"`
+	IDs          []string `json:"ids,omitempty"`
+	IDsTruncated bool     `json:"ids_truncated,omitempty" example:"false"`
+}
+
+// StatusesResponse lists the valid statuses and the transitions allowed from each
+type StatusesResponse struct {
+	Statuses    []TaskStatus                `json:"statuses"`
+	Transitions map[TaskStatus][]TaskStatus `json:"transitions"`
+}
+
+// ReadinessResponse reports whether the service's dependencies were reachable
+// at the last check
+type ReadinessResponse struct {
+	Status       string                      `json:"status" example:"degraded"`
+	Version      string                      `json:"version,omitempty" example:"1.4.0"`
+	Maintenance  bool                        `json:"maintenance" example:"false"`
+	CheckedAt    time.Time                   `json:"checked_at" example:"2025-11-01T12:00:00Z"`
+	Error        string                      `json:"error,omitempty" example:"cache: connection refused"`
+	Dependencies map[string]DependencyHealth `json:"dependencies,omitempty"`
+}
+
+// DependencyHealth is the result of the last check of one dependency
+type DependencyHealth struct {
+	Status    string  `json:"status" example:"up"`
+	LatencyMS float64 `json:"latency_ms" example:"1.25"`
+	Error     string  `json:"error,omitempty" example:"connection refused"`
 }
 
 // NewTask creates a new task with default values
@@ -78,6 +531,13 @@ func NewTask(title, description, assignee string, status TaskStatus) *Task {
 	}
 }
 
+// IsValidTaskID reports whether id has the canonical form of the IDs NewTask
+// generates
+func IsValidTaskID(id string) bool {
+	_, err := uuid.Parse(id)
+	return err == nil && len(id) == 36
+}
+
 // IsValidStatus checks if the status is valid
 func IsValidStatus(status TaskStatus) bool {
 	switch status {
@@ -87,3 +547,38 @@ func IsValidStatus(status TaskStatus) bool {
 		return false
 	}
 }
+
+// AllStatuses returns every valid task status in workflow order
+func AllStatuses() []TaskStatus {
+	return []TaskStatus{TaskStatusPending, TaskStatusInProgress, TaskStatusCompleted, TaskStatusCancelled}
+}
+
+// TerminalStatuses returns the statuses in which no more work is expected
+func TerminalStatuses() []TaskStatus {
+	return []TaskStatus{TaskStatusCompleted, TaskStatusCancelled}
+}
+
+// AllowedTransitions returns the statuses a task in status from may move to.
+// No transition rules are enforced, so every other status is allowed.
+func AllowedTransitions(from TaskStatus) []TaskStatus {
+	allowed := []TaskStatus{}
+	for _, status := range AllStatuses() {
+		if status != from {
+			allowed = append(allowed, status)
+		}
+	}
+	return allowed
+}
+
+// TaskJSONFields returns the JSON field names of a Task in declaration order
+func TaskJSONFields() []string {
+	t := reflect.TypeOf(Task{})
+	fields := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
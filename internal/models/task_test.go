@@ -1,7 +1,9 @@
 package models
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -50,3 +52,114 @@ func TestIsValidStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestIsValidTaskID(t *testing.T) {
+	assert.True(t, IsValidTaskID(NewTask("Test", "", "", "").ID))
+	assert.True(t, IsValidTaskID("550e8400-e29b-41d4-a716-446655440000"))
+	assert.False(t, IsValidTaskID(""))
+	assert.False(t, IsValidTaskID("not-a-uuid"))
+	assert.False(t, IsValidTaskID("{550e8400-e29b-41d4-a716-446655440000}"))
+	assert.False(t, IsValidTaskID("550e8400e29b41d4a716446655440000"))
+}
+
+func TestTaskListResponse_ETag(t *testing.T) {
+	task := NewTask("Test", "Description", "test@example.com", TaskStatusPending)
+	response := &TaskListResponse{Tasks: []Task{*task}, Total: 1, Page: 1, PageSize: 10, TotalPages: 1}
+
+	etag := response.ETag()
+	assert.Equal(t, etag, response.ETag())
+	assert.Contains(t, etag, `W/"`)
+
+	response.Tasks[0].UpdatedAt = task.UpdatedAt.Add(time.Second)
+	assert.NotEqual(t, etag, response.ETag())
+}
+
+func TestTaskJSONFields(t *testing.T) {
+	fields := TaskJSONFields()
+	assert.Equal(t, "id", fields[0])
+	assert.Contains(t, fields, "title")
+	assert.Contains(t, fields, "updated_at")
+}
+
+func TestTaskStatus_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected TaskStatus
+		wantErr  bool
+	}{
+		{"Canonical", `"pending"`, TaskStatusPending, false},
+		{"Mixed case", `"Pending"`, TaskStatusPending, false},
+		{"Whitespace", `"  completed "`, TaskStatusCompleted, false},
+		{"Hyphenated", `"In-Progress"`, TaskStatusInProgress, false},
+		{"Number", `1`, "", true},
+		{"Boolean", `true`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var status TaskStatus
+			err := json.Unmarshal([]byte(tt.input), &status)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "status must be a string")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, status)
+		})
+	}
+}
+
+func TestCreateTaskRequest_NumericStatus(t *testing.T) {
+	var req CreateTaskRequest
+	err := json.Unmarshal([]byte(`{"title":"Test","status":2}`), &req)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "status must be a string")
+}
+
+func TestNormalizeSortOrder(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected SortOrder
+		ok       bool
+	}{
+		{"asc", SortOrderAsc, true},
+		{"ASC", SortOrderAsc, true},
+		{" Ascending ", SortOrderAsc, true},
+		{"desc", SortOrderDesc, true},
+		{"DESC", SortOrderDesc, true},
+		{"descending", SortOrderDesc, true},
+		{"", SortOrderDesc, true},
+		{"sideways", SortOrderDesc, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			order, ok := NormalizeSortOrder(tt.raw)
+			assert.Equal(t, tt.expected, order)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func TestAllowedTransitions(t *testing.T) {
+	allowed := AllowedTransitions(TaskStatusCompleted)
+	assert.Len(t, allowed, len(AllStatuses())-1)
+	assert.NotContains(t, allowed, TaskStatusCompleted)
+	for _, status := range AllStatuses() {
+		assert.True(t, IsValidStatus(status))
+	}
+}
+
+func TestTask_ETag(t *testing.T) {
+	task := NewTask("Task", "Desc", "user@example.com", TaskStatusPending)
+	etag := task.ETag()
+
+	roundTripped := *task
+	roundTripped.UpdatedAt = task.UpdatedAt.Truncate(time.Microsecond)
+	assert.Equal(t, etag, roundTripped.ETag())
+
+	roundTripped.UpdatedAt = roundTripped.UpdatedAt.Add(time.Second)
+	assert.NotEqual(t, etag, roundTripped.ETag())
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskTemplate is a reusable blueprint for tasks that are created repeatedly.
+// Title and Description may contain {{name}} placeholders that are filled in
+// when the template is instantiated.
+type TaskTemplate struct {
+	ID          string     `json:"id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	Name        string     `json:"name" example:"weekly-report"`
+	Title       string     `json:"title" example:"Weekly report {{date}}"`
+	Description string     `json:"description" example:"Summarize progress for {{team}}"`
+	Status      TaskStatus `json:"status,omitempty" example:"pending"`
+	Assignee    string     `json:"assignee,omitempty" example:"john.doe@example.com"`
+	CreatedAt   time.Time  `json:"created_at" example:"2025-11-01T10:00:00Z"`
+	UpdatedAt   time.Time  `json:"updated_at" example:"2025-11-01T12:00:00Z"`
+}
+
+// CreateTemplateRequest represents the request body for creating a template
+type CreateTemplateRequest struct {
+	Name        string     `json:"name" binding:"required" example:"weekly-report"`
+	Title       string     `json:"title" binding:"required" example:"Weekly report {{date}}"`
+	Description string     `json:"description" example:"Summarize progress for {{team}}"`
+	Status      TaskStatus `json:"status" example:"pending"`
+	Assignee    string     `json:"assignee" example:"john.doe@example.com"`
+}
+
+// UpdateTemplateRequest represents the request body for updating a template
+type UpdateTemplateRequest struct {
+	Name        *string     `json:"name,omitempty" example:"weekly-report"`
+	Title       *string     `json:"title,omitempty" example:"Weekly status {{date}}"`
+	Description *string     `json:"description,omitempty" example:"Summarize progress for {{team}}"`
+	Status      *TaskStatus `json:"status,omitempty" example:"pending"`
+	Assignee    *string     `json:"assignee,omitempty" example:"jane.doe@example.com"`
+}
+
+// InstantiateTemplateRequest represents the request body for creating a task
+// from a template. Variables fill the template's placeholders; the other
+// fields replace the template's values as given.
+type InstantiateTemplateRequest struct {
+	Variables   map[string]string `json:"variables,omitempty"`
+	Title       *string           `json:"title,omitempty" example:"Weekly report for the platform team"`
+	Description *string           `json:"description,omitempty"`
+	Status      *TaskStatus       `json:"status,omitempty" example:"in_progress"`
+	Assignee    *string           `json:"assignee,omitempty" example:"jane.doe@example.com"`
+}
+
+// TemplateListResponse represents all templates, ordered by name
+type TemplateListResponse struct {
+	Templates []TaskTemplate `json:"templates"`
+}
+
+// NewTaskTemplate creates a new template with a generated ID
+func NewTaskTemplate(name, title, description, assignee string, status TaskStatus) *TaskTemplate {
+	now := time.Now()
+	return &TaskTemplate{
+		ID:          uuid.New().String(),
+		Name:        name,
+		Title:       title,
+		Description: description,
+		Status:      status,
+		Assignee:    assignee,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
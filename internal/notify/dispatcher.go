@@ -0,0 +1,176 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+)
+
+// ErrQueueFull is returned when an event is dispatched while the queue is at
+// capacity. The event is dropped.
+var ErrQueueFull = errors.New("webhook queue is full")
+
+// errInterrupted is returned by deliver when ctx ends before the event was
+// delivered or given up on
+var errInterrupted = errors.New("webhook delivery interrupted")
+
+// Dispatcher queues webhook events and delivers them from a background
+// worker, so request handling never waits on the receiver. Failed deliveries
+// are retried with exponential backoff.
+type Dispatcher struct {
+	webhook     *WebhookNotifier
+	maxAttempts int
+	backoff     time.Duration
+	queue       chan []byte
+	// lost counts events Run could not hand back because the queue was full
+	lost atomic.Int64
+}
+
+// DispatcherOption configures optional Dispatcher behaviour
+type DispatcherOption func(*Dispatcher)
+
+// WithRetries makes up to maxAttempts delivery attempts per event, waiting
+// backoff before the second and doubling the wait after each failure
+func WithRetries(maxAttempts int, backoff time.Duration) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.maxAttempts = max(maxAttempts, 1)
+		d.backoff = backoff
+	}
+}
+
+// WithQueueSize sets how many events may wait for delivery
+func WithQueueSize(size int) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.queue = make(chan []byte, max(size, 1))
+	}
+}
+
+// NewDispatcher creates a dispatcher delivering through webhook. By default
+// it queues up to 100 events and tries each three times, one second apart
+// at first.
+func NewDispatcher(webhook *WebhookNotifier, opts ...DispatcherOption) *Dispatcher {
+	d := &Dispatcher{
+		webhook:     webhook,
+		maxAttempts: 3,
+		backoff:     time.Second,
+		queue:       make(chan []byte, 100),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Dispatch queues payload for delivery without blocking. The payload is
+// encoded immediately, so later changes to it are not sent.
+func (d *Dispatcher) Dispatch(payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	select {
+	case d.queue <- data:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// NotifyAssigneeChanged queues the reassignment event
+func (d *Dispatcher) NotifyAssigneeChanged(ctx context.Context, event models.AssigneeChangedEvent) error {
+	return d.Dispatch(event)
+}
+
+// NotifyAssigneesReassigned queues the bulk reassignment event, which takes
+// a single queue slot however many tasks moved
+func (d *Dispatcher) NotifyAssigneesReassigned(ctx context.Context, event models.AssigneesReassignedEvent) error {
+	return d.Dispatch(event)
+}
+
+// Run delivers queued events until ctx is cancelled. Events still queued at
+// that point stay queued for Flush, including the one being delivered or
+// waiting to be retried when ctx ended.
+func (d *Dispatcher) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case data := <-d.queue:
+			err := d.deliver(ctx, data)
+			if errors.Is(err, errInterrupted) {
+				// Hand the event back so Flush can still deliver it
+				select {
+				case d.queue <- data:
+				default:
+					d.lost.Add(1)
+				}
+				return
+			}
+			if err != nil {
+				log.Printf("Warning: webhook delivery failed: %v", err)
+			}
+		}
+	}
+}
+
+// Flush delivers the events still queued once Run has returned, retrying as
+// usual, and reports how many were delivered and how many were dropped,
+// either because delivery failed or because ctx ended first. It is meant for
+// shutdown, with ctx bounding how long the final deliveries may take.
+func (d *Dispatcher) Flush(ctx context.Context) (delivered, dropped int) {
+	dropped = int(d.lost.Swap(0))
+	for {
+		select {
+		case data := <-d.queue:
+			if ctx.Err() != nil {
+				dropped++
+				continue
+			}
+			if err := d.deliver(ctx, data); err != nil {
+				log.Printf("Warning: webhook delivery failed: %v", err)
+				dropped++
+				continue
+			}
+			delivered++
+		default:
+			return delivered, dropped
+		}
+	}
+}
+
+// deliver sends data, retrying network errors and temporary failures. It
+// returns errInterrupted when ctx ends before the outcome is known.
+func (d *Dispatcher) deliver(ctx context.Context, data []byte) error {
+	delay := d.backoff
+	for attempt := 1; ; attempt++ {
+		err := d.webhook.send(ctx, data)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("%w: %w", errInterrupted, err)
+		}
+
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && !statusErr.Temporary() {
+			return err
+		}
+		if attempt >= d.maxAttempts {
+			return fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", errInterrupted, err)
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
@@ -0,0 +1,162 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runDispatcher(t *testing.T, d *Dispatcher) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		d.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func TestDispatcher_RetriesTemporaryFailures(t *testing.T) {
+	var attempts atomic.Int32
+	received := make(chan models.AssigneeChangedEvent, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var event models.AssigneeChangedEvent
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		received <- event
+	}))
+	defer server.Close()
+
+	d := NewDispatcher(NewWebhookNotifier(server.URL, time.Second), WithRetries(3, time.Millisecond))
+	runDispatcher(t, d)
+
+	require.NoError(t, d.NotifyAssigneeChanged(context.Background(), models.AssigneeChangedEvent{TaskID: "task-1"}))
+
+	select {
+	case event := <-received:
+		assert.Equal(t, "task-1", event.TaskID)
+	case <-time.After(time.Second):
+		t.Fatal("event was not delivered")
+	}
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+func TestDispatcher_Flush(t *testing.T) {
+	var received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event models.AssigneeChangedEvent
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		if event.TaskID == "rejected" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received.Add(1)
+	}))
+	defer server.Close()
+
+	t.Run("Delivers queued events", func(t *testing.T) {
+		received.Store(0)
+		d := NewDispatcher(NewWebhookNotifier(server.URL, time.Second))
+		for _, id := range []string{"task-1", "rejected", "task-2"} {
+			require.NoError(t, d.NotifyAssigneeChanged(context.Background(), models.AssigneeChangedEvent{TaskID: id}))
+		}
+
+		delivered, dropped := d.Flush(context.Background())
+		assert.Equal(t, 2, delivered)
+		assert.Equal(t, 1, dropped)
+		assert.Equal(t, int32(2), received.Load())
+	})
+
+	t.Run("Drops events once its context ends", func(t *testing.T) {
+		received.Store(0)
+		d := NewDispatcher(NewWebhookNotifier(server.URL, time.Second))
+		require.NoError(t, d.NotifyAssigneeChanged(context.Background(), models.AssigneeChangedEvent{TaskID: "task-1"}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		delivered, dropped := d.Flush(ctx)
+		assert.Equal(t, 0, delivered)
+		assert.Equal(t, 1, dropped)
+		assert.Zero(t, received.Load())
+	})
+}
+
+func TestDispatcher_RunHandsBackInterruptedEvent(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	d := NewDispatcher(NewWebhookNotifier(server.URL, time.Second), WithRetries(3, time.Hour))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		d.Run(ctx)
+		close(done)
+	}()
+
+	require.NoError(t, d.NotifyAssigneeChanged(context.Background(), models.AssigneeChangedEvent{TaskID: "task-1"}))
+	require.Eventually(t, func() bool { return attempts.Load() == 1 }, time.Second, time.Millisecond)
+
+	// Stop Run while the event waits out its backoff
+	cancel()
+	<-done
+
+	delivered, dropped := d.Flush(context.Background())
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, 0, dropped)
+	assert.Equal(t, int32(2), attempts.Load())
+}
+
+func TestDispatcher_DoesNotRetryClientErrors(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	d := NewDispatcher(NewWebhookNotifier(server.URL, time.Second), WithRetries(5, time.Millisecond))
+	err := d.deliver(context.Background(), []byte(`{}`))
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+func TestDispatcher_GivesUp(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	d := NewDispatcher(NewWebhookNotifier(server.URL, time.Second), WithRetries(2, time.Millisecond))
+	err := d.deliver(context.Background(), []byte(`{}`))
+	assert.ErrorContains(t, err, "giving up after 2 attempts")
+	assert.Equal(t, int32(2), attempts.Load())
+}
+
+func TestDispatcher_QueueFull(t *testing.T) {
+	d := NewDispatcher(NewWebhookNotifier("http://127.0.0.1:0", time.Second), WithQueueSize(1))
+
+	assert.NoError(t, d.Dispatch(models.AssigneeChangedEvent{}))
+	assert.ErrorIs(t, d.Dispatch(models.AssigneeChangedEvent{}), ErrQueueFull)
+}
@@ -0,0 +1,114 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+)
+
+// SignatureHeader carries the HMAC-SHA256 of the request body, as
+// "sha256=<hex>", when a signing secret is configured
+const SignatureHeader = "X-Signature"
+
+// WebhookNotifier posts task events as JSON to a fixed URL
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+	secret []byte
+}
+
+// WebhookOption configures optional WebhookNotifier behaviour
+type WebhookOption func(*WebhookNotifier)
+
+// WithSigningSecret signs every request body with secret in the
+// X-Signature header, so receivers can verify where it came from
+func WithSigningSecret(secret string) WebhookOption {
+	return func(n *WebhookNotifier) {
+		n.secret = []byte(secret)
+	}
+}
+
+// NewWebhookNotifier creates a notifier that posts to url
+func NewWebhookNotifier(url string, timeout time.Duration, opts ...WebhookOption) *WebhookNotifier {
+	n := &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	return n
+}
+
+// NotifyAssigneeChanged posts the reassignment event to the webhook
+func (n *WebhookNotifier) NotifyAssigneeChanged(ctx context.Context, event models.AssigneeChangedEvent) error {
+	return n.post(ctx, event)
+}
+
+// NotifyAssigneesReassigned posts the bulk reassignment event to the webhook
+func (n *WebhookNotifier) NotifyAssigneesReassigned(ctx context.Context, event models.AssigneesReassignedEvent) error {
+	return n.post(ctx, event)
+}
+
+// StatusError reports a non-2xx webhook response
+type StatusError struct {
+	Code int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("webhook returned status %d", e.Code)
+}
+
+// Temporary reports whether the receiver may accept the request on a later
+// attempt: rate limiting and server errors are, other client errors are not
+func (e *StatusError) Temporary() bool {
+	return e.Code == http.StatusTooManyRequests || e.Code >= 500
+}
+
+// post sends payload and treats any non-2xx response as a failure
+func (n *WebhookNotifier) post(ctx context.Context, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+	return n.send(ctx, data)
+}
+
+// send posts an encoded payload, signing it when a secret is configured
+func (n *WebhookNotifier) send(ctx context.Context, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(n.secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(n.secret, data))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &StatusError{Code: resp.StatusCode}
+	}
+	return nil
+}
+
+// Sign returns the X-Signature value for body: "sha256=" followed by the
+// hex-encoded HMAC-SHA256 of body keyed with secret
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
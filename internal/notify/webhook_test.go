@@ -0,0 +1,105 @@
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookNotifier_NotifyAssigneeChanged(t *testing.T) {
+	var received models.AssigneeChangedEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	task := models.NewTask("Task", "Desc", "new@example.com", models.TaskStatusPending)
+	event := models.AssigneeChangedEvent{
+		Event:       "task.assignee_changed",
+		TaskID:      task.ID,
+		OldAssignee: "old@example.com",
+		NewAssignee: "new@example.com",
+		Task:        *task,
+	}
+
+	notifier := NewWebhookNotifier(server.URL, time.Second)
+	err := notifier.NotifyAssigneeChanged(context.Background(), event)
+	assert.NoError(t, err)
+	assert.Equal(t, task.ID, received.TaskID)
+	assert.Equal(t, "old@example.com", received.OldAssignee)
+	assert.Equal(t, "new@example.com", received.NewAssignee)
+}
+
+func TestWebhookNotifier_NotifyAssigneesReassigned(t *testing.T) {
+	var received models.AssigneesReassignedEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	event := models.AssigneesReassignedEvent{
+		Event:       "task.assignees_reassigned",
+		OldAssignee: "old@example.com",
+		NewAssignee: "new@example.com",
+		TaskIDs:     []string{"task-1", "task-2"},
+	}
+
+	notifier := NewWebhookNotifier(server.URL, time.Second)
+	require.NoError(t, notifier.NotifyAssigneesReassigned(context.Background(), event))
+	assert.Equal(t, "task.assignees_reassigned", received.Event)
+	assert.Equal(t, []string{"task-1", "task-2"}, received.TaskIDs)
+	assert.Nil(t, received.Status)
+}
+
+func TestWebhookNotifier_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL, time.Second)
+	err := notifier.NotifyAssigneeChanged(context.Background(), models.AssigneeChangedEvent{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "503")
+}
+
+func TestWebhookNotifier_Signature(t *testing.T) {
+	var body []byte
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL, time.Second, WithSigningSecret("secret"))
+	require.NoError(t, notifier.NotifyAssigneeChanged(context.Background(), models.AssigneeChangedEvent{TaskID: "task-1"}))
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), signature)
+}
+
+func TestWebhookNotifier_Unsigned(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get(SignatureHeader))
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL, time.Second)
+	assert.NoError(t, notifier.NotifyAssigneeChanged(context.Background(), models.AssigneeChangedEvent{}))
+}
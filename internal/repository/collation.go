@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// WithSortCollation sorts by title under collation, e.g. "de_DE" or
+// "und-x-icu", instead of the database default, so accented titles order
+// correctly for the deployment's locale. Empty keeps the default. The
+// collation must exist; check it first with CollationExists.
+func WithSortCollation(collation string) Option {
+	return func(r *PostgresTaskRepository) {
+		r.sortCollation = collation
+	}
+}
+
+// CollationExists reports whether the database knows collation
+func CollationExists(ctx context.Context, db *sql.DB, collation string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM pg_collation WHERE collname = $1)`
+	if err := db.QueryRowContext(ctx, query, collation).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to look up collation: %w", err)
+	}
+	return exists, nil
+}
+
+// orderColumn is the ORDER BY expression for a sort field: its column, with
+// the configured collation applied to title. Like sortColumn, the result is
+// safe to interpolate.
+func (r *PostgresTaskRepository) orderColumn(field string) string {
+	column := sortColumn(field)
+	if column == "title" && r.sortCollation != "" {
+		return column + " COLLATE " + pq.QuoteIdentifier(r.sortCollation)
+	}
+	return column
+}
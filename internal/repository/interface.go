@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/Ali-Gorgani/task-manager/internal/models"
 )
@@ -9,9 +10,37 @@ import (
 // TaskRepository defines the interface for task storage operations
 type TaskRepository interface {
 	Create(ctx context.Context, task *models.Task) error
+	CreateMany(ctx context.Context, tasks []*models.Task) error
+	GetBoard(ctx context.Context, filter *models.TaskFilter, pages map[models.TaskStatus]models.BoardPage) (map[models.TaskStatus]models.BoardColumn, error)
 	GetByID(ctx context.Context, id string) (*models.Task, error)
+	GetByIDs(ctx context.Context, ids []string) ([]models.Task, error)
+	GetUpdatedAt(ctx context.Context, id string) (time.Time, error)
 	GetAll(ctx context.Context, filter *models.TaskFilter) ([]models.Task, int, error)
+	GetIDs(ctx context.Context, filter *models.TaskFilter, limit int) ([]string, int, error)
 	Update(ctx context.Context, task *models.Task) error
+	UpdateAssignee(ctx context.Context, id, assignee string, updatedAt time.Time) (*models.Task, string, error)
+	ReassignAll(ctx context.Context, from, to string, status *models.TaskStatus, updatedAt time.Time) ([]models.Task, error)
+	UpsertByExternalID(ctx context.Context, task *models.Task) (*models.Task, bool, error)
+	CreateIfNotExists(ctx context.Context, task *models.Task) (*models.Task, bool, error)
 	Delete(ctx context.Context, id string) error
+	DeleteIfUnmodified(ctx context.Context, id string, updatedAt time.Time) error
 	Count(ctx context.Context) (int, error)
+	OldestOpenByStatus(ctx context.Context) (map[models.TaskStatus]time.Time, error)
+	CompletionsByAssignee(ctx context.Context, since, until time.Time, assignee *string) (map[string]int, error)
+	Trend(ctx context.Context, interval string, from, to time.Time) ([]models.TrendBucket, error)
+	GetChangedSince(ctx context.Context, since time.Time, afterID string, limit int) ([]models.Task, error)
+	GetRecentlyUpdated(ctx context.Context, limit int) ([]models.Task, error)
+	PurgeCompletedBefore(ctx context.Context, before time.Time) (int, error)
+	TransitionStale(ctx context.Context, from, to models.TaskStatus, before, updatedAt time.Time) (int, error)
+	ListCompletedBefore(ctx context.Context, before time.Time, limit int) ([]string, int, error)
+	Ping(ctx context.Context) error
+}
+
+// TemplateRepository defines the interface for task template storage operations
+type TemplateRepository interface {
+	Create(ctx context.Context, template *models.TaskTemplate) error
+	GetByID(ctx context.Context, id string) (*models.TaskTemplate, error)
+	List(ctx context.Context) ([]models.TaskTemplate, error)
+	Update(ctx context.Context, template *models.TaskTemplate) error
+	Delete(ctx context.Context, id string) error
 }
@@ -5,53 +5,220 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/lib/pq"
 )
 
 var (
-	ErrTaskNotFound = errors.New("task not found")
-	ErrInvalidInput = errors.New("invalid input")
+	ErrTaskNotFound        = errors.New("task not found")
+	ErrInvalidInput        = errors.New("invalid input")
+	ErrDuplicateTask       = errors.New("a task with this title already exists for the assignee")
+	ErrTaskModified        = errors.New("task has been modified")
+	ErrDuplicateExternalID = errors.New("a task with this external ID already exists for the source")
 )
 
+// PostgreSQL error codes that indicate a transaction may succeed if retried
+const (
+	pqSerializationFailure = "40001"
+	pqDeadlockDetected     = "40P01"
+)
+
+// pqUniqueViolation is the PostgreSQL error code for a unique constraint violation
+const pqUniqueViolation = "23505"
+
+// IsRetryable reports whether err is a PostgreSQL serialization failure or
+// deadlock, which are safe to retry
+func IsRetryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == pqSerializationFailure || pqErr.Code == pqDeadlockDetected
+}
+
+const (
+	// externalIDIndex enforces one task per (source, external_id)
+	externalIDIndex = "idx_tasks_source_external_id"
+	// assigneeTitleIndex enforces unique titles per assignee when enabled
+	assigneeTitleIndex = "idx_tasks_assignee_title"
+)
+
+// duplicateError maps a PostgreSQL unique constraint violation onto the
+// sentinel for the violated constraint. Other errors, including violations
+// of constraints without a sentinel such as the primary key, yield nil.
+func duplicateError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) || pqErr.Code != pqUniqueViolation {
+		return nil
+	}
+	switch pqErr.Constraint {
+	case externalIDIndex:
+		return ErrDuplicateExternalID
+	case assigneeTitleIndex:
+		return ErrDuplicateTask
+	default:
+		return nil
+	}
+}
+
+// searchableColumns whitelists the columns a free-text search may match.
+// Column names are interpolated into SQL, so nothing outside this set is
+// ever used.
+var searchableColumns = map[string]bool{
+	"title":       true,
+	"description": true,
+	"assignee":    true,
+}
+
+// DefaultSearchFields are the columns searched when none are configured
+var DefaultSearchFields = []string{"title", "description"}
+
+// ValidateSearchFields reports an error naming any field that is not a
+// searchable column
+func ValidateSearchFields(fields []string) error {
+	var unknown []string
+	for _, field := range fields {
+		if !searchableColumns[field] {
+			unknown = append(unknown, field)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w: unknown search fields: %s", ErrInvalidInput, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
 // PostgresTaskRepository implements TaskRepository for PostgreSQL
 type PostgresTaskRepository struct {
-	db *sql.DB
+	db            *sql.DB
+	searchFields  []string
+	sortCollation string
+
+	slowQueryThreshold time.Duration
+	logger             *slog.Logger
+}
+
+// Option configures a PostgresTaskRepository
+type Option func(*PostgresTaskRepository)
+
+// WithSearchFields sets the columns matched by the list search term. Fields
+// that are not searchable columns are dropped; an empty result keeps the
+// defaults.
+func WithSearchFields(fields []string) Option {
+	return func(r *PostgresTaskRepository) {
+		allowed := []string{}
+		for _, field := range fields {
+			if searchableColumns[field] {
+				allowed = append(allowed, field)
+			}
+		}
+		if len(allowed) > 0 {
+			r.searchFields = allowed
+		}
+	}
 }
 
 // NewPostgresTaskRepository creates a new PostgreSQL task repository
-func NewPostgresTaskRepository(db *sql.DB) *PostgresTaskRepository {
-	return &PostgresTaskRepository{db: db}
+func NewPostgresTaskRepository(db *sql.DB, opts ...Option) *PostgresTaskRepository {
+	r := &PostgresTaskRepository{db: db, searchFields: DefaultSearchFields}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 // Create inserts a new task into the database
 func (r *PostgresTaskRepository) Create(ctx context.Context, task *models.Task) error {
+	defer r.observe("Create", time.Now(), slog.String("task_id", task.ID))
 	query := `
-		INSERT INTO tasks (id, title, description, status, assignee, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO tasks (id, title, description, status, assignee, source, external_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 	_, err := r.db.ExecContext(ctx, query,
 		task.ID, task.Title, task.Description, task.Status, task.Assignee,
-		task.CreatedAt, task.UpdatedAt,
+		task.Source, task.ExternalID, task.CreatedAt, task.UpdatedAt,
 	)
 	if err != nil {
+		if dupErr := duplicateError(err); dupErr != nil {
+			return dupErr
+		}
 		return fmt.Errorf("failed to create task: %w", err)
 	}
 	return nil
 }
 
+// CreateMany inserts tasks in a single transaction, so either all of them
+// are stored or none are. Their IDs and timestamps are stored as given.
+func (r *PostgresTaskRepository) CreateMany(ctx context.Context, tasks []*models.Task) (err error) {
+	defer r.observe("CreateMany", time.Now(), slog.Int("count", len(tasks)))
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	stmt, err := tx.PrepareContext(ctx, insertTaskQuery)
+	if err != nil {
+		return fmt.Errorf("failed to prepare insert: %w", err)
+	}
+	defer stmt.Close()
+
+	if err = insertTasks(ctx, stmt, tasks); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit tasks: %w", err)
+	}
+	return nil
+}
+
+// insertTaskQuery inserts a task with the ID and timestamps it already has
+const insertTaskQuery = `
+	INSERT INTO tasks (id, title, description, status, assignee, source, external_id, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+`
+
+// insertTasks runs the prepared insertTaskQuery for each task, stopping at
+// the first failure
+func insertTasks(ctx context.Context, stmt *sql.Stmt, tasks []*models.Task) error {
+	for _, task := range tasks {
+		_, err := stmt.ExecContext(ctx,
+			task.ID, task.Title, task.Description, task.Status, task.Assignee,
+			task.Source, task.ExternalID, task.CreatedAt, task.UpdatedAt,
+		)
+		if err != nil {
+			if dupErr := duplicateError(err); dupErr != nil {
+				return dupErr
+			}
+			return fmt.Errorf("failed to create task: %w", err)
+		}
+	}
+	return nil
+}
+
 // GetByID retrieves a task by its ID
 func (r *PostgresTaskRepository) GetByID(ctx context.Context, id string) (*models.Task, error) {
+	defer r.observe("GetByID", time.Now(), slog.String("task_id", id))
 	query := `
-		SELECT id, title, description, status, assignee, created_at, updated_at
+		SELECT id, title, description, status, assignee, source, external_id, created_at, updated_at
 		FROM tasks
 		WHERE id = $1
 	`
 	task := &models.Task{}
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&task.ID, &task.Title, &task.Description, &task.Status, &task.Assignee,
-		&task.CreatedAt, &task.UpdatedAt,
+		&task.Source, &task.ExternalID, &task.CreatedAt, &task.UpdatedAt,
 	)
 	if err == sql.ErrNoRows {
 		return nil, ErrTaskNotFound
@@ -62,9 +229,59 @@ func (r *PostgresTaskRepository) GetByID(ctx context.Context, id string) (*model
 	return task, nil
 }
 
-// GetAll retrieves all tasks with optional filtering and pagination
-func (r *PostgresTaskRepository) GetAll(ctx context.Context, filter *models.TaskFilter) ([]models.Task, int, error) {
-	// Build query with filters
+// GetUpdatedAt returns when the task was last updated without loading the
+// rest of it
+func (r *PostgresTaskRepository) GetUpdatedAt(ctx context.Context, id string) (time.Time, error) {
+	defer r.observe("GetUpdatedAt", time.Now(), slog.String("task_id", id))
+	var updatedAt time.Time
+	err := r.db.QueryRowContext(ctx, `SELECT updated_at FROM tasks WHERE id = $1`, id).Scan(&updatedAt)
+	if err == sql.ErrNoRows {
+		return time.Time{}, ErrTaskNotFound
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get task: %w", err)
+	}
+	return updatedAt, nil
+}
+
+// GetByIDs retrieves the tasks with the given IDs. Missing IDs are skipped
+// and the result order is unspecified.
+func (r *PostgresTaskRepository) GetByIDs(ctx context.Context, ids []string) ([]models.Task, error) {
+	defer r.observe("GetByIDs", time.Now(), slog.Int("ids", len(ids)))
+	query := `
+		SELECT id, title, description, status, assignee, source, external_id, created_at, updated_at
+		FROM tasks
+		WHERE id = ANY($1)
+	`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := []models.Task{}
+	for rows.Next() {
+		var task models.Task
+		err := rows.Scan(
+			&task.ID, &task.Title, &task.Description, &task.Status, &task.Assignee,
+			&task.Source, &task.ExternalID, &task.CreatedAt, &task.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// filterClause builds the WHERE clause selecting the tasks that match filter,
+// ignoring its pagination, and the arguments it refers to as $1, $2, ...
+func (r *PostgresTaskRepository) filterClause(filter *models.TaskFilter) (string, []interface{}) {
 	whereClause := []string{}
 	args := []interface{}{}
 	argPos := 1
@@ -75,17 +292,165 @@ func (r *PostgresTaskRepository) GetAll(ctx context.Context, filter *models.Task
 		argPos++
 	}
 
-	if filter.Assignee != nil {
+	// Several assignees match any of them
+	switch len(filter.Assignees) {
+	case 0:
+	case 1:
 		whereClause = append(whereClause, fmt.Sprintf("assignee = $%d", argPos))
-		args = append(args, *filter.Assignee)
+		args = append(args, filter.Assignees[0])
+		argPos++
+	default:
+		whereClause = append(whereClause, fmt.Sprintf("assignee = ANY($%d)", argPos))
+		args = append(args, pq.Array(filter.Assignees))
+		argPos++
+	}
+
+	if filter.Source != nil {
+		whereClause = append(whereClause, fmt.Sprintf("source = $%d", argPos))
+		args = append(args, *filter.Source)
+		argPos++
+	}
+
+	if filter.ExternalID != nil {
+		whereClause = append(whereClause, fmt.Sprintf("external_id = $%d", argPos))
+		args = append(args, *filter.ExternalID)
 		argPos++
 	}
 
+	if filter.HasDescription != nil {
+		if *filter.HasDescription {
+			whereClause = append(whereClause, "description <> ''")
+		} else {
+			whereClause = append(whereClause, "(description = '' OR description IS NULL)")
+		}
+	}
+
+	if filter.Search != "" {
+		conditions := make([]string, len(r.searchFields))
+		for i, field := range r.searchFields {
+			conditions[i] = fmt.Sprintf("%s ILIKE $%d", field, argPos)
+		}
+		whereClause = append(whereClause, "("+strings.Join(conditions, " OR ")+")")
+		args = append(args, "%"+escapeLike(filter.Search)+"%")
+		argPos++
+	}
+
+	// Matches idx_tasks_title_prefix, unlike ILIKE which cannot use an index
+	if filter.TitlePrefix != nil {
+		whereClause = append(whereClause, fmt.Sprintf("lower(title) LIKE lower($%d) || '%%'", argPos))
+		args = append(args, escapeLike(*filter.TitlePrefix))
+		argPos++
+	}
+
+	// Stale tasks are open and untouched for StaleDays, measured by the
+	// database clock so cached queries and page tokens stay stable
+	if filter.StaleDays > 0 {
+		whereClause = append(whereClause, fmt.Sprintf("updated_at < NOW() - $%d * INTERVAL '1 day' AND status <> ALL($%d)", argPos, argPos+1))
+		args = append(args, filter.StaleDays, pq.Array(models.TerminalStatuses()))
+		argPos += 2
+	}
+
 	whereSQL := ""
 	if len(whereClause) > 0 {
 		whereSQL = "WHERE " + strings.Join(whereClause, " AND ")
 	}
 
+	return whereSQL, args
+}
+
+// GetBoard returns the requested page of every status in pages, ordered by
+// the filter's sort within each status, along with the number of matching
+// tasks per status. Pages of all columns are read in one windowed query.
+func (r *PostgresTaskRepository) GetBoard(ctx context.Context, filter *models.TaskFilter, pages map[models.TaskStatus]models.BoardPage) (map[models.TaskStatus]models.BoardColumn, error) {
+	defer r.observe("GetBoard", time.Now(), filterAttrs(filter))
+
+	whereSQL, args := r.filterClause(filter)
+	argPos := len(args) + 1
+
+	columns := make(map[models.TaskStatus]models.BoardColumn, len(pages))
+	statuses := make([]string, 0, len(pages))
+	firsts := make([]int64, 0, len(pages))
+	lasts := make([]int64, 0, len(pages))
+	for _, status := range slices.Sorted(maps.Keys(pages)) {
+		page := pages[status]
+		columns[status] = models.BoardColumn{Tasks: []models.Task{}, Offset: page.Offset, Limit: page.Limit}
+		statuses = append(statuses, string(status))
+		firsts = append(firsts, int64(page.Offset))
+		lasts = append(lasts, int64(page.Offset+page.Limit))
+	}
+
+	countQuery := fmt.Sprintf("SELECT status, COUNT(*) FROM tasks %s GROUP BY status", whereSQL)
+	rows, err := r.db.QueryContext(ctx, countQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count board tasks: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var status models.TaskStatus
+		var total int
+		if err := rows.Scan(&status, &total); err != nil {
+			return nil, fmt.Errorf("failed to scan board count: %w", err)
+		}
+		if column, ok := columns[status]; ok {
+			column.Total = total
+			columns[status] = column
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating board counts: %w", err)
+	}
+
+	// Number the tasks of each status in sort order and keep the rows that
+	// fall inside that status's page
+	direction := orderDirection(filter.Order)
+	query := fmt.Sprintf(`
+		SELECT ranked.id, ranked.title, ranked.description, ranked.status, ranked.assignee,
+			ranked.source, ranked.external_id, ranked.created_at, ranked.updated_at
+		FROM (
+			SELECT id, title, description, status, assignee, source, external_id, created_at, updated_at,
+				ROW_NUMBER() OVER (PARTITION BY status ORDER BY %s %s, id %s) AS position
+			FROM tasks
+			%s
+		) ranked
+		JOIN unnest($%d::text[], $%d::bigint[], $%d::bigint[]) AS page(status, first, last)
+			ON page.status = ranked.status
+		WHERE ranked.position > page.first AND ranked.position <= page.last
+		ORDER BY ranked.status, ranked.position
+	`, r.orderColumn(filter.Sort), direction, direction, whereSQL, argPos, argPos+1, argPos+2)
+	args = append(args, pq.Array(statuses), pq.Array(firsts), pq.Array(lasts))
+
+	taskRows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board tasks: %w", err)
+	}
+	defer taskRows.Close()
+	for taskRows.Next() {
+		var task models.Task
+		err := taskRows.Scan(
+			&task.ID, &task.Title, &task.Description, &task.Status, &task.Assignee,
+			&task.Source, &task.ExternalID, &task.CreatedAt, &task.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+		column := columns[task.Status]
+		column.Tasks = append(column.Tasks, task)
+		columns[task.Status] = column
+	}
+	if err := taskRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating board tasks: %w", err)
+	}
+
+	return columns, nil
+}
+
+// GetAll retrieves all tasks with optional filtering and pagination
+func (r *PostgresTaskRepository) GetAll(ctx context.Context, filter *models.TaskFilter) ([]models.Task, int, error) {
+	defer r.observe("GetAll", time.Now(), filterAttrs(filter))
+
+	whereSQL, args := r.filterClause(filter)
+	argPos := len(args) + 1
+
 	// Get total count
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM tasks %s", whereSQL)
 	var total int
@@ -109,14 +474,15 @@ func (r *PostgresTaskRepository) GetAll(ctx context.Context, filter *models.Task
 
 	offset := (page - 1) * pageSize
 
-	// Get paginated results
+	// Get paginated results, with id as a tiebreaker so pages are stable
+	direction := orderDirection(filter.Order)
 	query := fmt.Sprintf(`
-		SELECT id, title, description, status, assignee, created_at, updated_at
+		SELECT id, title, description, status, assignee, source, external_id, created_at, updated_at
 		FROM tasks
 		%s
-		ORDER BY created_at DESC
+		ORDER BY %s %s, id %s
 		LIMIT $%d OFFSET $%d
-	`, whereSQL, argPos, argPos+1)
+	`, whereSQL, r.orderColumn(filter.Sort), direction, direction, argPos, argPos+1)
 
 	args = append(args, pageSize, offset)
 
@@ -128,10 +494,15 @@ func (r *PostgresTaskRepository) GetAll(ctx context.Context, filter *models.Task
 
 	tasks := []models.Task{}
 	for rows.Next() {
+		// Stop scanning as soon as the caller gives up on the request
+		if err := ctx.Err(); err != nil {
+			return nil, 0, fmt.Errorf("task iteration aborted: %w", err)
+		}
+
 		var task models.Task
 		err := rows.Scan(
 			&task.ID, &task.Title, &task.Description, &task.Status, &task.Assignee,
-			&task.CreatedAt, &task.UpdatedAt,
+			&task.Source, &task.ExternalID, &task.CreatedAt, &task.UpdatedAt,
 		)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan task: %w", err)
@@ -146,17 +517,184 @@ func (r *PostgresTaskRepository) GetAll(ctx context.Context, filter *models.Task
 	return tasks, total, nil
 }
 
+// escapeLike escapes the LIKE wildcards in a search term so it is matched literally
+func escapeLike(term string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(term)
+}
+
+// sortColumn maps a sort field onto its column, falling back to the default
+// for anything that is not sortable so the result is safe to interpolate
+func sortColumn(field string) string {
+	if models.IsSortableField(field) {
+		return field
+	}
+	return models.DefaultSortField
+}
+
+// GetIDs returns the IDs of the first limit tasks matching filter, in the
+// filter's sort order, and the total number of matches. Pagination is
+// ignored. Only the id column is read, which an index-only scan can serve.
+func (r *PostgresTaskRepository) GetIDs(ctx context.Context, filter *models.TaskFilter, limit int) ([]string, int, error) {
+	defer r.observe("GetIDs", time.Now(), filterAttrs(filter))
+
+	whereSQL, args := r.filterClause(filter)
+
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM tasks %s", whereSQL)
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count tasks: %w", err)
+	}
+
+	direction := orderDirection(filter.Order)
+	query := fmt.Sprintf(`
+		SELECT id
+		FROM tasks
+		%s
+		ORDER BY %s %s, id %s
+		LIMIT $%d
+	`, whereSQL, r.orderColumn(filter.Sort), direction, direction, len(args)+1)
+
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get task IDs: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan task ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating task IDs: %w", err)
+	}
+
+	return ids, total, nil
+}
+
+// orderDirection maps a sort order onto its SQL keyword. Only the two fixed
+// keywords are ever returned, so the result is safe to interpolate.
+func orderDirection(order models.SortOrder) string {
+	if order == models.SortOrderAsc {
+		return "ASC"
+	}
+	return "DESC"
+}
+
+// GetChangedSince retrieves tasks changed after a position, oldest change
+// first. The position is (since, afterID) in (updated_at, id) order, so a
+// page ending inside a run of equal timestamps resumes at the next id. An
+// empty afterID starts after every task updated at since.
+func (r *PostgresTaskRepository) GetChangedSince(ctx context.Context, since time.Time, afterID string, limit int) ([]models.Task, error) {
+	defer r.observe("GetChangedSince", time.Now(), slog.Time("since", since), slog.String("after_id", afterID), slog.Int("limit", limit))
+	query := `
+		SELECT id, title, description, status, assignee, source, external_id, created_at, updated_at
+		FROM tasks
+		WHERE updated_at > $1
+		ORDER BY updated_at ASC, id ASC
+		LIMIT $2
+	`
+	args := []interface{}{since, limit}
+	if afterID != "" {
+		query = `
+			SELECT id, title, description, status, assignee, source, external_id, created_at, updated_at
+			FROM tasks
+			WHERE (updated_at, id) > ($1, $2)
+			ORDER BY updated_at ASC, id ASC
+			LIMIT $3
+		`
+		args = []interface{}{since, afterID, limit}
+	}
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changed tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := []models.Task{}
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("task iteration aborted: %w", err)
+		}
+
+		var task models.Task
+		err := rows.Scan(
+			&task.ID, &task.Title, &task.Description, &task.Status, &task.Assignee,
+			&task.Source, &task.ExternalID, &task.CreatedAt, &task.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// GetRecentlyUpdated retrieves the limit most recently updated tasks, newest first
+func (r *PostgresTaskRepository) GetRecentlyUpdated(ctx context.Context, limit int) ([]models.Task, error) {
+	defer r.observe("GetRecentlyUpdated", time.Now(), slog.Int("limit", limit))
+	query := `
+		SELECT id, title, description, status, assignee, source, external_id, created_at, updated_at
+		FROM tasks
+		ORDER BY updated_at DESC, id DESC
+		LIMIT $1
+	`
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := []models.Task{}
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("task iteration aborted: %w", err)
+		}
+
+		var task models.Task
+		err := rows.Scan(
+			&task.ID, &task.Title, &task.Description, &task.Status, &task.Assignee,
+			&task.Source, &task.ExternalID, &task.CreatedAt, &task.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
 // Update updates an existing task
 func (r *PostgresTaskRepository) Update(ctx context.Context, task *models.Task) error {
+	defer r.observe("Update", time.Now(), slog.String("task_id", task.ID))
 	query := `
 		UPDATE tasks
-		SET title = $1, description = $2, status = $3, assignee = $4, updated_at = $5
-		WHERE id = $6
+		SET title = $1, description = $2, status = $3, assignee = $4,
+			source = $5, external_id = $6, updated_at = $7
+		WHERE id = $8
 	`
 	result, err := r.db.ExecContext(ctx, query,
-		task.Title, task.Description, task.Status, task.Assignee, task.UpdatedAt, task.ID,
+		task.Title, task.Description, task.Status, task.Assignee,
+		task.Source, task.ExternalID, task.UpdatedAt, task.ID,
 	)
 	if err != nil {
+		if dupErr := duplicateError(err); dupErr != nil {
+			return dupErr
+		}
 		return fmt.Errorf("failed to update task: %w", err)
 	}
 
@@ -172,8 +710,211 @@ func (r *PostgresTaskRepository) Update(ctx context.Context, task *models.Task)
 	return nil
 }
 
+// UpdateAssignee sets only the assignee of a task and returns the updated task
+// together with the assignee it replaced. updated_at never moves before
+// created_at.
+func (r *PostgresTaskRepository) UpdateAssignee(ctx context.Context, id, assignee string, updatedAt time.Time) (*models.Task, string, error) {
+	defer r.observe("UpdateAssignee", time.Now(), slog.String("task_id", id))
+	query := `
+		UPDATE tasks t
+		SET assignee = $2, updated_at = GREATEST($3, t.created_at)
+		FROM (SELECT id, assignee FROM tasks WHERE id = $1 FOR UPDATE) prev
+		WHERE t.id = prev.id
+		RETURNING t.id, t.title, t.description, t.status, t.assignee, t.source, t.external_id,
+			t.created_at, t.updated_at, prev.assignee
+	`
+	task := &models.Task{}
+	var previousAssignee string
+	err := r.db.QueryRowContext(ctx, query, id, assignee, updatedAt).Scan(
+		&task.ID, &task.Title, &task.Description, &task.Status, &task.Assignee,
+		&task.Source, &task.ExternalID, &task.CreatedAt, &task.UpdatedAt, &previousAssignee,
+	)
+	if err == sql.ErrNoRows {
+		return nil, "", ErrTaskNotFound
+	}
+	if err != nil {
+		if dupErr := duplicateError(err); dupErr != nil {
+			return nil, "", dupErr
+		}
+		return nil, "", fmt.Errorf("failed to update assignee: %w", err)
+	}
+	return task, previousAssignee, nil
+}
+
+// ReassignAll moves every task assigned to from, or only those in status when
+// it is set, to the assignee to in a single statement and returns the updated
+// tasks. A uniqueness violation leaves every task untouched. updated_at never
+// moves before created_at.
+func (r *PostgresTaskRepository) ReassignAll(ctx context.Context, from, to string, status *models.TaskStatus, updatedAt time.Time) ([]models.Task, error) {
+	defer r.observe("ReassignAll", time.Now())
+	query := `
+		UPDATE tasks
+		SET assignee = $1, updated_at = GREATEST($2, created_at)
+		WHERE assignee = $3
+	`
+	args := []interface{}{to, updatedAt, from}
+	if status != nil {
+		query += " AND status = $4"
+		args = append(args, *status)
+	}
+	query += " RETURNING id, title, description, status, assignee, source, external_id, created_at, updated_at"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		if dupErr := duplicateError(err); dupErr != nil {
+			return nil, dupErr
+		}
+		return nil, fmt.Errorf("failed to reassign tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := []models.Task{}
+	for rows.Next() {
+		var task models.Task
+		err := rows.Scan(
+			&task.ID, &task.Title, &task.Description, &task.Status, &task.Assignee,
+			&task.Source, &task.ExternalID, &task.CreatedAt, &task.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err = rows.Err(); err != nil {
+		if dupErr := duplicateError(err); dupErr != nil {
+			return nil, dupErr
+		}
+		return nil, fmt.Errorf("error iterating tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// UpsertByExternalID inserts task, or when a task with the same source and
+// external ID exists, overwrites its title, description, status and assignee.
+// The stored task is returned along with whether it was newly created.
+func (r *PostgresTaskRepository) UpsertByExternalID(ctx context.Context, task *models.Task) (*models.Task, bool, error) {
+	if task.Source == nil || task.ExternalID == nil {
+		return nil, false, fmt.Errorf("%w: upsert requires source and external_id", ErrInvalidInput)
+	}
+	defer r.observe("UpsertByExternalID", time.Now(), slog.String("source", *task.Source), slog.String("external_id", *task.ExternalID))
+
+	query := `
+		INSERT INTO tasks (id, title, description, status, assignee, source, external_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (source, external_id) DO UPDATE
+		SET title = EXCLUDED.title, description = EXCLUDED.description, status = EXCLUDED.status,
+			assignee = EXCLUDED.assignee, updated_at = GREATEST(EXCLUDED.updated_at, tasks.created_at)
+		RETURNING id, title, description, status, assignee, source, external_id, created_at, updated_at,
+			(xmax = 0) AS inserted
+	`
+	stored := &models.Task{}
+	var inserted bool
+	err := r.db.QueryRowContext(ctx, query,
+		task.ID, task.Title, task.Description, task.Status, task.Assignee,
+		task.Source, task.ExternalID, task.CreatedAt, task.UpdatedAt,
+	).Scan(
+		&stored.ID, &stored.Title, &stored.Description, &stored.Status, &stored.Assignee,
+		&stored.Source, &stored.ExternalID, &stored.CreatedAt, &stored.UpdatedAt, &inserted,
+	)
+	if err != nil {
+		if dupErr := duplicateError(err); dupErr != nil {
+			return nil, false, dupErr
+		}
+		return nil, false, fmt.Errorf("failed to upsert task: %w", err)
+	}
+	return stored, inserted, nil
+}
+
+// createIfNotExistsAttempts bounds how often CreateIfNotExists retries when
+// the conflicting task is deleted between its insert and lookup
+const createIfNotExistsAttempts = 3
+
+// CreateIfNotExists inserts task unless a task with the same source and
+// external ID exists, in which case that task is returned untouched. The
+// stored task is returned along with whether it was newly created. The
+// unique index decides the race between concurrent callers, so exactly one
+// of them creates the task.
+func (r *PostgresTaskRepository) CreateIfNotExists(ctx context.Context, task *models.Task) (*models.Task, bool, error) {
+	if task.Source == nil || task.ExternalID == nil {
+		return nil, false, fmt.Errorf("%w: conditional create requires source and external_id", ErrInvalidInput)
+	}
+	defer r.observe("CreateIfNotExists", time.Now(), slog.String("source", *task.Source), slog.String("external_id", *task.ExternalID))
+
+	insert := `
+		INSERT INTO tasks (id, title, description, status, assignee, source, external_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (source, external_id) DO NOTHING
+		RETURNING id, title, description, status, assignee, source, external_id, created_at, updated_at
+	`
+	lookup := `
+		SELECT id, title, description, status, assignee, source, external_id, created_at, updated_at
+		FROM tasks
+		WHERE source = $1 AND external_id = $2
+	`
+	for attempt := 1; ; attempt++ {
+		stored := &models.Task{}
+		err := r.db.QueryRowContext(ctx, insert,
+			task.ID, task.Title, task.Description, task.Status, task.Assignee,
+			task.Source, task.ExternalID, task.CreatedAt, task.UpdatedAt,
+		).Scan(
+			&stored.ID, &stored.Title, &stored.Description, &stored.Status, &stored.Assignee,
+			&stored.Source, &stored.ExternalID, &stored.CreatedAt, &stored.UpdatedAt,
+		)
+		if err == nil {
+			return stored, true, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			if dupErr := duplicateError(err); dupErr != nil {
+				return nil, false, dupErr
+			}
+			return nil, false, fmt.Errorf("failed to create task: %w", err)
+		}
+
+		// The insert hit an existing task; a fresh statement sees it even
+		// when it was committed after the insert started
+		err = r.db.QueryRowContext(ctx, lookup, task.Source, task.ExternalID).Scan(
+			&stored.ID, &stored.Title, &stored.Description, &stored.Status, &stored.Assignee,
+			&stored.Source, &stored.ExternalID, &stored.CreatedAt, &stored.UpdatedAt,
+		)
+		if err == nil {
+			return stored, false, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, false, fmt.Errorf("failed to get existing task: %w", err)
+		}
+		if attempt >= createIfNotExistsAttempts {
+			return nil, false, fmt.Errorf("failed to create task: existing task kept disappearing")
+		}
+	}
+}
+
+// DeleteIfUnmodified deletes a task only while its updated_at still equals
+// updatedAt, returning ErrTaskModified otherwise
+func (r *PostgresTaskRepository) DeleteIfUnmodified(ctx context.Context, id string, updatedAt time.Time) error {
+	defer r.observe("DeleteIfUnmodified", time.Now(), slog.String("task_id", id))
+	query := `DELETE FROM tasks WHERE id = $1 AND updated_at = $2`
+	result, err := r.db.ExecContext(ctx, query, id, updatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrTaskModified
+	}
+
+	return nil
+}
+
 // Delete deletes a task by its ID
 func (r *PostgresTaskRepository) Delete(ctx context.Context, id string) error {
+	defer r.observe("Delete", time.Now(), slog.String("task_id", id))
 	query := `DELETE FROM tasks WHERE id = $1`
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
@@ -194,6 +935,7 @@ func (r *PostgresTaskRepository) Delete(ctx context.Context, id string) error {
 
 // Count returns the total number of tasks
 func (r *PostgresTaskRepository) Count(ctx context.Context) (int, error) {
+	defer r.observe("Count", time.Now())
 	var count int
 	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM tasks").Scan(&count)
 	if err != nil {
@@ -202,6 +944,200 @@ func (r *PostgresTaskRepository) Count(ctx context.Context) (int, error) {
 	return count, nil
 }
 
+// OldestOpenByStatus returns the earliest created_at of tasks in each status
+// that is not completed or cancelled
+func (r *PostgresTaskRepository) OldestOpenByStatus(ctx context.Context) (map[models.TaskStatus]time.Time, error) {
+	defer r.observe("OldestOpenByStatus", time.Now())
+	query := `
+		SELECT status, MIN(created_at)
+		FROM tasks
+		WHERE status NOT IN ($1, $2)
+		GROUP BY status
+	`
+	rows, err := r.db.QueryContext(ctx, query, models.TaskStatusCompleted, models.TaskStatusCancelled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oldest tasks: %w", err)
+	}
+	defer rows.Close()
+
+	oldest := make(map[models.TaskStatus]time.Time)
+	for rows.Next() {
+		var status models.TaskStatus
+		var createdAt time.Time
+		if err := rows.Scan(&status, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan oldest task: %w", err)
+		}
+		oldest[status] = createdAt
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating oldest tasks: %w", err)
+	}
+
+	return oldest, nil
+}
+
+// CompletionsByAssignee counts the tasks currently completed whose last
+// update falls in [since, until), per assignee. There is no status history,
+// so updated_at stands in for the completion time; a completed task edited
+// later moves to the window of that edit.
+func (r *PostgresTaskRepository) CompletionsByAssignee(ctx context.Context, since, until time.Time, assignee *string) (map[string]int, error) {
+	defer r.observe("CompletionsByAssignee", time.Now(), slog.Time("since", since), slog.Time("until", until))
+	query := `
+		SELECT assignee, COUNT(*)
+		FROM tasks
+		WHERE status = $1 AND updated_at >= $2 AND updated_at < $3
+	`
+	args := []interface{}{models.TaskStatusCompleted, since, until}
+	if assignee != nil {
+		query += " AND assignee = $4"
+		args = append(args, *assignee)
+	}
+	query += " GROUP BY assignee"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count completions: %w", err)
+	}
+	defer rows.Close()
+
+	completions := make(map[string]int)
+	for rows.Next() {
+		var name string
+		var count int
+		if err := rows.Scan(&name, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan completions: %w", err)
+		}
+		completions[name] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating completions: %w", err)
+	}
+
+	return completions, nil
+}
+
+// Trend counts the tasks created and completed in [from, to), grouped by the
+// UTC date_trunc of interval (day, week or month). Only non-empty buckets
+// are returned, oldest first. As in CompletionsByAssignee, updated_at stands
+// in for the completion time. The columns are plain TIMESTAMPs holding UTC,
+// so they are truncated as-is; converting them to timestamptz would truncate
+// in the session TimeZone instead.
+func (r *PostgresTaskRepository) Trend(ctx context.Context, interval string, from, to time.Time) ([]models.TrendBucket, error) {
+	defer r.observe("Trend", time.Now(), slog.String("interval", interval), slog.Time("from", from), slog.Time("to", to))
+	query := `
+		SELECT bucket, SUM(created), SUM(completed)
+		FROM (
+			SELECT date_trunc($1, created_at) AS bucket, 1 AS created, 0 AS completed
+			FROM tasks
+			WHERE created_at >= $2 AND created_at < $3
+			UNION ALL
+			SELECT date_trunc($1, updated_at), 0, 1
+			FROM tasks
+			WHERE status = $4 AND updated_at >= $2 AND updated_at < $3
+		) events
+		GROUP BY bucket
+		ORDER BY bucket
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, interval, from, to, models.TaskStatusCompleted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count trend: %w", err)
+	}
+	defer rows.Close()
+
+	var buckets []models.TrendBucket
+	for rows.Next() {
+		var bucket models.TrendBucket
+		if err := rows.Scan(&bucket.Start, &bucket.Created, &bucket.Completed); err != nil {
+			return nil, fmt.Errorf("failed to scan trend: %w", err)
+		}
+		bucket.Start = bucket.Start.UTC()
+		buckets = append(buckets, bucket)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating trend: %w", err)
+	}
+
+	return buckets, nil
+}
+
+// PurgeCompletedBefore deletes completed tasks last updated before the cutoff
+// and returns the number of rows removed
+func (r *PostgresTaskRepository) PurgeCompletedBefore(ctx context.Context, before time.Time) (int, error) {
+	defer r.observe("PurgeCompletedBefore", time.Now(), slog.Time("before", before))
+	query := `DELETE FROM tasks WHERE status = $1 AND updated_at < $2`
+	result, err := r.db.ExecContext(ctx, query, models.TaskStatusCompleted, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge tasks: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return int(rowsAffected), nil
+}
+
+// TransitionStale moves tasks in status from that were last updated before
+// the cutoff to status to in a single statement and returns how many moved
+func (r *PostgresTaskRepository) TransitionStale(ctx context.Context, from, to models.TaskStatus, before, updatedAt time.Time) (int, error) {
+	defer r.observe("TransitionStale", time.Now(), slog.String("from", string(from)), slog.Time("before", before))
+	query := `UPDATE tasks SET status = $1, updated_at = $2 WHERE status = $3 AND updated_at < $4`
+	result, err := r.db.ExecContext(ctx, query, to, updatedAt, from, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to transition stale tasks: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return int(rowsAffected), nil
+}
+
+// ListCompletedBefore returns the IDs of at most limit of the tasks
+// PurgeCompletedBefore would delete for the same cutoff, oldest first, and
+// the number of tasks it would delete in total
+func (r *PostgresTaskRepository) ListCompletedBefore(ctx context.Context, before time.Time, limit int) ([]string, int, error) {
+	defer r.observe("ListCompletedBefore", time.Now(), slog.Time("before", before), slog.Int("limit", limit))
+	// The window count is taken before LIMIT, so it covers every match
+	query := `SELECT id, COUNT(*) OVER () FROM tasks WHERE status = $1 AND updated_at < $2 ORDER BY updated_at ASC LIMIT $3`
+	rows, err := r.db.QueryContext(ctx, query, models.TaskStatusCompleted, before, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list purgeable tasks: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	total := 0
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id, &total); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan task id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating task ids: %w", err)
+	}
+
+	return ids, total, nil
+}
+
+// Ping verifies the database connection is alive
+func (r *PostgresTaskRepository) Ping(ctx context.Context) error {
+	if err := r.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+	return nil
+}
+
 // InitSchema initializes the database schema
 func (r *PostgresTaskRepository) InitSchema(ctx context.Context) error {
 	query := `
@@ -215,9 +1151,17 @@ func (r *PostgresTaskRepository) InitSchema(ctx context.Context) error {
 			updated_at TIMESTAMP NOT NULL
 		);
 
+		ALTER TABLE tasks ADD COLUMN IF NOT EXISTS source VARCHAR(100);
+		ALTER TABLE tasks ADD COLUMN IF NOT EXISTS external_id VARCHAR(255);
+
 		CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks(status);
 		CREATE INDEX IF NOT EXISTS idx_tasks_assignee ON tasks(assignee);
 		CREATE INDEX IF NOT EXISTS idx_tasks_created_at ON tasks(created_at);
+		CREATE INDEX IF NOT EXISTS idx_tasks_updated_at ON tasks(updated_at);
+		CREATE INDEX IF NOT EXISTS idx_tasks_created_at_id ON tasks(created_at, id);
+		CREATE INDEX IF NOT EXISTS idx_tasks_updated_at_id ON tasks(updated_at, id);
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_source_external_id ON tasks(source, external_id);
+		CREATE INDEX IF NOT EXISTS idx_tasks_title_prefix ON tasks(lower(title) text_pattern_ops);
 	`
 	_, err := r.db.ExecContext(ctx, query)
 	if err != nil {
@@ -225,3 +1169,38 @@ func (r *PostgresTaskRepository) InitSchema(ctx context.Context) error {
 	}
 	return nil
 }
+
+// EnsureUniqueTitlePerAssignee adds a unique index on (assignee, title).
+// When existing rows already violate it the index is not created and
+// applied is false, so callers can warn instead of failing startup.
+func (r *PostgresTaskRepository) EnsureUniqueTitlePerAssignee(ctx context.Context) (applied bool, err error) {
+	var hasDuplicates bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM tasks
+			GROUP BY assignee, title
+			HAVING COUNT(*) > 1
+		)
+	`
+	if err := r.db.QueryRowContext(ctx, query).Scan(&hasDuplicates); err != nil {
+		return false, fmt.Errorf("failed to check for duplicate titles: %w", err)
+	}
+	if hasDuplicates {
+		return false, nil
+	}
+
+	_, err = r.db.ExecContext(ctx, `CREATE UNIQUE INDEX IF NOT EXISTS `+assigneeTitleIndex+` ON tasks(assignee, title)`)
+	if err != nil {
+		return false, fmt.Errorf("failed to create unique title index: %w", err)
+	}
+	return true, nil
+}
+
+// DropUniqueTitlePerAssignee removes the index added by
+// EnsureUniqueTitlePerAssignee, so turning the setting off stops enforcing it
+func (r *PostgresTaskRepository) DropUniqueTitlePerAssignee(ctx context.Context) error {
+	if _, err := r.db.ExecContext(ctx, `DROP INDEX IF EXISTS `+assigneeTitleIndex); err != nil {
+		return fmt.Errorf("failed to drop unique title index: %w", err)
+	}
+	return nil
+}
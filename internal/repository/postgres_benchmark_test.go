@@ -350,10 +350,10 @@ func BenchmarkTaskFilterCreation(b *testing.B) {
 
 	for i := 0; i < b.N; i++ {
 		filter := &models.TaskFilter{
-			Status:   &status,
-			Assignee: &assignee,
-			Page:     1,
-			PageSize: 10,
+			Status:    &status,
+			Assignees: []string{assignee},
+			Page:      1,
+			PageSize:  10,
 		}
 		_ = filter
 	}
@@ -472,9 +472,9 @@ func BenchmarkPostgresQueryPatterns(b *testing.B) {
 
 	b.Run("AssigneeFilter", func(b *testing.B) {
 		filter := &models.TaskFilter{
-			Assignee: &assignee,
-			Page:     1,
-			PageSize: 10,
+			Assignees: []string{assignee},
+			Page:      1,
+			PageSize:  10,
 		}
 		b.ReportAllocs()
 		b.ResetTimer()
@@ -485,10 +485,10 @@ func BenchmarkPostgresQueryPatterns(b *testing.B) {
 
 	b.Run("CombinedFilter", func(b *testing.B) {
 		filter := &models.TaskFilter{
-			Status:   &status,
-			Assignee: &assignee,
-			Page:     1,
-			PageSize: 10,
+			Status:    &status,
+			Assignees: []string{assignee},
+			Page:      1,
+			PageSize:  10,
 		}
 		b.ReportAllocs()
 		b.ResetTimer()
@@ -3,10 +3,14 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/Ali-Gorgani/task-manager/internal/models"
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -25,7 +29,7 @@ func TestCreate(t *testing.T) {
 	task := models.NewTask("Test Task", "Description", "test@example.com", models.TaskStatusPending)
 
 	mock.ExpectExec("INSERT INTO tasks").
-		WithArgs(task.ID, task.Title, task.Description, task.Status, task.Assignee, task.CreatedAt, task.UpdatedAt).
+		WithArgs(task.ID, task.Title, task.Description, task.Status, task.Assignee, task.Source, task.ExternalID, task.CreatedAt, task.UpdatedAt).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	err := repo.Create(context.Background(), task)
@@ -40,8 +44,8 @@ func TestGetByID_Success(t *testing.T) {
 	repo := NewPostgresTaskRepository(db)
 	expectedTask := models.NewTask("Test Task", "Description", "test@example.com", models.TaskStatusPending)
 
-	rows := sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "created_at", "updated_at"}).
-		AddRow(expectedTask.ID, expectedTask.Title, expectedTask.Description, expectedTask.Status, expectedTask.Assignee, expectedTask.CreatedAt, expectedTask.UpdatedAt)
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at"}).
+		AddRow(expectedTask.ID, expectedTask.Title, expectedTask.Description, expectedTask.Status, expectedTask.Assignee, expectedTask.Source, expectedTask.ExternalID, expectedTask.CreatedAt, expectedTask.UpdatedAt)
 
 	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = \\$1").
 		WithArgs(expectedTask.ID).
@@ -90,10 +94,10 @@ func TestGetAll_WithFilters(t *testing.T) {
 
 	// Mock select query
 	task := models.NewTask("Test", "Desc", "test@example.com", status)
-	rows := sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "created_at", "updated_at"}).
-		AddRow(task.ID, task.Title, task.Description, task.Status, task.Assignee, task.CreatedAt, task.UpdatedAt)
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at"}).
+		AddRow(task.ID, task.Title, task.Description, task.Status, task.Assignee, task.Source, task.ExternalID, task.CreatedAt, task.UpdatedAt)
 
-	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE status = \\$1 ORDER BY created_at DESC LIMIT \\$2 OFFSET \\$3").
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE status = \\$1 ORDER BY created_at DESC, id DESC LIMIT \\$2 OFFSET \\$3").
 		WithArgs(status, 10, 0).
 		WillReturnRows(rows)
 
@@ -112,7 +116,7 @@ func TestUpdate(t *testing.T) {
 	task := models.NewTask("Updated Task", "Updated Desc", "test@example.com", models.TaskStatusCompleted)
 
 	mock.ExpectExec("UPDATE tasks SET").
-		WithArgs(task.Title, task.Description, task.Status, task.Assignee, task.UpdatedAt, task.ID).
+		WithArgs(task.Title, task.Description, task.Status, task.Assignee, task.Source, task.ExternalID, task.UpdatedAt, task.ID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	err := repo.Update(context.Background(), task)
@@ -128,7 +132,7 @@ func TestUpdate_NotFound(t *testing.T) {
 	task := models.NewTask("Task", "Desc", "test@example.com", models.TaskStatusPending)
 
 	mock.ExpectExec("UPDATE tasks SET").
-		WithArgs(task.Title, task.Description, task.Status, task.Assignee, task.UpdatedAt, task.ID).
+		WithArgs(task.Title, task.Description, task.Status, task.Assignee, task.Source, task.ExternalID, task.UpdatedAt, task.ID).
 		WillReturnResult(sqlmock.NewResult(0, 0))
 
 	err := repo.Update(context.Background(), task)
@@ -230,11 +234,11 @@ func TestGetAll_NoFilters(t *testing.T) {
 	// Mock select query
 	task1 := models.NewTask("Task 1", "Desc 1", "test1@example.com", models.TaskStatusPending)
 	task2 := models.NewTask("Task 2", "Desc 2", "test2@example.com", models.TaskStatusCompleted)
-	rows := sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "created_at", "updated_at"}).
-		AddRow(task1.ID, task1.Title, task1.Description, task1.Status, task1.Assignee, task1.CreatedAt, task1.UpdatedAt).
-		AddRow(task2.ID, task2.Title, task2.Description, task2.Status, task2.Assignee, task2.CreatedAt, task2.UpdatedAt)
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at"}).
+		AddRow(task1.ID, task1.Title, task1.Description, task1.Status, task1.Assignee, task1.Source, task1.ExternalID, task1.CreatedAt, task1.UpdatedAt).
+		AddRow(task2.ID, task2.Title, task2.Description, task2.Status, task2.Assignee, task2.Source, task2.ExternalID, task2.CreatedAt, task2.UpdatedAt)
 
-	mock.ExpectQuery("SELECT (.+) FROM tasks ORDER BY created_at DESC LIMIT \\$1 OFFSET \\$2").
+	mock.ExpectQuery("SELECT (.+) FROM tasks ORDER BY created_at DESC, id DESC LIMIT \\$1 OFFSET \\$2").
 		WithArgs(10, 0).
 		WillReturnRows(rows)
 
@@ -252,9 +256,9 @@ func TestGetAll_WithAssigneeFilter(t *testing.T) {
 	repo := NewPostgresTaskRepository(db)
 	assignee := "test@example.com"
 	filter := &models.TaskFilter{
-		Assignee: &assignee,
-		Page:     1,
-		PageSize: 10,
+		Assignees: []string{assignee},
+		Page:      1,
+		PageSize:  10,
 	}
 
 	// Mock count query
@@ -264,10 +268,10 @@ func TestGetAll_WithAssigneeFilter(t *testing.T) {
 
 	// Mock select query
 	task := models.NewTask("Test", "Desc", assignee, models.TaskStatusPending)
-	rows := sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "created_at", "updated_at"}).
-		AddRow(task.ID, task.Title, task.Description, task.Status, task.Assignee, task.CreatedAt, task.UpdatedAt)
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at"}).
+		AddRow(task.ID, task.Title, task.Description, task.Status, task.Assignee, task.Source, task.ExternalID, task.CreatedAt, task.UpdatedAt)
 
-	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE assignee = \\$1 ORDER BY created_at DESC LIMIT \\$2 OFFSET \\$3").
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE assignee = \\$1 ORDER BY created_at DESC, id DESC LIMIT \\$2 OFFSET \\$3").
 		WithArgs(assignee, 10, 0).
 		WillReturnRows(rows)
 
@@ -278,6 +282,33 @@ func TestGetAll_WithAssigneeFilter(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestGetAll_WithSeveralAssignees(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	assignees := []string{"alice@example.com", "bob@example.com"}
+	filter := &models.TaskFilter{
+		Assignees: assignees,
+		Page:      1,
+		PageSize:  10,
+	}
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM tasks WHERE assignee = ANY\\(\\$1\\)").
+		WithArgs(pq.Array(assignees)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE assignee = ANY\\(\\$1\\) ORDER BY created_at DESC, id DESC LIMIT \\$2 OFFSET \\$3").
+		WithArgs(pq.Array(assignees), 10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at"}))
+
+	tasks, total, err := repo.GetAll(context.Background(), filter)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.Empty(t, tasks)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestGetAll_WithBothFilters(t *testing.T) {
 	db, mock := setupMockDB(t)
 	defer db.Close()
@@ -286,10 +317,10 @@ func TestGetAll_WithBothFilters(t *testing.T) {
 	status := models.TaskStatusCompleted
 	assignee := "test@example.com"
 	filter := &models.TaskFilter{
-		Status:   &status,
-		Assignee: &assignee,
-		Page:     2,
-		PageSize: 5,
+		Status:    &status,
+		Assignees: []string{assignee},
+		Page:      2,
+		PageSize:  5,
 	}
 
 	// Mock count query
@@ -298,9 +329,9 @@ func TestGetAll_WithBothFilters(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
 
 	// Mock select query
-	rows := sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "created_at", "updated_at"})
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at"})
 
-	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE status = \\$1 AND assignee = \\$2 ORDER BY created_at DESC LIMIT \\$3 OFFSET \\$4").
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE status = \\$1 AND assignee = \\$2 ORDER BY created_at DESC, id DESC LIMIT \\$3 OFFSET \\$4").
 		WithArgs(status, assignee, 5, 5).
 		WillReturnRows(rows)
 
@@ -344,7 +375,7 @@ func TestGetAll_QueryError(t *testing.T) {
 	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM tasks").
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
 
-	mock.ExpectQuery("SELECT (.+) FROM tasks ORDER BY created_at DESC LIMIT \\$1 OFFSET \\$2").
+	mock.ExpectQuery("SELECT (.+) FROM tasks ORDER BY created_at DESC, id DESC LIMIT \\$1 OFFSET \\$2").
 		WithArgs(10, 0).
 		WillReturnError(sql.ErrConnDone)
 
@@ -363,7 +394,7 @@ func TestCreate_Error(t *testing.T) {
 	task := models.NewTask("Test Task", "Description", "test@example.com", models.TaskStatusPending)
 
 	mock.ExpectExec("INSERT INTO tasks").
-		WithArgs(task.ID, task.Title, task.Description, task.Status, task.Assignee, task.CreatedAt, task.UpdatedAt).
+		WithArgs(task.ID, task.Title, task.Description, task.Status, task.Assignee, task.Source, task.ExternalID, task.CreatedAt, task.UpdatedAt).
 		WillReturnError(sql.ErrConnDone)
 
 	err := repo.Create(context.Background(), task)
@@ -395,7 +426,7 @@ func TestUpdate_Error(t *testing.T) {
 	task := models.NewTask("Task", "Desc", "test@example.com", models.TaskStatusPending)
 
 	mock.ExpectExec("UPDATE tasks SET").
-		WithArgs(task.Title, task.Description, task.Status, task.Assignee, task.UpdatedAt, task.ID).
+		WithArgs(task.Title, task.Description, task.Status, task.Assignee, task.Source, task.ExternalID, task.UpdatedAt, task.ID).
 		WillReturnError(sql.ErrConnDone)
 
 	err := repo.Update(context.Background(), task)
@@ -432,3 +463,1004 @@ func TestCount_Error(t *testing.T) {
 	assert.Equal(t, 0, count)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestPurgeCompletedBefore(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	cutoff := time.Now().Add(-30 * 24 * time.Hour)
+
+	mock.ExpectExec("DELETE FROM tasks WHERE status = \\$1 AND updated_at < \\$2").
+		WithArgs(models.TaskStatusCompleted, cutoff).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+
+	purged, err := repo.PurgeCompletedBefore(context.Background(), cutoff)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, purged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeCompletedBefore_Error(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	cutoff := time.Now()
+
+	mock.ExpectExec("DELETE FROM tasks WHERE status = \\$1 AND updated_at < \\$2").
+		WithArgs(models.TaskStatusCompleted, cutoff).
+		WillReturnError(sql.ErrConnDone)
+
+	purged, err := repo.PurgeCompletedBefore(context.Background(), cutoff)
+	assert.Error(t, err)
+	assert.Equal(t, 0, purged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransitionStale(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	now := time.Now()
+	cutoff := now.Add(-30 * 24 * time.Hour)
+
+	mock.ExpectExec("UPDATE tasks SET status = \\$1, updated_at = \\$2 WHERE status = \\$3 AND updated_at < \\$4").
+		WithArgs(models.TaskStatusCancelled, now, models.TaskStatusPending, cutoff).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	expired, err := repo.TransitionStale(context.Background(), models.TaskStatusPending, models.TaskStatusCancelled, cutoff, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, expired)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// cancelAfterContext reports cancellation through Err once it has been
+// checked more than `after` times. Its Done channel is nil so database/sql
+// and sqlmock never observe the cancellation themselves.
+type cancelAfterContext struct {
+	context.Context
+	after int
+	calls int
+}
+
+func (c *cancelAfterContext) Err() error {
+	c.calls++
+	if c.calls > c.after {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestGetAll_ContextCancelledDuringIteration(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	filter := &models.TaskFilter{
+		Page:     1,
+		PageSize: 10,
+	}
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM tasks").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at"})
+	for i := 0; i < 3; i++ {
+		task := models.NewTask("Task", "Desc", "test@example.com", models.TaskStatusPending)
+		rows.AddRow(task.ID, task.Title, task.Description, task.Status, task.Assignee, task.Source, task.ExternalID, task.CreatedAt, task.UpdatedAt)
+	}
+
+	mock.ExpectQuery("SELECT (.+) FROM tasks ORDER BY created_at DESC, id DESC LIMIT \\$1 OFFSET \\$2").
+		WithArgs(10, 0).
+		WillReturnRows(rows)
+
+	ctx := &cancelAfterContext{Context: context.Background(), after: 1}
+	tasks, total, err := repo.GetAll(ctx, filter)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, 0, total)
+	assert.Nil(t, tasks)
+	assert.Equal(t, 2, ctx.calls, "iteration should stop at the second row")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetChangedSince(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	since := time.Now().Add(-time.Hour)
+
+	task := models.NewTask("Test", "Desc", "test@example.com", models.TaskStatusPending)
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at"}).
+		AddRow(task.ID, task.Title, task.Description, task.Status, task.Assignee, task.Source, task.ExternalID, task.CreatedAt, task.UpdatedAt)
+
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE updated_at > \\$1 ORDER BY updated_at ASC, id ASC LIMIT \\$2").
+		WithArgs(since, 50).
+		WillReturnRows(rows)
+
+	tasks, err := repo.GetChangedSince(context.Background(), since, "", 50)
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	assert.Equal(t, task.ID, tasks[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetChangedSince_AfterID(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	since := time.Now().Add(-time.Hour)
+
+	task := models.NewTask("Test", "Desc", "test@example.com", models.TaskStatusPending)
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at"}).
+		AddRow(task.ID, task.Title, task.Description, task.Status, task.Assignee, task.Source, task.ExternalID, task.CreatedAt, task.UpdatedAt)
+
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE \\(updated_at, id\\) > \\(\\$1, \\$2\\) ORDER BY updated_at ASC, id ASC LIMIT \\$3").
+		WithArgs(since, "task-1", 50).
+		WillReturnRows(rows)
+
+	tasks, err := repo.GetChangedSince(context.Background(), since, "task-1", 50)
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetRecentlyUpdated(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+
+	task := models.NewTask("Test", "Desc", "test@example.com", models.TaskStatusPending)
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at"}).
+		AddRow(task.ID, task.Title, task.Description, task.Status, task.Assignee, task.Source, task.ExternalID, task.CreatedAt, task.UpdatedAt)
+
+	mock.ExpectQuery("SELECT (.+) FROM tasks ORDER BY updated_at DESC, id DESC LIMIT \\$1").
+		WithArgs(5).
+		WillReturnRows(rows)
+
+	tasks, err := repo.GetRecentlyUpdated(context.Background(), 5)
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	assert.Equal(t, task.ID, tasks[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"Serialization failure", &pq.Error{Code: "40001"}, true},
+		{"Deadlock", &pq.Error{Code: "40P01"}, true},
+		{"Wrapped serialization failure", fmt.Errorf("failed: %w", &pq.Error{Code: "40001"}), true},
+		{"Unique violation", &pq.Error{Code: "23505"}, false},
+		{"Generic error", sql.ErrConnDone, false},
+		{"Nil error", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsRetryable(tt.err))
+		})
+	}
+}
+
+func TestGetAll_WithHasDescriptionFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  bool
+		clause string
+	}{
+		{"With description", true, "WHERE description <> ''"},
+		{"Without description", false, "WHERE \\(description = '' OR description IS NULL\\)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupMockDB(t)
+			defer db.Close()
+
+			repo := NewPostgresTaskRepository(db)
+			hasDescription := tt.value
+			filter := &models.TaskFilter{
+				HasDescription: &hasDescription,
+				Page:           1,
+				PageSize:       10,
+			}
+
+			mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM tasks " + tt.clause).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+			mock.ExpectQuery("SELECT (.+) FROM tasks "+tt.clause+" ORDER BY created_at DESC, id DESC LIMIT \\$1 OFFSET \\$2").
+				WithArgs(10, 0).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at"}))
+
+			tasks, total, err := repo.GetAll(context.Background(), filter)
+			assert.NoError(t, err)
+			assert.Equal(t, 0, total)
+			assert.Len(t, tasks, 0)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPing(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+
+	mock.ExpectPing()
+	assert.NoError(t, repo.Ping(context.Background()))
+
+	mock.ExpectPing().WillReturnError(sql.ErrConnDone)
+	assert.Error(t, repo.Ping(context.Background()))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetUpdatedAt(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	updatedAt := time.Now()
+
+	mock.ExpectQuery("SELECT updated_at FROM tasks WHERE id = \\$1").
+		WithArgs("task-id").
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(updatedAt))
+	mock.ExpectQuery("SELECT updated_at FROM tasks WHERE id = \\$1").
+		WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+
+	got, err := repo.GetUpdatedAt(context.Background(), "task-id")
+	assert.NoError(t, err)
+	assert.Equal(t, updatedAt, got)
+
+	_, err = repo.GetUpdatedAt(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetByIDs(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	task := models.NewTask("Test", "Desc", "test@example.com", models.TaskStatusPending)
+	ids := []string{task.ID, "missing"}
+
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at"}).
+		AddRow(task.ID, task.Title, task.Description, task.Status, task.Assignee, task.Source, task.ExternalID, task.CreatedAt, task.UpdatedAt)
+
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE id = ANY\\(\\$1\\)").
+		WithArgs(pq.Array(ids)).
+		WillReturnRows(rows)
+
+	tasks, err := repo.GetByIDs(context.Background(), ids)
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	assert.Equal(t, task.ID, tasks[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOldestOpenByStatus(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	pendingSince := time.Now().Add(-2 * time.Hour)
+
+	rows := sqlmock.NewRows([]string{"status", "min"}).
+		AddRow(models.TaskStatusPending, pendingSince)
+
+	mock.ExpectQuery("SELECT status, MIN\\(created_at\\) FROM tasks WHERE status NOT IN \\(\\$1, \\$2\\) GROUP BY status").
+		WithArgs(models.TaskStatusCompleted, models.TaskStatusCancelled).
+		WillReturnRows(rows)
+
+	oldest, err := repo.OldestOpenByStatus(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, oldest, 1)
+	assert.Equal(t, pendingSince, oldest[models.TaskStatusPending])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCompletionsByAssignee(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	since := time.Now().Add(-7 * 24 * time.Hour)
+	until := time.Now()
+	assignee := "a@example.com"
+
+	mock.ExpectQuery("SELECT assignee, COUNT\\(\\*\\) FROM tasks WHERE status = \\$1 AND updated_at >= \\$2 AND updated_at < \\$3 GROUP BY assignee").
+		WithArgs(models.TaskStatusCompleted, since, until).
+		WillReturnRows(sqlmock.NewRows([]string{"assignee", "count"}).
+			AddRow("a@example.com", 2).
+			AddRow("b@example.com", 5))
+	mock.ExpectQuery("AND assignee = \\$4 GROUP BY assignee").
+		WithArgs(models.TaskStatusCompleted, since, until, assignee).
+		WillReturnRows(sqlmock.NewRows([]string{"assignee", "count"}).AddRow("a@example.com", 2))
+
+	completions, err := repo.CompletionsByAssignee(context.Background(), since, until, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"a@example.com": 2, "b@example.com": 5}, completions)
+
+	completions, err = repo.CompletionsByAssignee(context.Background(), since, until, &assignee)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"a@example.com": 2}, completions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTrend(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	from := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	mock.ExpectQuery("SELECT bucket, SUM\\(created\\), SUM\\(completed\\) FROM \\(.*date_trunc\\(\\$1, created_at\\) AS bucket.*UNION ALL.*GROUP BY bucket ORDER BY bucket").
+		WithArgs("day", from, to, models.TaskStatusCompleted).
+		WillReturnRows(sqlmock.NewRows([]string{"bucket", "created", "completed"}).
+			AddRow(from, 3, 1).
+			AddRow(from.AddDate(0, 0, 2), 0, 2))
+
+	buckets, err := repo.Trend(context.Background(), "day", from, to)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.TrendBucket{
+		{Start: from, Created: 3, Completed: 1},
+		{Start: from.AddDate(0, 0, 2), Completed: 2},
+	}, buckets)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateAssignee(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	task := models.NewTask("Task", "Desc", "new@example.com", models.TaskStatusPending)
+
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at", "assignee"}).
+		AddRow(task.ID, task.Title, task.Description, task.Status, task.Assignee, task.Source, task.ExternalID, task.CreatedAt, task.UpdatedAt, "old@example.com")
+
+	mock.ExpectQuery("UPDATE tasks t SET assignee = \\$2, updated_at = GREATEST\\(\\$3, t.created_at\\)").
+		WithArgs(task.ID, task.Assignee, task.UpdatedAt).
+		WillReturnRows(rows)
+
+	updated, previous, err := repo.UpdateAssignee(context.Background(), task.ID, task.Assignee, task.UpdatedAt)
+	assert.NoError(t, err)
+	assert.Equal(t, "new@example.com", updated.Assignee)
+	assert.Equal(t, "old@example.com", previous)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateAssignee_NotFound(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	now := time.Now()
+
+	mock.ExpectQuery("UPDATE tasks t SET assignee").
+		WithArgs("missing", "new@example.com", now).
+		WillReturnError(sql.ErrNoRows)
+
+	task, _, err := repo.UpdateAssignee(context.Background(), "missing", "new@example.com", now)
+	assert.Nil(t, task)
+	assert.Equal(t, ErrTaskNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReassignAll(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	task := models.NewTask("Task", "Desc", "new@example.com", models.TaskStatusPending)
+	status := models.TaskStatusPending
+
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at"}).
+		AddRow(task.ID, task.Title, task.Description, task.Status, task.Assignee, task.Source, task.ExternalID, task.CreatedAt, task.UpdatedAt)
+
+	mock.ExpectQuery("UPDATE tasks SET assignee = \\$1, updated_at = GREATEST\\(\\$2, created_at\\) WHERE assignee = \\$3 AND status = \\$4 RETURNING").
+		WithArgs("new@example.com", task.UpdatedAt, "old@example.com", status).
+		WillReturnRows(rows)
+
+	tasks, err := repo.ReassignAll(context.Background(), "old@example.com", "new@example.com", &status, task.UpdatedAt)
+	assert.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, task.ID, tasks[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReassignAll_Duplicate(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	now := time.Now()
+
+	mock.ExpectQuery("UPDATE tasks SET assignee = \\$1, updated_at = GREATEST\\(\\$2, created_at\\) WHERE assignee = \\$3 RETURNING").
+		WithArgs("new@example.com", now, "old@example.com").
+		WillReturnError(&pq.Error{Code: pqUniqueViolation, Constraint: "idx_tasks_assignee_title"})
+
+	tasks, err := repo.ReassignAll(context.Background(), "old@example.com", "new@example.com", nil, now)
+	assert.Nil(t, tasks)
+	assert.ErrorIs(t, err, ErrDuplicateTask)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAll_AscendingOrder(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	filter := &models.TaskFilter{Order: models.SortOrderAsc, Page: 1, PageSize: 10}
+
+	mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("ORDER BY created_at ASC").
+		WithArgs(10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at"}))
+
+	_, _, err := repo.GetAll(context.Background(), filter)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAll_SortCollation(t *testing.T) {
+	rows := []string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at"}
+
+	t.Run("Applies to title", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewPostgresTaskRepository(db, WithSortCollation("de_DE"))
+		filter := &models.TaskFilter{Sort: "title", Order: models.SortOrderAsc, Page: 1, PageSize: 10}
+
+		mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(`ORDER BY title COLLATE "de_DE" ASC, id ASC`).
+			WithArgs(10, 0).
+			WillReturnRows(sqlmock.NewRows(rows))
+
+		_, _, err := repo.GetAll(context.Background(), filter)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Ignores other fields", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewPostgresTaskRepository(db, WithSortCollation("de_DE"))
+		filter := &models.TaskFilter{Page: 1, PageSize: 10}
+
+		mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery("ORDER BY created_at DESC, id DESC").
+			WithArgs(10, 0).
+			WillReturnRows(sqlmock.NewRows(rows))
+
+		_, _, err := repo.GetAll(context.Background(), filter)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCollationExists(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM pg_collation WHERE collname = \\$1\\)").
+		WithArgs("de_DE").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	exists, err := CollationExists(context.Background(), db, "de_DE")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAll_TitlePrefix(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	prefix := "100%"
+	filter := &models.TaskFilter{TitlePrefix: &prefix, Page: 1, PageSize: 10}
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM tasks WHERE lower\\(title\\) LIKE lower\\(\\$1\\) \\|\\| '%'").
+		WithArgs(`100\%`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("SELECT id").
+		WithArgs(`100\%`, 10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at"}))
+
+	_, _, err := repo.GetAll(context.Background(), filter)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAll_Stale(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	assignee := "a@example.com"
+	filter := &models.TaskFilter{Assignees: []string{assignee}, Stale: true, StaleDays: 14, Page: 1, PageSize: 10}
+	terminal := pq.Array(models.TerminalStatuses())
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM tasks WHERE assignee = \\$1 AND updated_at < NOW\\(\\) - \\$2 \\* INTERVAL '1 day' AND status <> ALL\\(\\$3\\)").
+		WithArgs(assignee, 14, terminal).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("SELECT id").
+		WithArgs(assignee, 14, terminal, 10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at"}))
+
+	_, _, err := repo.GetAll(context.Background(), filter)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetIDs(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	status := models.TaskStatusPending
+	filter := &models.TaskFilter{Status: &status, Sort: "updated_at", Order: models.SortOrderAsc}
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM tasks WHERE status = \\$1").
+		WithArgs(status).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery("SELECT id FROM tasks WHERE status = \\$1 ORDER BY updated_at ASC, id ASC LIMIT \\$2").
+		WithArgs(status, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("id-1").AddRow("id-2"))
+
+	ids, total, err := repo.GetIDs(context.Background(), filter, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id-1", "id-2"}, ids)
+	assert.Equal(t, 3, total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAll_Search(t *testing.T) {
+	t.Run("Default fields", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewPostgresTaskRepository(db)
+		filter := &models.TaskFilter{Search: "50%_off", Page: 1, PageSize: 10}
+
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM tasks WHERE \\(title ILIKE \\$1 OR description ILIKE \\$1\\)").
+			WithArgs(`%50\%\_off%`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery("SELECT id").
+			WithArgs(`%50\%\_off%`, 10, 0).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at"}))
+
+		_, _, err := repo.GetAll(context.Background(), filter)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Configured fields", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewPostgresTaskRepository(db, WithSearchFields([]string{"assignee", "id; DROP TABLE tasks"}))
+		filter := &models.TaskFilter{Search: "jane", Page: 1, PageSize: 10}
+
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM tasks WHERE \\(assignee ILIKE \\$1\\)").
+			WithArgs("%jane%").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery("SELECT id").
+			WithArgs("%jane%", 10, 0).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at"}))
+
+		_, _, err := repo.GetAll(context.Background(), filter)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestValidateSearchFields(t *testing.T) {
+	assert.NoError(t, ValidateSearchFields([]string{"title", "assignee"}))
+
+	err := ValidateSearchFields([]string{"title", "password"})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	assert.Contains(t, err.Error(), "password")
+}
+
+func TestCreate_DuplicateTitle(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	task := models.NewTask("Task", "Desc", "test@example.com", models.TaskStatusPending)
+
+	mock.ExpectExec("INSERT INTO tasks").
+		WillReturnError(&pq.Error{Code: "23505", Constraint: assigneeTitleIndex})
+
+	err := repo.Create(context.Background(), task)
+	assert.Equal(t, ErrDuplicateTask, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreate_PrimaryKeyViolation(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	task := models.NewTask("Task", "Desc", "test@example.com", models.TaskStatusPending)
+
+	mock.ExpectExec("INSERT INTO tasks").
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "tasks_pkey"})
+
+	err := repo.Create(context.Background(), task)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrDuplicateTask)
+	assert.NotErrorIs(t, err, ErrDuplicateExternalID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEnsureUniqueTitlePerAssignee(t *testing.T) {
+	t.Run("Creates index", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewPostgresTaskRepository(db)
+
+		mock.ExpectQuery("SELECT EXISTS").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectExec("CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_assignee_title").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		applied, err := repo.EnsureUniqueTitlePerAssignee(context.Background())
+		assert.NoError(t, err)
+		assert.True(t, applied)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Skips when duplicates exist", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewPostgresTaskRepository(db)
+
+		mock.ExpectQuery("SELECT EXISTS").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		applied, err := repo.EnsureUniqueTitlePerAssignee(context.Background())
+		assert.NoError(t, err)
+		assert.False(t, applied)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDropUniqueTitlePerAssignee(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+
+	mock.ExpectExec("DROP INDEX IF EXISTS idx_tasks_assignee_title").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, repo.DropUniqueTitlePerAssignee(context.Background()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListCompletedBefore(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	cutoff := time.Now().Add(-24 * time.Hour)
+
+	mock.ExpectQuery("SELECT id, COUNT\\(\\*\\) OVER \\(\\) FROM tasks WHERE status = \\$1 AND updated_at < \\$2 (.+) LIMIT \\$3").
+		WithArgs(models.TaskStatusCompleted, cutoff, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "count"}).AddRow("a", 5).AddRow("b", 5))
+
+	ids, total, err := repo.ListCompletedBefore(context.Background(), cutoff, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, ids)
+	assert.Equal(t, 5, total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAll_SortByUpdatedAt(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	filter := &models.TaskFilter{Sort: "updated_at", Order: models.SortOrderDesc, Page: 1, PageSize: 10}
+
+	mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("ORDER BY updated_at DESC, id DESC").
+		WithArgs(10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at"}))
+
+	_, _, err := repo.GetAll(context.Background(), filter)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteIfUnmodified(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	updatedAt := time.Now()
+
+	mock.ExpectExec("DELETE FROM tasks WHERE id = \\$1 AND updated_at = \\$2").
+		WithArgs("test-id", updatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.DeleteIfUnmodified(context.Background(), "test-id", updatedAt))
+
+	mock.ExpectExec("DELETE FROM tasks WHERE id = \\$1 AND updated_at = \\$2").
+		WithArgs("test-id", updatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.Equal(t, ErrTaskModified, repo.DeleteIfUnmodified(context.Background(), "test-id", updatedAt))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreate_DuplicateExternalID(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	task := models.NewTask("Task", "Desc", "test@example.com", models.TaskStatusPending)
+
+	mock.ExpectExec("INSERT INTO tasks").
+		WillReturnError(&pq.Error{Code: "23505", Constraint: externalIDIndex})
+
+	err := repo.Create(context.Background(), task)
+	assert.Equal(t, ErrDuplicateExternalID, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAll_ExternalReference(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	source, externalID := "jira", "PROJ-1"
+	filter := &models.TaskFilter{Source: &source, ExternalID: &externalID, Page: 1, PageSize: 10}
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM tasks WHERE source = \\$1 AND external_id = \\$2").
+		WithArgs(source, externalID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("SELECT (.+) FROM tasks WHERE source = \\$1 AND external_id = \\$2 ORDER BY").
+		WithArgs(source, externalID, 10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at"}))
+
+	_, _, err := repo.GetAll(context.Background(), filter)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertByExternalID(t *testing.T) {
+	newTask := func() *models.Task {
+		task := models.NewTask("Task", "Desc", "test@example.com", models.TaskStatusPending)
+		source, externalID := "jira", "PROJ-1"
+		task.Source, task.ExternalID = &source, &externalID
+		return task
+	}
+	columns := []string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at", "inserted"}
+
+	for _, inserted := range []bool{true, false} {
+		t.Run(fmt.Sprintf("inserted=%t", inserted), func(t *testing.T) {
+			db, mock := setupMockDB(t)
+			defer db.Close()
+
+			repo := NewPostgresTaskRepository(db)
+			task := newTask()
+
+			mock.ExpectQuery("INSERT INTO tasks (.+) ON CONFLICT \\(source, external_id\\) DO UPDATE").
+				WithArgs(task.ID, task.Title, task.Description, task.Status, task.Assignee, task.Source, task.ExternalID, task.CreatedAt, task.UpdatedAt).
+				WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(task.ID, task.Title, task.Description, task.Status, task.Assignee, *task.Source, *task.ExternalID, task.CreatedAt, task.UpdatedAt, inserted))
+
+			stored, created, err := repo.UpsertByExternalID(context.Background(), task)
+			require.NoError(t, err)
+			assert.Equal(t, inserted, created)
+			assert.Equal(t, "jira", *stored.Source)
+			assert.Equal(t, "PROJ-1", *stored.ExternalID)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+
+	t.Run("Missing reference", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewPostgresTaskRepository(db)
+		task := models.NewTask("Task", "Desc", "", models.TaskStatusPending)
+
+		_, _, err := repo.UpsertByExternalID(context.Background(), task)
+		assert.ErrorIs(t, err, ErrInvalidInput)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCreateIfNotExists(t *testing.T) {
+	task := models.NewTask("Task", "Desc", "test@example.com", models.TaskStatusPending)
+	source, externalID := "jira", "PROJ-1"
+	task.Source, task.ExternalID = &source, &externalID
+	columns := []string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at"}
+	existing := time.Date(2023, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	t.Run("Created", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewPostgresTaskRepository(db)
+		mock.ExpectQuery("INSERT INTO tasks (.+) ON CONFLICT \\(source, external_id\\) DO NOTHING").
+			WithArgs(task.ID, task.Title, task.Description, task.Status, task.Assignee, task.Source, task.ExternalID, task.CreatedAt, task.UpdatedAt).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(task.ID, task.Title, task.Description, task.Status, task.Assignee, source, externalID, task.CreatedAt, task.UpdatedAt))
+
+		stored, created, err := repo.CreateIfNotExists(context.Background(), task)
+		require.NoError(t, err)
+		assert.True(t, created)
+		assert.Equal(t, task.ID, stored.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Existing", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewPostgresTaskRepository(db)
+		mock.ExpectQuery("ON CONFLICT \\(source, external_id\\) DO NOTHING").
+			WillReturnRows(sqlmock.NewRows(columns))
+		mock.ExpectQuery("SELECT (.+) FROM tasks WHERE source = \\$1 AND external_id = \\$2").
+			WithArgs(task.Source, task.ExternalID).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("existing-id", "Old", "", models.TaskStatusCompleted, "", source, externalID, existing, existing))
+
+		stored, created, err := repo.CreateIfNotExists(context.Background(), task)
+		require.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, "existing-id", stored.ID)
+		assert.Equal(t, "Old", stored.Title)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Existing deleted before lookup", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewPostgresTaskRepository(db)
+		mock.ExpectQuery("DO NOTHING").WillReturnRows(sqlmock.NewRows(columns))
+		mock.ExpectQuery("WHERE source = ").WillReturnRows(sqlmock.NewRows(columns))
+		mock.ExpectQuery("DO NOTHING").
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(task.ID, task.Title, task.Description, task.Status, task.Assignee, source, externalID, task.CreatedAt, task.UpdatedAt))
+
+		_, created, err := repo.CreateIfNotExists(context.Background(), task)
+		require.NoError(t, err)
+		assert.True(t, created)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Missing reference", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewPostgresTaskRepository(db)
+		_, _, err := repo.CreateIfNotExists(context.Background(), models.NewTask("Task", "", "", models.TaskStatusPending))
+		assert.ErrorIs(t, err, ErrInvalidInput)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCreateMany(t *testing.T) {
+	createdAt := time.Date(2023, 3, 1, 9, 30, 0, 0, time.UTC)
+	newTasks := func() []*models.Task {
+		first := models.NewTask("First", "", "", models.TaskStatusCompleted)
+		second := models.NewTask("Second", "", "", models.TaskStatusPending)
+		first.CreatedAt, first.UpdatedAt = createdAt, createdAt.Add(time.Hour)
+		second.CreatedAt, second.UpdatedAt = createdAt, createdAt
+		return []*models.Task{first, second}
+	}
+
+	t.Run("Inserts in one transaction", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewPostgresTaskRepository(db)
+		tasks := newTasks()
+
+		mock.ExpectBegin()
+		prep := mock.ExpectPrepare("INSERT INTO tasks")
+		for _, task := range tasks {
+			prep.ExpectExec().
+				WithArgs(task.ID, task.Title, task.Description, task.Status, task.Assignee, task.Source, task.ExternalID, task.CreatedAt, task.UpdatedAt).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+		}
+		mock.ExpectCommit()
+
+		err := repo.CreateMany(context.Background(), tasks)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Rolls back on duplicate", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewPostgresTaskRepository(db)
+		tasks := newTasks()
+
+		mock.ExpectBegin()
+		prep := mock.ExpectPrepare("INSERT INTO tasks")
+		prep.ExpectExec().WillReturnResult(sqlmock.NewResult(1, 1))
+		prep.ExpectExec().WillReturnError(&pq.Error{Code: "23505", Constraint: assigneeTitleIndex})
+		mock.ExpectRollback()
+
+		err := repo.CreateMany(context.Background(), tasks)
+		assert.Equal(t, ErrDuplicateTask, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Rolls back on error", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewPostgresTaskRepository(db)
+
+		mock.ExpectBegin()
+		prep := mock.ExpectPrepare("INSERT INTO tasks")
+		prep.ExpectExec().WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+
+		err := repo.CreateMany(context.Background(), newTasks())
+		assert.ErrorContains(t, err, "failed to create task")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetBoard(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	assignee := "alice@example.com"
+	filter := &models.TaskFilter{Assignees: []string{assignee}, Sort: "created_at", Order: models.SortOrderDesc}
+	pages := map[models.TaskStatus]models.BoardPage{
+		models.TaskStatusPending: {Offset: 0, Limit: 2},
+	}
+
+	mock.ExpectQuery("SELECT status, COUNT\\(\\*\\) FROM tasks WHERE assignee = \\$1 GROUP BY status").
+		WithArgs(assignee).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "count"}).
+			AddRow(models.TaskStatusPending, 5).
+			AddRow(models.TaskStatusCompleted, 3))
+
+	first := models.NewTask("First", "", assignee, models.TaskStatusPending)
+	second := models.NewTask("Second", "", assignee, models.TaskStatusPending)
+	mock.ExpectQuery("ROW_NUMBER\\(\\) OVER \\(PARTITION BY status ORDER BY created_at DESC, id DESC\\)").
+		WithArgs(assignee, pq.Array([]string{"pending"}), pq.Array([]int64{0}), pq.Array([]int64{2})).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "status", "assignee", "source", "external_id", "created_at", "updated_at"}).
+			AddRow(first.ID, first.Title, first.Description, first.Status, first.Assignee, first.Source, first.ExternalID, first.CreatedAt, first.UpdatedAt).
+			AddRow(second.ID, second.Title, second.Description, second.Status, second.Assignee, second.Source, second.ExternalID, second.CreatedAt, second.UpdatedAt))
+
+	columns, err := repo.GetBoard(context.Background(), filter, pages)
+	require.NoError(t, err)
+	require.Len(t, columns, 1)
+	pending := columns[models.TaskStatusPending]
+	assert.Equal(t, 5, pending.Total)
+	assert.Equal(t, 2, pending.Limit)
+	require.Len(t, pending.Tasks, 2)
+	assert.Equal(t, first.ID, pending.Tasks[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+)
+
+// WithSlowQueryThreshold logs every repository operation that takes longer
+// than threshold. Zero disables slow query logging.
+func WithSlowQueryThreshold(threshold time.Duration) Option {
+	return func(r *PostgresTaskRepository) {
+		r.slowQueryThreshold = threshold
+	}
+}
+
+// observe logs op at warn level when it ran for longer than the slow query
+// threshold. It is meant to be deferred at the top of each operation, so the
+// attributes are evaluated when the operation starts:
+//
+//	defer r.observe("GetByID", time.Now(), slog.String("task_id", id))
+//
+// Only IDs and filter values are passed as attributes, never task contents.
+func (r *PostgresTaskRepository) observe(op string, start time.Time, attrs ...slog.Attr) {
+	logSlowQuery(r.logger, r.slowQueryThreshold, op, start, attrs...)
+}
+
+// WithTemplateSlowQueryThreshold logs every template repository operation
+// that takes longer than threshold. Zero disables slow query logging.
+func WithTemplateSlowQueryThreshold(threshold time.Duration) TemplateOption {
+	return func(r *PostgresTemplateRepository) {
+		r.slowQueryThreshold = threshold
+	}
+}
+
+// observe logs a slow template operation, like PostgresTaskRepository.observe
+func (r *PostgresTemplateRepository) observe(op string, start time.Time, attrs ...slog.Attr) {
+	logSlowQuery(r.logger, r.slowQueryThreshold, op, start, attrs...)
+}
+
+// logSlowQuery logs op to logger, or the default logger when nil, if it ran
+// for at least threshold
+func logSlowQuery(logger *slog.Logger, threshold time.Duration, op string, start time.Time, attrs ...slog.Attr) {
+	if threshold <= 0 {
+		return
+	}
+	elapsed := time.Since(start)
+	if elapsed < threshold {
+		return
+	}
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+	args := make([]any, 0, len(attrs)+2)
+	args = append(args, slog.String("op", op), slog.Duration("duration", elapsed))
+	for _, attr := range attrs {
+		args = append(args, attr)
+	}
+	logger.Warn("slow query", args...)
+}
+
+// filterAttrs summarizes a list filter for slow query logs. The free-text
+// search term, title prefix and assignees are reported only as present or
+// absent, since they may contain personal data.
+func filterAttrs(filter *models.TaskFilter) slog.Attr {
+	attrs := []any{
+		slog.Int("page", filter.Page),
+		slog.Int("page_size", filter.PageSize),
+	}
+	if filter.Status != nil {
+		attrs = append(attrs, slog.String("status", string(*filter.Status)))
+	}
+	if filter.Source != nil {
+		attrs = append(attrs, slog.String("source", *filter.Source))
+	}
+	if filter.ExternalID != nil {
+		attrs = append(attrs, slog.String("external_id", *filter.ExternalID))
+	}
+	if filter.HasDescription != nil {
+		attrs = append(attrs, slog.Bool("has_description", *filter.HasDescription))
+	}
+	if len(filter.Assignees) > 0 {
+		attrs = append(attrs, slog.Bool("assignee", true))
+	}
+	if filter.Search != "" {
+		attrs = append(attrs, slog.Bool("search", true))
+	}
+	if filter.TitlePrefix != nil {
+		attrs = append(attrs, slog.Bool("title_prefix", true))
+	}
+	if filter.StaleDays > 0 {
+		attrs = append(attrs, slog.Int("stale_days", filter.StaleDays))
+	}
+	if filter.Sort != "" {
+		attrs = append(attrs, slog.String("sort", filter.Sort))
+	}
+	if filter.Order != "" {
+		attrs = append(attrs, slog.String("order", string(filter.Order)))
+	}
+	return slog.Group("filter", attrs...)
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlowQueryLogging(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	var buf bytes.Buffer
+	repo := NewPostgresTaskRepository(db, WithSlowQueryThreshold(20*time.Millisecond))
+	repo.logger = slog.New(slog.NewTextHandler(&buf, nil))
+
+	mock.ExpectExec("DELETE FROM tasks WHERE id").
+		WithArgs("fast-id").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM tasks WHERE id").
+		WithArgs("slow-id").
+		WillDelayFor(30 * time.Millisecond).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.Delete(context.Background(), "fast-id"))
+	assert.Empty(t, buf.String())
+
+	require.NoError(t, repo.Delete(context.Background(), "slow-id"))
+	assert.Contains(t, buf.String(), "slow query")
+	assert.Contains(t, buf.String(), "op=Delete")
+	assert.Contains(t, buf.String(), "task_id=slow-id")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSlowQueryLogging_Disabled(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	var buf bytes.Buffer
+	repo := NewPostgresTaskRepository(db)
+	repo.logger = slog.New(slog.NewTextHandler(&buf, nil))
+
+	mock.ExpectExec("DELETE FROM tasks WHERE id").
+		WithArgs("slow-id").
+		WillDelayFor(10 * time.Millisecond).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.Delete(context.Background(), "slow-id"))
+	assert.Empty(t, buf.String())
+}
+
+func TestSlowQueryLogging_Templates(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	var buf bytes.Buffer
+	repo := NewPostgresTemplateRepository(db, WithTemplateSlowQueryThreshold(20*time.Millisecond))
+	repo.logger = slog.New(slog.NewTextHandler(&buf, nil))
+
+	mock.ExpectExec("DELETE FROM task_templates WHERE id").
+		WithArgs("slow-id").
+		WillDelayFor(30 * time.Millisecond).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.Delete(context.Background(), "slow-id"))
+	assert.Contains(t, buf.String(), "op=DeleteTemplate")
+	assert.Contains(t, buf.String(), "template_id=slow-id")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilterAttrs(t *testing.T) {
+	status := models.TaskStatusPending
+	assignee := "john.doe@example.com"
+	filter := &models.TaskFilter{
+		Status:    &status,
+		Assignees: []string{assignee},
+		Search:    "salary review",
+		Page:      2,
+		PageSize:  20,
+	}
+
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("slow query", filterAttrs(filter))
+	out := buf.String()
+
+	assert.Contains(t, out, "filter.status=pending")
+	assert.Contains(t, out, "filter.page=2")
+	assert.Contains(t, out, "filter.page_size=20")
+	assert.Contains(t, out, "filter.assignee=true")
+	assert.Contains(t, out, "filter.search=true")
+	assert.NotContains(t, out, assignee)
+	assert.NotContains(t, out, "salary")
+}
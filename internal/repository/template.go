@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/lib/pq"
+)
+
+var (
+	ErrTemplateNotFound  = errors.New("template not found")
+	ErrDuplicateTemplate = errors.New("a template with this name already exists")
+)
+
+// PostgresTemplateRepository implements TemplateRepository for PostgreSQL
+type PostgresTemplateRepository struct {
+	db *sql.DB
+
+	slowQueryThreshold time.Duration
+	logger             *slog.Logger
+}
+
+// TemplateOption configures a PostgresTemplateRepository
+type TemplateOption func(*PostgresTemplateRepository)
+
+// NewPostgresTemplateRepository creates a new PostgreSQL template repository
+func NewPostgresTemplateRepository(db *sql.DB, opts ...TemplateOption) *PostgresTemplateRepository {
+	r := &PostgresTemplateRepository{db: db}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// templateWriteError maps a unique name violation onto ErrDuplicateTemplate
+// and wraps anything else
+func templateWriteError(action string, err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation {
+		return ErrDuplicateTemplate
+	}
+	return fmt.Errorf("failed to %s template: %w", action, err)
+}
+
+// Create inserts a new template into the database
+func (r *PostgresTemplateRepository) Create(ctx context.Context, template *models.TaskTemplate) error {
+	defer r.observe("CreateTemplate", time.Now(), slog.String("template_id", template.ID))
+	query := `
+		INSERT INTO task_templates (id, name, title, description, status, assignee, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		template.ID, template.Name, template.Title, template.Description,
+		template.Status, template.Assignee, template.CreatedAt, template.UpdatedAt,
+	)
+	if err != nil {
+		return templateWriteError("create", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a template by its ID
+func (r *PostgresTemplateRepository) GetByID(ctx context.Context, id string) (*models.TaskTemplate, error) {
+	defer r.observe("GetTemplate", time.Now(), slog.String("template_id", id))
+	query := `
+		SELECT id, name, title, description, status, assignee, created_at, updated_at
+		FROM task_templates
+		WHERE id = $1
+	`
+	template := &models.TaskTemplate{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&template.ID, &template.Name, &template.Title, &template.Description,
+		&template.Status, &template.Assignee, &template.CreatedAt, &template.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrTemplateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+	return template, nil
+}
+
+// List retrieves every template ordered by name
+func (r *PostgresTemplateRepository) List(ctx context.Context) ([]models.TaskTemplate, error) {
+	defer r.observe("ListTemplates", time.Now())
+	query := `
+		SELECT id, name, title, description, status, assignee, created_at, updated_at
+		FROM task_templates
+		ORDER BY name
+	`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []models.TaskTemplate{}
+	for rows.Next() {
+		var template models.TaskTemplate
+		err := rows.Scan(
+			&template.ID, &template.Name, &template.Title, &template.Description,
+			&template.Status, &template.Assignee, &template.CreatedAt, &template.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan template: %w", err)
+		}
+		templates = append(templates, template)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// Update updates an existing template
+func (r *PostgresTemplateRepository) Update(ctx context.Context, template *models.TaskTemplate) error {
+	defer r.observe("UpdateTemplate", time.Now(), slog.String("template_id", template.ID))
+	query := `
+		UPDATE task_templates
+		SET name = $1, title = $2, description = $3, status = $4, assignee = $5, updated_at = $6
+		WHERE id = $7
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		template.Name, template.Title, template.Description, template.Status,
+		template.Assignee, template.UpdatedAt, template.ID,
+	)
+	if err != nil {
+		return templateWriteError("update", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrTemplateNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes a template by its ID
+func (r *PostgresTemplateRepository) Delete(ctx context.Context, id string) error {
+	defer r.observe("DeleteTemplate", time.Now(), slog.String("template_id", id))
+	result, err := r.db.ExecContext(ctx, `DELETE FROM task_templates WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrTemplateNotFound
+	}
+
+	return nil
+}
+
+// InitSchema creates the task_templates table if it does not exist
+func (r *PostgresTemplateRepository) InitSchema(ctx context.Context) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS task_templates (
+			id VARCHAR(36) PRIMARY KEY,
+			name VARCHAR(100) NOT NULL UNIQUE,
+			title VARCHAR(255) NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			status VARCHAR(50) NOT NULL DEFAULT '',
+			assignee VARCHAR(255) NOT NULL DEFAULT '',
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		);
+	`
+	_, err := r.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to initialize template schema: %w", err)
+	}
+	return nil
+}
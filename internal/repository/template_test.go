@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var templateColumns = []string{"id", "name", "title", "description", "status", "assignee", "created_at", "updated_at"}
+
+func TestTemplateCreate(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTemplateRepository(db)
+	template := models.NewTaskTemplate("weekly-report", "Weekly report {{date}}", "", "john.doe@example.com", "")
+
+	mock.ExpectExec("INSERT INTO task_templates").
+		WithArgs(template.ID, template.Name, template.Title, template.Description,
+			template.Status, template.Assignee, template.CreatedAt, template.UpdatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO task_templates").
+		WillReturnError(&pq.Error{Code: pqUniqueViolation, Constraint: "task_templates_name_key"})
+
+	assert.NoError(t, repo.Create(context.Background(), template))
+	assert.ErrorIs(t, repo.Create(context.Background(), template), ErrDuplicateTemplate)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTemplateGetByID(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTemplateRepository(db)
+	template := models.NewTaskTemplate("weekly-report", "Weekly report {{date}}", "", "", models.TaskStatusPending)
+
+	mock.ExpectQuery("SELECT (.+) FROM task_templates WHERE id = \\$1").
+		WithArgs(template.ID).
+		WillReturnRows(sqlmock.NewRows(templateColumns).AddRow(
+			template.ID, template.Name, template.Title, template.Description,
+			template.Status, template.Assignee, template.CreatedAt, template.UpdatedAt))
+	mock.ExpectQuery("SELECT (.+) FROM task_templates WHERE id = \\$1").
+		WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+
+	result, err := repo.GetByID(context.Background(), template.ID)
+	require.NoError(t, err)
+	assert.Equal(t, template.Name, result.Name)
+	assert.Equal(t, template.Status, result.Status)
+
+	_, err = repo.GetByID(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrTemplateNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTemplateList(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTemplateRepository(db)
+	a := models.NewTaskTemplate("a", "Task A", "", "", "")
+	b := models.NewTaskTemplate("b", "Task B", "", "", "")
+
+	mock.ExpectQuery("SELECT (.+) FROM task_templates ORDER BY name").
+		WillReturnRows(sqlmock.NewRows(templateColumns).
+			AddRow(a.ID, a.Name, a.Title, a.Description, a.Status, a.Assignee, a.CreatedAt, a.UpdatedAt).
+			AddRow(b.ID, b.Name, b.Title, b.Description, b.Status, b.Assignee, b.CreatedAt, b.UpdatedAt))
+
+	templates, err := repo.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+	assert.Equal(t, "a", templates[0].Name)
+	assert.Equal(t, "b", templates[1].Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTemplateUpdate_NotFound(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTemplateRepository(db)
+	template := models.NewTaskTemplate("weekly-report", "Weekly report", "", "", "")
+
+	mock.ExpectExec("UPDATE task_templates").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.Update(context.Background(), template)
+	assert.ErrorIs(t, err, ErrTemplateNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTemplateDelete(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewPostgresTemplateRepository(db)
+
+	mock.ExpectExec("DELETE FROM task_templates WHERE id = \\$1").
+		WithArgs("template-id").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM task_templates WHERE id = \\$1").
+		WithArgs("missing").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, repo.Delete(context.Background(), "template-id"))
+	assert.ErrorIs(t, repo.Delete(context.Background(), "missing"), ErrTemplateNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
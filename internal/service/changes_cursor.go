@@ -0,0 +1,40 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Ali-Gorgani/task-manager/internal/repository"
+)
+
+// changesCursor is the position carried inside an opaque changes cursor: the
+// (updated_at, id) of the last task returned. An empty ID means "after every
+// task updated at UpdatedAt".
+type changesCursor struct {
+	UpdatedAt time.Time `json:"t"`
+	ID        string    `json:"id,omitempty"`
+}
+
+// encodeChangesCursor builds the opaque cursor for a position. It is not
+// signed: it only names a point in the change feed, which any caller may
+// read from anyway.
+func encodeChangesCursor(cursor changesCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeChangesCursor parses a cursor produced by encodeChangesCursor
+func decodeChangesCursor(token string) (*changesCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", repository.ErrInvalidInput)
+	}
+
+	var cursor changesCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.UpdatedAt.IsZero() {
+		return nil, fmt.Errorf("%w: malformed cursor", repository.ErrInvalidInput)
+	}
+	return &cursor, nil
+}
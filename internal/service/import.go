@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/Ali-Gorgani/task-manager/internal/repository"
+)
+
+const (
+	// maxImportTasks caps an atomic import, which is held in one transaction
+	maxImportTasks = 100
+	// maxBatchedImportTasks caps an import split into batches
+	maxBatchedImportTasks = 1000
+)
+
+// ImportTasks creates tasks migrated from another system, keeping their
+// original created_at and updated_at. updated_at defaults to created_at.
+//
+// By default every task is validated before any is stored, and they are
+// stored atomically, so a failed import leaves nothing behind. With a batch
+// size the tasks are imported in batches instead, each committed in its own
+// transaction: a batch with an invalid task or a failing insert is skipped
+// and reported, and the other batches are still stored. Batches committed
+// before a lost connection stay committed, so the failed ranges can be
+// resubmitted.
+func (s *TaskService) ImportTasks(ctx context.Context, req *models.ImportTasksRequest) (*models.ImportTasksResponse, error) {
+	if len(req.Tasks) == 0 {
+		return nil, fmt.Errorf("%w: no tasks to import", repository.ErrInvalidInput)
+	}
+	if req.BatchSize > 0 {
+		return s.importInBatches(ctx, req)
+	}
+	if len(req.Tasks) > maxImportTasks {
+		return nil, fmt.Errorf("%w: at most %d tasks may be imported at once", repository.ErrInvalidInput, maxImportTasks)
+	}
+
+	latest := time.Now().Add(s.importClockSkew)
+	tasks := make([]*models.Task, len(req.Tasks))
+	for i := range req.Tasks {
+		task, err := s.importedTask(&req.Tasks[i], latest)
+		if err != nil {
+			return nil, fmt.Errorf("tasks[%d]: %w", i, err)
+		}
+		tasks[i] = task
+	}
+
+	err := withRetry(ctx, func() error {
+		return s.repo.CreateMany(ctx, tasks)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to import tasks: %w", err)
+	}
+
+	if s.cache != nil {
+		_ = s.cache.InvalidateTaskList(ctx)
+		s.invalidateTaskCount(ctx)
+	}
+
+	imported := make([]models.Task, len(tasks))
+	for i, task := range tasks {
+		imported[i] = *task
+	}
+	return &models.ImportTasksResponse{Imported: len(imported), Tasks: imported}, nil
+}
+
+// importInBatches imports req.Tasks in batches of req.BatchSize, each in its
+// own transaction, and reports the outcome of every batch
+func (s *TaskService) importInBatches(ctx context.Context, req *models.ImportTasksRequest) (*models.ImportTasksResponse, error) {
+	if len(req.Tasks) > maxBatchedImportTasks {
+		return nil, fmt.Errorf("%w: at most %d tasks may be imported at once", repository.ErrInvalidInput, maxBatchedImportTasks)
+	}
+
+	latest := time.Now().Add(s.importClockSkew)
+	response := &models.ImportTasksResponse{Tasks: []models.Task{}}
+	for from := 0; from < len(req.Tasks); from += req.BatchSize {
+		to := min(from+req.BatchSize, len(req.Tasks))
+		result := models.ImportBatchResult{From: from, To: to - 1}
+
+		batch := make([]*models.Task, 0, to-from)
+		for i := from; i < to; i++ {
+			task, err := s.importedTask(&req.Tasks[i], latest)
+			if err != nil {
+				result.Error = fmt.Sprintf("tasks[%d]: %v", i, err)
+				break
+			}
+			batch = append(batch, task)
+		}
+
+		if result.Error == "" {
+			err := withRetry(ctx, func() error {
+				return s.repo.CreateMany(ctx, batch)
+			})
+			if err != nil {
+				result.Error = err.Error()
+			}
+		}
+
+		if result.Error == "" {
+			result.Imported = len(batch)
+			for _, task := range batch {
+				response.Tasks = append(response.Tasks, *task)
+			}
+		}
+		response.Batches = append(response.Batches, result)
+	}
+	response.Imported = len(response.Tasks)
+	response.Failed = len(req.Tasks) - response.Imported
+
+	if s.cache != nil && response.Imported > 0 {
+		_ = s.cache.InvalidateTaskList(ctx)
+		s.invalidateTaskCount(ctx)
+	}
+
+	return response, nil
+}
+
+// importedTask validates one imported task and builds it with its original
+// timestamps. Nothing later than latest is accepted. Every error it returns
+// is an invalid input error.
+func (s *TaskService) importedTask(in *models.ImportTaskRequest, latest time.Time) (*models.Task, error) {
+	task, err := s.newTask(&in.CreateTaskRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedAt := in.CreatedAt
+	if in.UpdatedAt != nil {
+		updatedAt = *in.UpdatedAt
+	}
+	switch {
+	case in.CreatedAt.IsZero():
+		return nil, fmt.Errorf("%w: created_at is required", repository.ErrInvalidInput)
+	case in.CreatedAt.After(latest) || updatedAt.After(latest):
+		return nil, fmt.Errorf("%w: timestamps may not be in the future", repository.ErrInvalidInput)
+	case updatedAt.Before(in.CreatedAt):
+		return nil, errUpdatedBeforeCreated
+	}
+	task.CreatedAt, task.UpdatedAt = in.CreatedAt, updatedAt
+	return task, nil
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/Ali-Gorgani/task-manager/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportTasks(t *testing.T) {
+	createdAt := time.Date(2023, 3, 1, 9, 30, 0, 0, time.UTC)
+	updatedAt := createdAt.Add(48 * time.Hour)
+
+	t.Run("Keeps timestamps", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(tasks []*models.Task) bool {
+			return len(tasks) == 2 &&
+				tasks[0].CreatedAt.Equal(createdAt) && tasks[0].UpdatedAt.Equal(updatedAt) &&
+				tasks[1].CreatedAt.Equal(createdAt) && tasks[1].UpdatedAt.Equal(createdAt)
+		})).Return(nil)
+
+		resp, err := service.ImportTasks(context.Background(), &models.ImportTasksRequest{
+			Tasks: []models.ImportTaskRequest{
+				{CreateTaskRequest: models.CreateTaskRequest{Title: "Old", Status: models.TaskStatusCompleted}, CreatedAt: createdAt, UpdatedAt: &updatedAt},
+				{CreateTaskRequest: models.CreateTaskRequest{Title: "Older"}, CreatedAt: createdAt},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, resp.Imported)
+		assert.Equal(t, "Old", resp.Tasks[0].Title)
+		assert.Equal(t, models.TaskStatusPending, resp.Tasks[1].Status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Rejects future timestamps", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil, WithImportClockSkew(time.Minute))
+
+		future := time.Now().Add(time.Hour)
+		_, err := service.ImportTasks(context.Background(), &models.ImportTasksRequest{
+			Tasks: []models.ImportTaskRequest{
+				{CreateTaskRequest: models.CreateTaskRequest{Title: "Fine"}, CreatedAt: createdAt},
+				{CreateTaskRequest: models.CreateTaskRequest{Title: "Ahead"}, CreatedAt: createdAt, UpdatedAt: &future},
+			},
+		})
+		assert.ErrorIs(t, err, repository.ErrInvalidInput)
+		assert.ErrorContains(t, err, "tasks[1]")
+		mockRepo.AssertNotCalled(t, "CreateMany", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Allows clock skew", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil, WithImportClockSkew(time.Minute))
+
+		mockRepo.On("CreateMany", mock.Anything, mock.Anything).Return(nil)
+
+		_, err := service.ImportTasks(context.Background(), &models.ImportTasksRequest{
+			Tasks: []models.ImportTaskRequest{
+				{CreateTaskRequest: models.CreateTaskRequest{Title: "Slightly ahead"}, CreatedAt: time.Now().Add(30 * time.Second)},
+			},
+		})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Rejects updated_at before created_at", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		earlier := createdAt.Add(-time.Hour)
+		_, err := service.ImportTasks(context.Background(), &models.ImportTasksRequest{
+			Tasks: []models.ImportTaskRequest{
+				{CreateTaskRequest: models.CreateTaskRequest{Title: "Backwards"}, CreatedAt: createdAt, UpdatedAt: &earlier},
+			},
+		})
+		assert.ErrorIs(t, err, repository.ErrInvalidInput)
+		assert.ErrorIs(t, err, errUpdatedBeforeCreated)
+		mockRepo.AssertNotCalled(t, "CreateMany", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Rejects inverted timestamps in a batch", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		earlier := createdAt.Add(-time.Nanosecond)
+		mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(tasks []*models.Task) bool {
+			return len(tasks) == 1 && tasks[0].Title == "Fine"
+		})).Return(nil).Once()
+
+		resp, err := service.ImportTasks(context.Background(), &models.ImportTasksRequest{
+			BatchSize: 1,
+			Tasks: []models.ImportTaskRequest{
+				{CreateTaskRequest: models.CreateTaskRequest{Title: "Fine"}, CreatedAt: createdAt},
+				{CreateTaskRequest: models.CreateTaskRequest{Title: "Backwards"}, CreatedAt: createdAt, UpdatedAt: &earlier},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.Failed)
+		assert.Equal(t, "tasks[1]: invalid input: updated_at is before created_at", resp.Batches[1].Error)
+		assert.Equal(t, createdAt, resp.Tasks[0].UpdatedAt)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Rejects invalid task", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		_, err := service.ImportTasks(context.Background(), &models.ImportTasksRequest{
+			Tasks: []models.ImportTaskRequest{
+				{CreateTaskRequest: models.CreateTaskRequest{Title: ""}, CreatedAt: createdAt},
+			},
+		})
+		assert.ErrorIs(t, err, repository.ErrInvalidInput)
+		assert.ErrorContains(t, err, "tasks[0]: invalid input: title is required")
+	})
+
+	t.Run("Rejects oversized batch", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		tasks := make([]models.ImportTaskRequest, maxImportTasks+1)
+		for i := range tasks {
+			tasks[i] = models.ImportTaskRequest{CreateTaskRequest: models.CreateTaskRequest{Title: "Bulk"}, CreatedAt: createdAt}
+		}
+		_, err := service.ImportTasks(context.Background(), &models.ImportTasksRequest{Tasks: tasks})
+		assert.ErrorIs(t, err, repository.ErrInvalidInput)
+	})
+}
+
+func TestImportTasks_Batches(t *testing.T) {
+	createdAt := time.Date(2023, 3, 1, 9, 30, 0, 0, time.UTC)
+	task := func(title string) models.ImportTaskRequest {
+		return models.ImportTaskRequest{CreateTaskRequest: models.CreateTaskRequest{Title: title}, CreatedAt: createdAt}
+	}
+
+	t.Run("Reports failed batches", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		// The invalid second batch never reaches the repository; the third
+		// fails there
+		mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(tasks []*models.Task) bool {
+			return len(tasks) == 2 && tasks[0].Title == "a"
+		})).Return(nil).Once()
+		mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(tasks []*models.Task) bool {
+			return len(tasks) == 1 && tasks[0].Title == "e"
+		})).Return(repository.ErrDuplicateTask).Once()
+
+		resp, err := service.ImportTasks(context.Background(), &models.ImportTasksRequest{
+			BatchSize: 2,
+			Tasks:     []models.ImportTaskRequest{task("a"), task("b"), task("c"), task(""), task("e")},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, resp.Imported)
+		assert.Equal(t, 3, resp.Failed)
+		require.Len(t, resp.Tasks, 2)
+		assert.Equal(t, "b", resp.Tasks[1].Title)
+		assert.Equal(t, []models.ImportBatchResult{
+			{From: 0, To: 1, Imported: 2},
+			{From: 2, To: 3, Error: "tasks[3]: invalid input: title is required"},
+			{From: 4, To: 4, Error: repository.ErrDuplicateTask.Error()},
+		}, resp.Batches)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Earlier batches stay committed after a connection error", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(tasks []*models.Task) bool {
+			return tasks[0].Title == "a"
+		})).Return(nil).Once()
+		mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(tasks []*models.Task) bool {
+			return tasks[0].Title == "b"
+		})).Return(errors.New("connection reset")).Once()
+
+		resp, err := service.ImportTasks(context.Background(), &models.ImportTasksRequest{
+			BatchSize: 1,
+			Tasks:     []models.ImportTaskRequest{task("a"), task("b")},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.Imported)
+		assert.Equal(t, []models.ImportBatchResult{
+			{From: 0, To: 0, Imported: 1},
+			{From: 1, To: 1, Error: "connection reset"},
+		}, resp.Batches)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Allows larger imports", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		tasks := make([]models.ImportTaskRequest, maxImportTasks+1)
+		for i := range tasks {
+			tasks[i] = task("Bulk")
+		}
+		mockRepo.On("CreateMany", mock.Anything, mock.Anything).Return(nil).Twice()
+
+		resp, err := service.ImportTasks(context.Background(), &models.ImportTasksRequest{BatchSize: 100, Tasks: tasks})
+		require.NoError(t, err)
+		assert.Equal(t, len(tasks), resp.Imported)
+		assert.Len(t, resp.Batches, 2)
+	})
+}
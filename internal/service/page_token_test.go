@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageToken_RoundTrip(t *testing.T) {
+	status := models.TaskStatusPending
+	filter := &models.TaskFilter{Status: &status, Page: 1, PageSize: 20}
+
+	key := []byte("secret")
+	token := encodePageToken(key, filter, 2)
+	state, err := decodePageToken(key, token, filter)
+	require.NoError(t, err)
+	assert.Equal(t, 2, state.Page)
+	assert.Equal(t, 20, state.PageSize)
+}
+
+func TestPageToken_Invalid(t *testing.T) {
+	status := models.TaskStatusPending
+	filter := &models.TaskFilter{Status: &status, Page: 1, PageSize: 20}
+	key := []byte("secret")
+	token := encodePageToken(key, filter, 2)
+
+	raw, _ := base64.RawURLEncoding.DecodeString(token)
+	tampered := append([]byte(nil), raw...)
+	for i := range tampered {
+		if tampered[i] == '2' {
+			tampered[i] = '9'
+			break
+		}
+	}
+
+	otherStatus := models.TaskStatusCompleted
+	otherFilter := &models.TaskFilter{Status: &otherStatus, Page: 1, PageSize: 20}
+
+	tests := []struct {
+		name   string
+		token  string
+		filter *models.TaskFilter
+	}{
+		{"Not base64", "%%%", filter},
+		{"Not JSON", base64.RawURLEncoding.EncodeToString([]byte("nope")), filter},
+		{"Tampered", base64.RawURLEncoding.EncodeToString(tampered), filter},
+		{"Different filter", token, otherFilter},
+		{"Other key", encodePageToken([]byte("other"), filter, 2), filter},
+		{"Forged", forgeUnkeyedToken(filter, 2, 1000000), filter},
+		{"Oversized page", encodePageToken(key, &models.TaskFilter{Status: &status, PageSize: maxPageSize + 1}, 2), filter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, err := decodePageToken(key, tt.token, tt.filter)
+			assert.Nil(t, state)
+			assert.True(t, errors.Is(err, ErrInvalidPageToken))
+		})
+	}
+}
+
+// forgeUnkeyedToken builds a token the way a client could without the
+// signing key: with a plain SHA-256 checksum
+func forgeUnkeyedToken(filter *models.TaskFilter, page, pageSize int) string {
+	state := pageTokenState{Version: pageTokenVersion, Page: page, PageSize: pageSize, Filter: filterFingerprint(filter)}
+	data, _ := json.Marshal(state)
+	sum := sha256.Sum256(data)
+	token, _ := json.Marshal(signedPageToken{State: state, Checksum: hex.EncodeToString(sum[:])})
+	return base64.RawURLEncoding.EncodeToString(token)
+}
+
+func TestListTasks_PageTokens(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil)
+
+	tasks := []models.Task{
+		*models.NewTask("Task 1", "Desc 1", "user1@example.com", models.TaskStatusPending),
+	}
+
+	mockRepo.On("GetAll", mock.Anything, mock.MatchedBy(func(f *models.TaskFilter) bool {
+		return f.Page == 1
+	})).Return(tasks, 25, nil).Once()
+
+	first, err := service.ListTasks(context.Background(), &models.TaskFilter{PageSize: 10})
+	require.NoError(t, err)
+	require.NotEmpty(t, first.NextPageToken)
+
+	mockRepo.On("GetAll", mock.Anything, mock.MatchedBy(func(f *models.TaskFilter) bool {
+		return f.Page == 2 && f.PageSize == 10
+	})).Return(tasks, 25, nil).Once()
+
+	second, err := service.ListTasks(context.Background(), &models.TaskFilter{PageToken: first.NextPageToken})
+	require.NoError(t, err)
+	assert.Equal(t, 2, second.Page)
+	assert.NotEmpty(t, second.NextPageToken)
+
+	mockRepo.On("GetAll", mock.Anything, mock.MatchedBy(func(f *models.TaskFilter) bool {
+		return f.Page == 3
+	})).Return(tasks, 25, nil).Once()
+
+	last, err := service.ListTasks(context.Background(), &models.TaskFilter{PageToken: second.NextPageToken})
+	require.NoError(t, err)
+	assert.Equal(t, 3, last.Page)
+	assert.Empty(t, last.NextPageToken)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPageToken_SharedSecret(t *testing.T) {
+	filter := &models.TaskFilter{Page: 1, PageSize: 10}
+	first := NewTaskService(new(MockTaskRepository), nil, WithPageTokenSecret("shared"))
+	second := NewTaskService(new(MockTaskRepository), nil, WithPageTokenSecret("shared"))
+	unkeyed := NewTaskService(new(MockTaskRepository), nil)
+
+	token := encodePageToken(first.pageTokenKey, filter, 2)
+	_, err := decodePageToken(second.pageTokenKey, token, filter)
+	assert.NoError(t, err)
+	_, err = decodePageToken(unkeyed.pageTokenKey, token, filter)
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+}
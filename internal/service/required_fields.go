@@ -0,0 +1,107 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/Ali-Gorgani/task-manager/internal/repository"
+)
+
+// requirableFields are the task fields a deployment may make mandatory
+var requirableFields = map[string]bool{
+	"title":       true,
+	"description": true,
+	"assignee":    true,
+	"source":      true,
+	"external_id": true,
+}
+
+// ValidateRequiredFields reports an error naming any field that cannot be
+// made mandatory
+func ValidateRequiredFields(fields []string) error {
+	var unknown []string
+	for _, field := range fields {
+		if !requirableFields[field] {
+			unknown = append(unknown, field)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w: unknown required fields: %s", repository.ErrInvalidInput, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// WithRequiredFields rejects creates and upserts that leave any of fields
+// empty, and updates that clear one of them. The title is always required.
+func WithRequiredFields(fields []string) Option {
+	return func(s *TaskService) {
+		s.requiredFields = fields
+	}
+}
+
+// MissingFieldsError lists the required fields a request left empty
+type MissingFieldsError struct {
+	Fields []string
+}
+
+func (e *MissingFieldsError) Error() string {
+	return fmt.Sprintf("missing required fields: %s", strings.Join(e.Fields, ", "))
+}
+
+// Unwrap makes missing fields an invalid input error
+func (e *MissingFieldsError) Unwrap() error {
+	return repository.ErrInvalidInput
+}
+
+// checkRequiredFields returns a *MissingFieldsError when task has an empty
+// required field. When touched is non-nil, only the fields it contains are
+// checked, so updates are not blocked by fields they leave alone.
+func (s *TaskService) checkRequiredFields(task *models.Task, touched map[string]bool) error {
+	var missing []string
+	for _, field := range s.requiredFields {
+		if touched != nil && !touched[field] {
+			continue
+		}
+		if taskFieldValue(task, field) == "" {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) > 0 {
+		return &MissingFieldsError{Fields: missing}
+	}
+	return nil
+}
+
+// updatedFields names the fields an update request sets
+func updatedFields(req *models.UpdateTaskRequest) map[string]bool {
+	return map[string]bool{
+		"title":       req.Title != nil,
+		"description": req.Description != nil,
+		"assignee":    req.Assignee != nil,
+		"source":      req.Source != nil,
+		"external_id": req.ExternalID != nil,
+	}
+}
+
+// taskFieldValue returns the value of a requirable task field, with unset
+// optional fields as empty strings
+func taskFieldValue(task *models.Task, field string) string {
+	switch field {
+	case "title":
+		return task.Title
+	case "description":
+		return task.Description
+	case "assignee":
+		return task.Assignee
+	case "source":
+		if task.Source != nil {
+			return *task.Source
+		}
+	case "external_id":
+		if task.ExternalID != nil {
+			return *task.ExternalID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/Ali-Gorgani/task-manager/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRequiredFields(t *testing.T) {
+	assert.NoError(t, ValidateRequiredFields([]string{"title", "assignee", "external_id"}))
+
+	err := ValidateRequiredFields([]string{"assignee", "due_date"})
+	assert.ErrorIs(t, err, repository.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "due_date")
+}
+
+func TestRequiredFields_Create(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil, WithRequiredFields([]string{"title", "assignee", "source"}))
+
+	_, err := service.CreateTask(context.Background(), &models.CreateTaskRequest{Title: "Task"})
+
+	var missing *MissingFieldsError
+	require.True(t, errors.As(err, &missing))
+	assert.Equal(t, []string{"assignee", "source"}, missing.Fields)
+	assert.ErrorIs(t, err, repository.ErrInvalidInput)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Task")).Return(nil)
+	_, err = service.CreateTask(context.Background(), &models.CreateTaskRequest{
+		Title:    "Task",
+		Assignee: "john.doe@example.com",
+		Source:   "jira",
+	})
+	assert.NoError(t, err)
+}
+
+func TestRequiredFields_Update(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil, WithRequiredFields([]string{"title", "assignee"}))
+
+	// An existing task without an assignee can still be updated otherwise
+	task := models.NewTask("Task", "", "", models.TaskStatusPending)
+	mockRepo.On("GetByID", mock.Anything, task.ID).Return(task, nil)
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*models.Task")).Return(nil)
+
+	status := models.TaskStatusInProgress
+	_, err := service.UpdateTask(context.Background(), task.ID, &models.UpdateTaskRequest{Status: &status})
+	assert.NoError(t, err)
+
+	// Clearing a required field is rejected
+	empty := ""
+	_, err = service.UpdateTask(context.Background(), task.ID, &models.UpdateTaskRequest{Assignee: &empty})
+
+	var missing *MissingFieldsError
+	require.True(t, errors.As(err, &missing))
+	assert.Equal(t, []string{"assignee"}, missing.Fields)
+	mockRepo.AssertNumberOfCalls(t, "Update", 1)
+}
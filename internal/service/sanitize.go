@@ -0,0 +1,63 @@
+package service
+
+import (
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// HTMLPolicy controls how sanitization treats HTML markup in task text
+type HTMLPolicy string
+
+const (
+	HTMLKeep   HTMLPolicy = "keep"
+	HTMLEscape HTMLPolicy = "escape"
+	HTMLStrip  HTMLPolicy = "strip"
+)
+
+// stripPolicy removes all markup, including unterminated tags and the
+// contents of script and style elements. Policies are safe for concurrent use.
+var stripPolicy = bluemonday.StrictPolicy()
+
+// sanitizer cleans user-submitted task text before it is stored
+type sanitizer struct {
+	htmlPolicy HTMLPolicy
+}
+
+// title removes every control character, including line breaks, and applies
+// the HTML policy
+func (z *sanitizer) title(value string) string {
+	return strings.TrimSpace(z.applyHTMLPolicy(stripControl(value, false)))
+}
+
+// description removes control characters other than line breaks and tabs
+// and applies the HTML policy
+func (z *sanitizer) description(value string) string {
+	return z.applyHTMLPolicy(stripControl(value, true))
+}
+
+func (z *sanitizer) applyHTMLPolicy(value string) string {
+	switch z.htmlPolicy {
+	case HTMLEscape:
+		return html.EscapeString(value)
+	case HTMLStrip:
+		return stripPolicy.Sanitize(value)
+	default:
+		return value
+	}
+}
+
+// stripControl drops control characters, optionally keeping \n, \r and \t
+func stripControl(value string, keepWhitespace bool) string {
+	return strings.Map(func(r rune) rune {
+		if keepWhitespace && (r == '\n' || r == '\r' || r == '\t') {
+			return r
+		}
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, value)
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizer(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      HTMLPolicy
+		title       string
+		description string
+		wantTitle   string
+		wantDesc    string
+	}{
+		{
+			name:        "Control characters",
+			policy:      HTMLKeep,
+			title:       "Fix\x00 bug\n",
+			description: "line one\nline\x07 two",
+			wantTitle:   "Fix bug",
+			wantDesc:    "line one\nline two",
+		},
+		{
+			name:        "Escape HTML",
+			policy:      HTMLEscape,
+			title:       "<b>Bold</b>",
+			description: "a < b",
+			wantTitle:   "&lt;b&gt;Bold&lt;/b&gt;",
+			wantDesc:    "a &lt; b",
+		},
+		{
+			name:        "Strip HTML",
+			policy:      HTMLStrip,
+			title:       "<script>alert(1)</script>Title",
+			description: "<p>Tom &amp; Jerry</p>",
+			wantTitle:   "Title",
+			wantDesc:    "Tom &amp; Jerry",
+		},
+		{
+			name:        "Strip unterminated tag",
+			policy:      HTMLStrip,
+			title:       "Title<img src=x onerror=alert(1)",
+			description: "<a href='javascript:alert(1)'>link</a><svg onload=alert(1)",
+			wantTitle:   "Title",
+			wantDesc:    "link",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			z := &sanitizer{htmlPolicy: tt.policy}
+			assert.Equal(t, tt.wantTitle, z.title(tt.title))
+			assert.Equal(t, tt.wantDesc, z.description(tt.description))
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/Ali-Gorgani/task-manager/internal/repository"
+)
+
+// ParseStatusAssignees parses status=assignee pairs, such as
+// "in_progress=reviewers@example.com", into a routing table for
+// WithStatusAssignees. Statuses are normalized; unknown statuses, entries
+// without an assignee and statuses listed twice are errors.
+func ParseStatusAssignees(pairs []string) (map[models.TaskStatus]string, error) {
+	routes := make(map[models.TaskStatus]string, len(pairs))
+	for _, pair := range pairs {
+		rawStatus, assignee, ok := strings.Cut(pair, "=")
+		assignee = strings.TrimSpace(assignee)
+		if !ok || assignee == "" {
+			return nil, fmt.Errorf("%w: %q is not a status=assignee pair", repository.ErrInvalidInput, pair)
+		}
+		status := models.NormalizeStatus(rawStatus)
+		if !models.IsValidStatus(status) {
+			return nil, fmt.Errorf("%w: unknown status %q", repository.ErrInvalidInput, rawStatus)
+		}
+		if _, dup := routes[status]; dup {
+			return nil, fmt.Errorf("%w: status %s is routed more than once", repository.ErrInvalidInput, status)
+		}
+		routes[status] = assignee
+	}
+	return routes, nil
+}
+
+// WithStatusAssignees reassigns a task to routes[status] when UpdateTask
+// moves it into status, e.g. to hand it to a review queue. An assignee given
+// in the same request always wins, and updates that leave the status
+// unchanged keep the current assignee.
+func WithStatusAssignees(routes map[models.TaskStatus]string) Option {
+	return func(s *TaskService) {
+		s.statusAssignees = routes
+	}
+}
+
+// routeAssignee applies the status routing to a task that moved from
+// previousStatus, unless the request set the assignee explicitly
+func (s *TaskService) routeAssignee(task *models.Task, previousStatus models.TaskStatus, explicitAssignee bool) {
+	if explicitAssignee || task.Status == previousStatus {
+		return
+	}
+	if assignee, ok := s.statusAssignees[task.Status]; ok {
+		task.Assignee = assignee
+	}
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/Ali-Gorgani/task-manager/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStatusAssignees(t *testing.T) {
+	routes, err := ParseStatusAssignees([]string{"In Progress = reviewers@example.com", "completed=archive@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, map[models.TaskStatus]string{
+		models.TaskStatusInProgress: "reviewers@example.com",
+		models.TaskStatusCompleted:  "archive@example.com",
+	}, routes)
+
+	for _, pairs := range [][]string{
+		{"in_progress"},
+		{"in_progress="},
+		{"in_review=reviewers@example.com"},
+		{"pending=a@example.com", "pending=b@example.com"},
+	} {
+		_, err := ParseStatusAssignees(pairs)
+		assert.ErrorIs(t, err, repository.ErrInvalidInput, pairs)
+	}
+}
+
+func TestUpdateTask_StatusAssignees(t *testing.T) {
+	routes := map[models.TaskStatus]string{models.TaskStatusInProgress: "reviewers@example.com"}
+	inProgress := models.TaskStatusInProgress
+
+	update := func(t *testing.T, task *models.Task, req *models.UpdateTaskRequest) *models.Task {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil, WithStatusAssignees(routes))
+		mockRepo.On("GetByID", mock.Anything, task.ID).Return(task, nil)
+		mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*models.Task")).Return(nil)
+
+		updated, err := service.UpdateTask(context.Background(), task.ID, req)
+		require.NoError(t, err)
+		return updated
+	}
+
+	t.Run("Entering a routed status reassigns", func(t *testing.T) {
+		task := models.NewTask("Task", "", "john.doe@example.com", models.TaskStatusPending)
+		updated := update(t, task, &models.UpdateTaskRequest{Status: &inProgress})
+		assert.Equal(t, "reviewers@example.com", updated.Assignee)
+	})
+
+	t.Run("Explicit assignee wins", func(t *testing.T) {
+		task := models.NewTask("Task", "", "john.doe@example.com", models.TaskStatusPending)
+		assignee := "jane.doe@example.com"
+		updated := update(t, task, &models.UpdateTaskRequest{Status: &inProgress, Assignee: &assignee})
+		assert.Equal(t, "jane.doe@example.com", updated.Assignee)
+	})
+
+	t.Run("Unchanged status keeps the assignee", func(t *testing.T) {
+		task := models.NewTask("Task", "", "john.doe@example.com", models.TaskStatusInProgress)
+		title := "Renamed"
+		updated := update(t, task, &models.UpdateTaskRequest{Title: &title, Status: &inProgress})
+		assert.Equal(t, "john.doe@example.com", updated.Assignee)
+	})
+
+	t.Run("Unrouted status keeps the assignee", func(t *testing.T) {
+		task := models.NewTask("Task", "", "john.doe@example.com", models.TaskStatusPending)
+		completed := models.TaskStatusCompleted
+		updated := update(t, task, &models.UpdateTaskRequest{Status: &completed})
+		assert.Equal(t, "john.doe@example.com", updated.Assignee)
+	})
+}
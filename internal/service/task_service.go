@@ -4,49 +4,297 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/Ali-Gorgani/task-manager/internal/cache"
+	"github.com/Ali-Gorgani/task-manager/internal/health"
 	"github.com/Ali-Gorgani/task-manager/internal/models"
 	"github.com/Ali-Gorgani/task-manager/internal/repository"
+	"golang.org/x/sync/singleflight"
 )
 
+const (
+	maxRetryAttempts   = 3
+	retryBaseDelay     = 10 * time.Millisecond
+	maxBatchIDs        = 100
+	defaultPageSize    = 10
+	maxPageSize        = 100
+	defaultRecent      = 10
+	maxRecent          = 100
+	maxAssigneeFilters = 50
+	defaultBoardLimit  = 20
+	maxBoardLimit      = 100
+	defaultStaleDays   = 14
+	maxTaskIDs         = 10000
+)
+
+// AssigneeNotifier is told when a task moves to a different assignee, or
+// when a bulk reassignment moves several at once
+type AssigneeNotifier interface {
+	NotifyAssigneeChanged(ctx context.Context, event models.AssigneeChangedEvent) error
+	NotifyAssigneesReassigned(ctx context.Context, event models.AssigneesReassignedEvent) error
+}
+
 // TaskService handles business logic for tasks
 type TaskService struct {
-	repo  repository.TaskRepository
-	cache *cache.RedisCache
+	repo             repository.TaskRepository
+	cache            *cache.RedisCache
+	assigneeNotifier AssigneeNotifier
+	sanitizer        *sanitizer
+	countCacheTTL    time.Duration
+	strictPageSize   bool
+	maxOffset        int
+	fullListMaxRows  int
+	requiredFields   []string
+	importClockSkew  time.Duration
+	defaultSort      string
+	defaultOrder     models.SortOrder
+	statusAssignees  map[models.TaskStatus]string
+	pageTokenKey     []byte
+
+	// countGroup collapses concurrent task counts into one query
+	countGroup singleflight.Group
+	// notifications tracks assignee change notifications still being sent
+	notifications sync.WaitGroup
+}
+
+// Option configures optional TaskService behaviour
+type Option func(*TaskService)
+
+// WithAssigneeNotifier notifies n whenever UpdateTask changes a task's assignee
+func WithAssigneeNotifier(n AssigneeNotifier) Option {
+	return func(s *TaskService) {
+		s.assigneeNotifier = n
+	}
+}
+
+// WithSanitization strips control characters from titles and descriptions
+// on create and update and handles HTML markup according to policy
+func WithSanitization(policy HTMLPolicy) Option {
+	return func(s *TaskService) {
+		s.sanitizer = &sanitizer{htmlPolicy: policy}
+	}
+}
+
+// WithCountCacheTTL serves GetTaskCount from the cache for up to ttl so
+// frequent callers do not each run COUNT(*). Zero disables count caching.
+func WithCountCacheTTL(ttl time.Duration) Option {
+	return func(s *TaskService) {
+		s.countCacheTTL = ttl
+	}
+}
+
+// WithPageTokenSecret signs page tokens with secret so they verify on every
+// replica and across restarts. Without it each process signs with its own
+// random key. An empty secret keeps the random key.
+func WithPageTokenSecret(secret string) Option {
+	return func(s *TaskService) {
+		if secret != "" {
+			s.pageTokenKey = []byte(secret)
+		}
+	}
+}
+
+// WithStrictPageSize rejects list requests whose page_size exceeds the
+// maximum instead of silently clamping it
+func WithStrictPageSize(strict bool) Option {
+	return func(s *TaskService) {
+		s.strictPageSize = strict
+	}
+}
+
+// WithMaxOffset rejects list requests for pages starting beyond offset max,
+// so deep pages cannot force huge OFFSET scans. Zero disables the limit.
+func WithMaxOffset(max int) Option {
+	return func(s *TaskService) {
+		s.maxOffset = max
+	}
+}
+
+// WithFullListCaching caches up to maxRows rows of the unpaginated result of
+// each list filter and serves pages by slicing it, so paging through a small
+// data set costs one load per filter until the next write. Pages beyond the
+// cached rows fall back to per-page queries. Zero disables it.
+func WithFullListCaching(maxRows int) Option {
+	return func(s *TaskService) {
+		s.fullListMaxRows = maxRows
+	}
+}
+
+// WithImportClockSkew lets imported timestamps lie up to skew in the future,
+// to absorb clock differences with the exporting system
+func WithImportClockSkew(skew time.Duration) Option {
+	return func(s *TaskService) {
+		s.importClockSkew = skew
+	}
+}
+
+// ValidateDefaultSort reports an error when field is not sortable or order is
+// not a recognized sort order
+func ValidateDefaultSort(field, order string) error {
+	if !models.IsSortableField(strings.ToLower(strings.TrimSpace(field))) {
+		return fmt.Errorf("%w: invalid sort field: %s", repository.ErrInvalidInput, field)
+	}
+	if _, ok := models.NormalizeSortOrder(order); !ok {
+		return fmt.Errorf("%w: invalid sort order: %s", repository.ErrInvalidInput, order)
+	}
+	return nil
+}
+
+// WithDefaultSort orders lists by field and order when the request gives no
+// sort field or order, instead of newest first. Both must pass
+// ValidateDefaultSort.
+func WithDefaultSort(field, order string) Option {
+	return func(s *TaskService) {
+		s.defaultSort = strings.ToLower(strings.TrimSpace(field))
+		s.defaultOrder, _ = models.NormalizeSortOrder(order)
+	}
 }
 
 // NewTaskService creates a new task service
-func NewTaskService(repo repository.TaskRepository, cache *cache.RedisCache) *TaskService {
-	return &TaskService{
-		repo:  repo,
-		cache: cache,
+func NewTaskService(repo repository.TaskRepository, cache *cache.RedisCache, opts ...Option) *TaskService {
+	s := &TaskService{
+		repo:         repo,
+		cache:        cache,
+		defaultSort:  models.DefaultSortField,
+		defaultOrder: models.SortOrderDesc,
+		pageTokenKey: newPageTokenKey(),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // CreateTask creates a new task
 func (s *TaskService) CreateTask(ctx context.Context, req *models.CreateTaskRequest) (*models.Task, error) {
+	task, err := s.newTask(req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = withRetry(ctx, func() error {
+		return s.repo.Create(ctx, task)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create task: %w", err)
+	}
+
+	// Invalidate list cache
+	if s.cache != nil {
+		_ = s.cache.InvalidateTaskList(ctx)
+		s.invalidateTaskCount(ctx)
+	}
+
+	return task, nil
+}
+
+// CreateTaskIfNotExists creates the task described by req unless a task
+// with the same source and external ID exists, in which case that task is
+// returned unchanged. It reports whether the task was created. The external
+// ID is required.
+func (s *TaskService) CreateTaskIfNotExists(ctx context.Context, req *models.CreateTaskRequest) (*models.Task, bool, error) {
+	task, err := s.newTask(req)
+	if err != nil {
+		return nil, false, err
+	}
+	if task.ExternalID == nil {
+		return nil, false, fmt.Errorf("%w: external_id is required", repository.ErrInvalidInput)
+	}
+
+	var stored *models.Task
+	var created bool
+	err = withRetry(ctx, func() error {
+		var err error
+		stored, created, err = s.repo.CreateIfNotExists(ctx, task)
+		return err
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create task: %w", err)
+	}
+
+	if created && s.cache != nil {
+		_ = s.cache.InvalidateTaskList(ctx)
+		s.invalidateTaskCount(ctx)
+	}
+
+	return stored, created, nil
+}
+
+// newTask validates a create request and builds the task it describes,
+// with server-set ID and timestamps
+func (s *TaskService) newTask(req *models.CreateTaskRequest) (*models.Task, error) {
+	if s.sanitizer != nil {
+		req.Title = s.sanitizer.title(req.Title)
+		req.Description = s.sanitizer.description(req.Description)
+	}
+
 	if req.Title == "" {
-		return nil, errors.New("title is required")
+		return nil, fmt.Errorf("%w: title is required", repository.ErrInvalidInput)
 	}
 
 	if req.Status != "" && !models.IsValidStatus(req.Status) {
-		return nil, errors.New("invalid status")
+		return nil, fmt.Errorf("%w: invalid status", repository.ErrInvalidInput)
+	}
+
+	source, externalID, err := externalReference(req.Source, req.ExternalID)
+	if err != nil {
+		return nil, err
 	}
 
 	task := models.NewTask(req.Title, req.Description, req.Assignee, req.Status)
+	task.Source, task.ExternalID = source, externalID
+	if err := s.checkRequiredFields(task, nil); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
 
-	if err := s.repo.Create(ctx, task); err != nil {
-		return nil, fmt.Errorf("failed to create task: %w", err)
+// ValidateTask runs the checks CreateTask applies to req, after the same
+// sanitization, and reports every failing field instead of stopping at the
+// first. Nothing is stored and req is left unchanged. Uniqueness is not
+// checked, since that needs the database.
+func (s *TaskService) ValidateTask(req models.CreateTaskRequest) *models.ValidationResult {
+	if s.sanitizer != nil {
+		req.Title = s.sanitizer.title(req.Title)
+		req.Description = s.sanitizer.description(req.Description)
 	}
 
-	// Invalidate list cache
-	if s.cache != nil {
-		_ = s.cache.InvalidateTaskList(ctx)
+	result := &models.ValidationResult{Errors: []models.FieldError{}}
+	reported := make(map[string]bool)
+	fail := func(field, message string) {
+		if !reported[field] {
+			reported[field] = true
+			result.Errors = append(result.Errors, models.FieldError{Field: field, Message: message})
+		}
 	}
 
-	return task, nil
+	if req.Title == "" {
+		fail("title", "is required")
+	}
+	if req.Status != "" && !models.IsValidStatus(req.Status) {
+		fail("status", "invalid status")
+	}
+	if _, _, err := externalReference(req.Source, req.ExternalID); err != nil {
+		fail("external_id", "requires a source")
+	}
+
+	task := models.NewTask(req.Title, req.Description, req.Assignee, req.Status)
+	task.Source, task.ExternalID = optionalString(req.Source), optionalString(req.ExternalID)
+	var missing *MissingFieldsError
+	if errors.As(s.checkRequiredFields(task, nil), &missing) {
+		for _, field := range missing.Fields {
+			fail(field, "is required")
+		}
+	}
+
+	result.Valid = len(result.Errors) == 0
+	return result
 }
 
 // GetTask retrieves a task by ID (with caching)
@@ -73,42 +321,96 @@ func (s *TaskService) GetTask(ctx context.Context, id string) (*models.Task, err
 	return task, nil
 }
 
+// GetTaskUpdatedAt returns when a task was last updated, from the cached
+// task when there is one and otherwise without loading the whole task
+func (s *TaskService) GetTaskUpdatedAt(ctx context.Context, id string) (time.Time, error) {
+	if s.cache != nil {
+		cachedTask, err := s.cache.GetTask(ctx, id)
+		if err == nil && cachedTask != nil {
+			return cachedTask.UpdatedAt, nil
+		}
+	}
+
+	return s.repo.GetUpdatedAt(ctx, id)
+}
+
+// GetTasksByIDs retrieves the tasks with the given IDs in request order.
+// IDs that do not exist are returned separately as missing.
+func (s *TaskService) GetTasksByIDs(ctx context.Context, ids []string) ([]models.Task, []string, error) {
+	if len(ids) == 0 {
+		return nil, nil, errors.New("at least one id is required")
+	}
+	if len(ids) > maxBatchIDs {
+		return nil, nil, fmt.Errorf("at most %d ids may be requested", maxBatchIDs)
+	}
+
+	found, err := s.repo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	byID := make(map[string]models.Task, len(found))
+	for _, task := range found {
+		byID[task.ID] = task
+	}
+
+	tasks := make([]models.Task, 0, len(found))
+	missing := []string{}
+	for _, id := range ids {
+		if task, ok := byID[id]; ok {
+			tasks = append(tasks, task)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+
+	return tasks, missing, nil
+}
+
 // ListTasks retrieves all tasks with filtering and pagination (with caching)
 func (s *TaskService) ListTasks(ctx context.Context, filter *models.TaskFilter) (*models.TaskListResponse, error) {
 	if filter == nil {
 		filter = &models.TaskFilter{}
 	}
 
-	// Set default pagination
-	if filter.Page < 1 {
-		filter.Page = 1
+	if err := s.normalizeFilter(filter, s.strictPageSize || filter.StrictPageSize); err != nil {
+		return nil, err
 	}
-	if filter.PageSize < 1 {
-		filter.PageSize = 10
+
+	// A page token overrides page and page_size
+	if filter.PageToken != "" {
+		state, err := decodePageToken(s.pageTokenKey, filter.PageToken, filter)
+		if err != nil {
+			return nil, err
+		}
+		filter.Page = state.Page
+		filter.PageSize = state.PageSize
+		filter.PageToken = ""
 	}
-	if filter.PageSize > 100 {
-		filter.PageSize = 100
+
+	if s.maxOffset > 0 {
+		if offset := (filter.Page - 1) * filter.PageSize; offset > s.maxOffset {
+			return nil, fmt.Errorf("page %d starts at offset %d, beyond the maximum of %d; narrow the results with filters or walk them in order with /api/v1/tasks/changes",
+				filter.Page, offset, s.maxOffset)
+		}
 	}
 
-	// Validate filter
-	if filter.Status != nil && !models.IsValidStatus(*filter.Status) {
-		return nil, errors.New("invalid status filter")
+	if s.cache != nil && s.fullListMaxRows > 0 {
+		response, ok, err := s.fullListPage(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return response, nil
+		}
 	}
 
 	// Try cache first (only for GET requests with specific filters)
 	if s.cache != nil {
 		cacheKey := cache.GenerateCacheKey(filter)
-		cachedTasks, err := s.cache.GetTaskList(ctx, cacheKey)
-		if err == nil && cachedTasks != nil {
-			total := len(cachedTasks)
-			totalPages := (total + filter.PageSize - 1) / filter.PageSize
-			return &models.TaskListResponse{
-				Tasks:      cachedTasks,
-				Total:      total,
-				Page:       filter.Page,
-				PageSize:   filter.PageSize,
-				TotalPages: totalPages,
-			}, nil
+		cached, err := s.cache.GetTaskList(ctx, cacheKey)
+		if err == nil && cached != nil {
+			return s.newTaskListResponse(filter, cached.Tasks, cached.Total), nil
 		}
 	}
 
@@ -121,7 +423,179 @@ func (s *TaskService) ListTasks(ctx context.Context, filter *models.TaskFilter)
 	// Store in cache
 	if s.cache != nil {
 		cacheKey := cache.GenerateCacheKey(filter)
-		_ = s.cache.SetTaskList(ctx, cacheKey, tasks)
+		_ = s.cache.SetTaskList(ctx, cacheKey, tasks, total)
+	}
+
+	return s.newTaskListResponse(filter, tasks, total), nil
+}
+
+// fullListPage serves the requested page from the cached leading rows of the
+// unpaginated result for filter, loading them on a cache miss. ok is false
+// when the page lies beyond the cached rows. A miss for a page ending past
+// fullListMaxRows is not loaded, since those rows could never serve it.
+func (s *TaskService) fullListPage(ctx context.Context, filter *models.TaskFilter) (response *models.TaskListResponse, ok bool, err error) {
+	start := (filter.Page - 1) * filter.PageSize
+	end := start + filter.PageSize
+
+	cacheKey := cache.GenerateFullListCacheKey(filter)
+	entry, err := s.cache.GetTaskList(ctx, cacheKey)
+	if err != nil || entry == nil {
+		if end > s.fullListMaxRows {
+			return nil, false, nil
+		}
+		tasks, total, err := s.loadFullList(ctx, filter)
+		if err != nil {
+			return nil, false, err
+		}
+		entry = &cache.TaskListEntry{Tasks: tasks, Total: total}
+		_ = s.cache.SetTaskList(ctx, cacheKey, tasks, total)
+	}
+
+	cached := len(entry.Tasks)
+	if end > cached && cached < entry.Total {
+		return nil, false, nil
+	}
+
+	tasks := entry.Tasks[min(start, cached):min(end, cached)]
+	return s.newTaskListResponse(filter, tasks, entry.Total), true, nil
+}
+
+// loadFullList reads up to fullListMaxRows rows of the unpaginated result
+// for filter, a maximum-size page at a time, along with the total match count
+func (s *TaskService) loadFullList(ctx context.Context, filter *models.TaskFilter) ([]models.Task, int, error) {
+	page := *filter
+	page.PageSize = maxPageSize
+
+	var all []models.Task
+	for page.Page = 1; ; page.Page++ {
+		tasks, total, err := s.repo.GetAll(ctx, &page)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to list tasks: %w", err)
+		}
+		all = append(all, tasks...)
+
+		if len(tasks) < maxPageSize || len(all) >= total || len(all) >= s.fullListMaxRows {
+			if len(all) > s.fullListMaxRows {
+				all = all[:s.fullListMaxRows]
+			}
+			return all, total, nil
+		}
+	}
+}
+
+// ListTaskIDs returns the IDs of the tasks matching filter in list order,
+// without pagination, for clients that only need the matching set. At most
+// 10000 IDs are returned; Total still counts every match.
+func (s *TaskService) ListTaskIDs(ctx context.Context, filter *models.TaskFilter) (*models.TaskIDListResponse, error) {
+	if filter == nil {
+		filter = &models.TaskFilter{}
+	}
+	if err := s.normalizeFilter(filter, false); err != nil {
+		return nil, err
+	}
+
+	ids, total, err := s.repo.GetIDs(ctx, filter, maxTaskIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task IDs: %w", err)
+	}
+	return &models.TaskIDListResponse{IDs: ids, Total: total}, nil
+}
+
+// normalizeFilter applies pagination defaults and canonicalizes the status,
+// sort field and sort order of a list filter in place, falling back to the
+// service's default sort. An oversized page size is clamped unless
+// strictPageSize is set, in which case it is an error.
+func (s *TaskService) normalizeFilter(filter *models.TaskFilter, strictPageSize bool) error {
+	// Set default pagination
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = defaultPageSize
+	}
+	if filter.PageSize > maxPageSize {
+		if strictPageSize {
+			return fmt.Errorf("page_size %d exceeds the maximum of %d", filter.PageSize, maxPageSize)
+		}
+		filter.PageSize = maxPageSize
+	}
+
+	// Validate filter. An empty ?status= means no status filter.
+	if filter.Status != nil {
+		status := models.NormalizeStatus(string(*filter.Status))
+		filter.Status = &status
+		if status == "" {
+			filter.Status = nil
+		}
+	}
+	if filter.Status != nil && !models.IsValidStatus(*filter.Status) {
+		return errors.New("invalid status filter")
+	}
+
+	if len(filter.Assignees) > maxAssigneeFilters {
+		return fmt.Errorf("at most %d assignees may be filtered on", maxAssigneeFilters)
+	}
+	filter.Assignees = normalizeAssignees(filter.Assignees)
+
+	if filter.TitlePrefix != nil {
+		prefix := strings.TrimSpace(*filter.TitlePrefix)
+		if prefix == "" {
+			return errors.New("title_prefix must not be empty")
+		}
+		filter.TitlePrefix = &prefix
+	}
+
+	// stale=true selects open tasks not updated for stale_days
+	switch {
+	case filter.StaleDays < 0:
+		return errors.New("stale_days must be positive")
+	case filter.StaleDays > 0 && !filter.Stale:
+		return errors.New("stale_days requires stale=true")
+	case filter.Stale && filter.StaleDays == 0:
+		filter.StaleDays = defaultStaleDays
+	}
+
+	filter.Sort = strings.ToLower(strings.TrimSpace(filter.Sort))
+	if filter.Sort == "" {
+		filter.Sort = s.defaultSort
+	}
+	if !models.IsSortableField(filter.Sort) {
+		return fmt.Errorf("invalid sort field: %s", filter.Sort)
+	}
+
+	// An unrecognized sort order is a client quirk, not an error
+	order, ok := models.NormalizeSortOrder(string(filter.Order))
+	if strings.TrimSpace(string(filter.Order)) == "" {
+		order = s.defaultOrder
+	} else if !ok {
+		order = s.defaultOrder
+		log.Printf("Warning: unknown sort order %q, falling back to %s", filter.Order, order)
+	}
+	filter.Order = order
+
+	return nil
+}
+
+// normalizeAssignees trims, sorts and deduplicates an assignee filter. A
+// blank value stays as "", which matches unassigned tasks, so ?assignee=
+// keeps meaning what it did before several assignees were accepted.
+func normalizeAssignees(assignees []string) []string {
+	if len(assignees) == 0 {
+		return nil
+	}
+	normalized := make([]string, len(assignees))
+	for i, assignee := range assignees {
+		normalized[i] = strings.TrimSpace(assignee)
+	}
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
+
+// newTaskListResponse builds the paginated response for a page of tasks
+func (s *TaskService) newTaskListResponse(filter *models.TaskFilter, tasks []models.Task, total int) *models.TaskListResponse {
+	// A nil slice would marshal as null; clients expect [] for an empty page
+	if tasks == nil {
+		tasks = []models.Task{}
 	}
 
 	totalPages := (total + filter.PageSize - 1) / filter.PageSize
@@ -129,15 +603,148 @@ func (s *TaskService) ListTasks(ctx context.Context, filter *models.TaskFilter)
 		totalPages = 1
 	}
 
-	return &models.TaskListResponse{
+	response := &models.TaskListResponse{
 		Tasks:      tasks,
 		Total:      total,
 		Page:       filter.Page,
 		PageSize:   filter.PageSize,
 		TotalPages: totalPages,
+	}
+	if filter.Page < totalPages {
+		response.NextPageToken = encodePageToken(s.pageTokenKey, filter, filter.Page+1)
+	}
+	if filter.AppliedFilters {
+		response.AppliedFilters = filter.Applied()
+	}
+	return response
+}
+
+// ListChangesSince retrieves tasks updated after the given time for incremental
+// sync. A non-empty cursor from a previous response takes precedence over
+// since and resumes exactly where that page ended.
+func (s *TaskService) ListChangesSince(ctx context.Context, since time.Time, cursor string, limit int) (*models.TaskChangesResponse, error) {
+	position := changesCursor{UpdatedAt: since}
+	if cursor != "" {
+		decoded, err := decodeChangesCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		position = *decoded
+		since = position.UpdatedAt
+	}
+
+	if limit < 1 {
+		limit = 100
+	}
+	if limit > 1000 {
+		limit = 1000
+	}
+
+	// Fetch one extra row to find out whether more changes remain
+	tasks, err := s.repo.GetChangedSince(ctx, position.UpdatedAt, position.ID, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changes: %w", err)
+	}
+
+	hasMore := len(tasks) > limit
+	if hasMore {
+		tasks = tasks[:limit]
+	}
+
+	if tasks == nil {
+		tasks = []models.Task{}
+	}
+
+	next := position
+	if len(tasks) > 0 {
+		last := tasks[len(tasks)-1]
+		next = changesCursor{UpdatedAt: last.UpdatedAt, ID: last.ID}
+	}
+
+	return &models.TaskChangesResponse{
+		Tasks:      tasks,
+		Since:      since,
+		NextSince:  next.UpdatedAt,
+		NextCursor: encodeChangesCursor(next),
+		HasMore:    hasMore,
 	}, nil
 }
 
+// GetBoard groups the tasks matching query's filters into a column per
+// status. Each column holds at most query.Limit tasks, starting at its offset
+// in offsets (zero when absent), in the filter's sort order. A status filter
+// restricts the board to that column.
+func (s *TaskService) GetBoard(ctx context.Context, query *models.BoardQuery, offsets map[models.TaskStatus]int) (*models.BoardResponse, error) {
+	filter := &query.TaskFilter
+	if err := s.normalizeFilter(filter, false); err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrInvalidInput, err)
+	}
+
+	limit := query.Limit
+	if limit < 1 {
+		limit = defaultBoardLimit
+	}
+	if limit > maxBoardLimit {
+		limit = maxBoardLimit
+	}
+
+	for status, offset := range offsets {
+		if !models.IsValidStatus(status) {
+			return nil, fmt.Errorf("%w: invalid offset status: %s", repository.ErrInvalidInput, status)
+		}
+		if offset < 0 || (s.maxOffset > 0 && offset > s.maxOffset) {
+			return nil, fmt.Errorf("%w: invalid offset for %s: %d", repository.ErrInvalidInput, status, offset)
+		}
+	}
+
+	statuses := models.AllStatuses()
+	if filter.Status != nil {
+		statuses = []models.TaskStatus{*filter.Status}
+	}
+	pages := make(map[models.TaskStatus]models.BoardPage, len(statuses))
+	for _, status := range statuses {
+		pages[status] = models.BoardPage{Offset: offsets[status], Limit: limit}
+	}
+
+	columns, err := s.repo.GetBoard(ctx, filter, pages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board: %w", err)
+	}
+	return &models.BoardResponse{Columns: columns}, nil
+}
+
+// ListRecentTasks retrieves the limit most recently updated tasks, newest
+// first. The result is cached briefly and dropped on every write.
+func (s *TaskService) ListRecentTasks(ctx context.Context, limit int) (*models.RecentTasksResponse, error) {
+	if limit < 1 {
+		limit = defaultRecent
+	}
+	if limit > maxRecent {
+		limit = maxRecent
+	}
+
+	if s.cache != nil {
+		cached, err := s.cache.GetRecentTasks(ctx, limit)
+		if err == nil && cached != nil {
+			return &models.RecentTasksResponse{Tasks: cached}, nil
+		}
+	}
+
+	tasks, err := s.repo.GetRecentlyUpdated(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recent tasks: %w", err)
+	}
+	if tasks == nil {
+		tasks = []models.Task{}
+	}
+
+	if s.cache != nil {
+		_ = s.cache.SetRecentTasks(ctx, limit, tasks)
+	}
+
+	return &models.RecentTasksResponse{Tasks: tasks}, nil
+}
+
 // UpdateTask updates an existing task
 func (s *TaskService) UpdateTask(ctx context.Context, id string, req *models.UpdateTaskRequest) (*models.Task, error) {
 	// Get existing task
@@ -146,26 +753,51 @@ func (s *TaskService) UpdateTask(ctx context.Context, id string, req *models.Upd
 		return nil, err
 	}
 
+	previousAssignee := task.Assignee
+	previousStatus := task.Status
+
 	// Update fields
 	if req.Title != nil {
 		task.Title = *req.Title
+		if s.sanitizer != nil {
+			task.Title = s.sanitizer.title(task.Title)
+		}
 	}
 	if req.Description != nil {
 		task.Description = *req.Description
+		if s.sanitizer != nil {
+			task.Description = s.sanitizer.description(task.Description)
+		}
 	}
 	if req.Status != nil {
 		if !models.IsValidStatus(*req.Status) {
-			return nil, errors.New("invalid status")
+			return nil, fmt.Errorf("%w: invalid status", repository.ErrInvalidInput)
 		}
 		task.Status = *req.Status
 	}
 	if req.Assignee != nil {
 		task.Assignee = *req.Assignee
 	}
+	s.routeAssignee(task, previousStatus, req.Assignee != nil)
+	if req.Source != nil {
+		task.Source = optionalString(*req.Source)
+	}
+	if req.ExternalID != nil {
+		task.ExternalID = optionalString(*req.ExternalID)
+	}
+	if task.ExternalID != nil && task.Source == nil {
+		return nil, errExternalIDWithoutSource
+	}
+	if err := s.checkRequiredFields(task, updatedFields(req)); err != nil {
+		return nil, err
+	}
 
-	task.UpdatedAt = time.Now()
+	touch(task)
 
-	if err := s.repo.Update(ctx, task); err != nil {
+	err = withRetry(ctx, func() error {
+		return s.repo.Update(ctx, task)
+	})
+	if err != nil {
 		return nil, fmt.Errorf("failed to update task: %w", err)
 	}
 
@@ -175,12 +807,227 @@ func (s *TaskService) UpdateTask(ctx context.Context, id string, req *models.Upd
 		_ = s.cache.InvalidateTaskList(ctx)
 	}
 
+	if task.Assignee != previousAssignee {
+		s.notifyAssigneeChanged(ctx, task, previousAssignee)
+	}
+
 	return task, nil
 }
 
+// UpsertTaskByExternalID creates the task identified by req's source and
+// external ID, or overwrites the existing one, so sync jobs can replay
+// external items idempotently. It reports whether the task was created.
+// Assignee change notifications are not sent for upserts.
+func (s *TaskService) UpsertTaskByExternalID(ctx context.Context, req *models.CreateTaskRequest) (*models.Task, bool, error) {
+	task, err := s.newTask(req)
+	if err != nil {
+		return nil, false, err
+	}
+	if task.ExternalID == nil {
+		return nil, false, fmt.Errorf("%w: external_id is required", repository.ErrInvalidInput)
+	}
+
+	var stored *models.Task
+	var created bool
+	err = withRetry(ctx, func() error {
+		var err error
+		stored, created, err = s.repo.UpsertByExternalID(ctx, task)
+		return err
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to upsert task: %w", err)
+	}
+
+	// Invalidate caches
+	if s.cache != nil {
+		_ = s.cache.DeleteTask(ctx, stored.ID)
+		_ = s.cache.InvalidateTaskList(ctx)
+		s.invalidateTaskCount(ctx)
+	}
+
+	return stored, created, nil
+}
+
+// errUpdatedBeforeCreated rejects timestamps that would break sorting by
+// either field and the trend stats
+var errUpdatedBeforeCreated = fmt.Errorf("%w: updated_at is before created_at", repository.ErrInvalidInput)
+
+// touch stamps task as updated now. An imported task may have a created_at
+// up to the import clock skew ahead of this server's clock, so updated_at is
+// kept at or after created_at.
+func touch(task *models.Task) {
+	now := time.Now()
+	if now.Before(task.CreatedAt) {
+		now = task.CreatedAt
+	}
+	task.UpdatedAt = now
+}
+
+// errExternalIDWithoutSource rejects an external ID that has no source to
+// scope it
+var errExternalIDWithoutSource = fmt.Errorf("%w: external_id requires a source", repository.ErrInvalidInput)
+
+// externalReference trims an external source and ID, mapping empty values to
+// nil so they are stored as NULL
+func externalReference(source, externalID string) (*string, *string, error) {
+	src := optionalString(source)
+	ext := optionalString(externalID)
+	if ext != nil && src == nil {
+		return nil, nil, errExternalIDWithoutSource
+	}
+	return src, ext, nil
+}
+
+// optionalString returns nil for a blank value and a pointer to the trimmed
+// value otherwise
+func optionalString(value string) *string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// AssignTask changes only the assignee of a task
+func (s *TaskService) AssignTask(ctx context.Context, id, assignee string) (*models.Task, error) {
+	var task *models.Task
+	var previousAssignee string
+	err := withRetry(ctx, func() error {
+		var err error
+		task, previousAssignee, err = s.repo.UpdateAssignee(ctx, id, assignee, time.Now())
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign task: %w", err)
+	}
+
+	// Invalidate caches
+	if s.cache != nil {
+		_ = s.cache.DeleteTask(ctx, id)
+		_ = s.cache.InvalidateTaskList(ctx)
+	}
+
+	if task.Assignee != previousAssignee {
+		s.notifyAssigneeChanged(ctx, task, previousAssignee)
+	}
+
+	return task, nil
+}
+
+// ReassignTasks moves every task of one assignee, optionally only those in
+// one status, to another assignee and returns how many moved. The moved tasks
+// are reported in a single bulk reassignment notification, so large
+// handovers cannot overflow the notifier's queue.
+func (s *TaskService) ReassignTasks(ctx context.Context, req *models.ReassignTasksRequest) (int, error) {
+	if strings.EqualFold(req.From, req.To) {
+		return 0, fmt.Errorf("%w: from and to must differ", repository.ErrInvalidInput)
+	}
+	if req.Status != nil {
+		status := models.NormalizeStatus(string(*req.Status))
+		if !models.IsValidStatus(status) {
+			return 0, fmt.Errorf("%w: invalid status", repository.ErrInvalidInput)
+		}
+		req.Status = &status
+	}
+
+	var tasks []models.Task
+	err := withRetry(ctx, func() error {
+		var err error
+		tasks, err = s.repo.ReassignAll(ctx, req.From, req.To, req.Status, time.Now())
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to reassign tasks: %w", err)
+	}
+
+	// Invalidate caches
+	if s.cache != nil && len(tasks) > 0 {
+		_ = s.cache.InvalidateAllTasks(ctx)
+		_ = s.cache.InvalidateTaskList(ctx)
+	}
+
+	if len(tasks) > 0 {
+		s.notifyAssigneesReassigned(ctx, req, tasks)
+	}
+
+	return len(tasks), nil
+}
+
+// notifyAssigneeChanged sends the reassignment event without blocking the caller
+func (s *TaskService) notifyAssigneeChanged(ctx context.Context, task *models.Task, previousAssignee string) {
+	if s.assigneeNotifier == nil {
+		return
+	}
+
+	event := models.AssigneeChangedEvent{
+		Event:       "task.assignee_changed",
+		TaskID:      task.ID,
+		OldAssignee: previousAssignee,
+		NewAssignee: task.Assignee,
+		Task:        *task,
+		ChangedAt:   task.UpdatedAt,
+	}
+
+	// The notification outlives the request, so detach it from cancellation
+	notifyCtx := context.WithoutCancel(ctx)
+	s.notifications.Go(func() {
+		if err := s.assigneeNotifier.NotifyAssigneeChanged(notifyCtx, event); err != nil {
+			log.Printf("Warning: assignee change notification for task %s failed: %v", task.ID, err)
+		}
+	})
+}
+
+// notifyAssigneesReassigned sends one event listing every task a bulk
+// reassignment moved, without blocking the caller
+func (s *TaskService) notifyAssigneesReassigned(ctx context.Context, req *models.ReassignTasksRequest, tasks []models.Task) {
+	if s.assigneeNotifier == nil {
+		return
+	}
+
+	event := models.AssigneesReassignedEvent{
+		Event:        "task.assignees_reassigned",
+		OldAssignee:  req.From,
+		NewAssignee:  req.To,
+		Status:       req.Status,
+		TaskIDs:      make([]string, len(tasks)),
+		ReassignedAt: tasks[0].UpdatedAt,
+	}
+	for i := range tasks {
+		event.TaskIDs[i] = tasks[i].ID
+	}
+
+	notifyCtx := context.WithoutCancel(ctx)
+	s.notifications.Go(func() {
+		if err := s.assigneeNotifier.NotifyAssigneesReassigned(notifyCtx, event); err != nil {
+			log.Printf("Warning: bulk reassignment notification for %d tasks failed: %v", len(event.TaskIDs), err)
+		}
+	})
+}
+
+// WaitForNotifications waits until every assignee change notification has
+// been handed to the notifier, or returns ctx's error if it ends first. It is
+// meant for shutdown, once requests have stopped and before the notifier is
+// flushed.
+func (s *TaskService) WaitForNotifications(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.notifications.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // DeleteTask deletes a task by ID
 func (s *TaskService) DeleteTask(ctx context.Context, id string) error {
-	if err := s.repo.Delete(ctx, id); err != nil {
+	err := withRetry(ctx, func() error {
+		return s.repo.Delete(ctx, id)
+	})
+	if err != nil {
 		return err
 	}
 
@@ -188,12 +1035,285 @@ func (s *TaskService) DeleteTask(ctx context.Context, id string) error {
 	if s.cache != nil {
 		_ = s.cache.DeleteTask(ctx, id)
 		_ = s.cache.InvalidateTaskList(ctx)
+		s.invalidateTaskCount(ctx)
 	}
 
 	return nil
 }
 
-// GetTaskCount returns the total number of tasks
+// DeleteTaskIfMatch deletes a task only if ifMatch (an If-Match header value)
+// matches the task's current ETag, returning repository.ErrTaskModified when
+// it does not
+func (s *TaskService) DeleteTaskIfMatch(ctx context.Context, id, ifMatch string) error {
+	task, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !ifMatchSatisfied(ifMatch, task.ETag()) {
+		return repository.ErrTaskModified
+	}
+
+	err = withRetry(ctx, func() error {
+		return s.repo.DeleteIfUnmodified(ctx, id, task.UpdatedAt)
+	})
+	if errors.Is(err, repository.ErrTaskModified) {
+		// No row matched: the task was either changed or deleted since it
+		// was read, and a deleted task is reported as not found
+		if _, lookupErr := s.repo.GetUpdatedAt(ctx, id); errors.Is(lookupErr, repository.ErrTaskNotFound) {
+			return repository.ErrTaskNotFound
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	// Invalidate caches
+	if s.cache != nil {
+		_ = s.cache.DeleteTask(ctx, id)
+		_ = s.cache.InvalidateTaskList(ctx)
+		s.invalidateTaskCount(ctx)
+	}
+
+	return nil
+}
+
+// ifMatchSatisfied applies the If-Match strong comparison: "*" matches any
+// existing task and weak tags never match
+func ifMatchSatisfied(ifMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTaskCount returns the total number of tasks. With count caching, the
+// cached count is dropped whenever tasks are created or deleted, and
+// concurrent cache misses share a single COUNT(*).
 func (s *TaskService) GetTaskCount(ctx context.Context) (int, error) {
-	return s.repo.Count(ctx)
+	useCache := s.cache != nil && s.countCacheTTL > 0
+	if useCache {
+		if count, ok, err := s.cache.GetTaskCount(ctx); err == nil && ok {
+			return count, nil
+		}
+	}
+
+	result, err, _ := s.countGroup.Do("count", func() (interface{}, error) {
+		count, err := s.repo.Count(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if useCache {
+			_ = s.cache.SetTaskCount(ctx, count, s.countCacheTTL)
+		}
+		return count, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(int), nil
+}
+
+// invalidateTaskCount drops the cached task count once tasks were created or
+// deleted. A count computed concurrently may still be cached afterwards; it
+// expires within the count cache TTL.
+func (s *TaskService) invalidateTaskCount(ctx context.Context) {
+	if s.cache != nil && s.countCacheTTL > 0 {
+		_ = s.cache.InvalidateTaskCount(ctx)
+	}
+}
+
+// GetOldestOpenTaskAges returns, per open status, how long the oldest task
+// in that status has existed
+func (s *TaskService) GetOldestOpenTaskAges(ctx context.Context) (map[models.TaskStatus]time.Duration, error) {
+	oldest, err := s.repo.OldestOpenByStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task ages: %w", err)
+	}
+
+	now := time.Now()
+	ages := make(map[models.TaskStatus]time.Duration, len(oldest))
+	for status, createdAt := range oldest {
+		ages[status] = now.Sub(createdAt)
+	}
+	return ages, nil
+}
+
+// GetCompletionStats counts the tasks completed in the query window,
+// optionally for one assignee and broken down per assignee. Until defaults
+// to now. Completion time is approximated by updated_at.
+func (s *TaskService) GetCompletionStats(ctx context.Context, query *models.CompletionStatsQuery) (*models.CompletionStatsResponse, error) {
+	until := time.Now()
+	if query.Until != nil {
+		until = *query.Until
+	}
+	if !until.After(query.Since) {
+		return nil, fmt.Errorf("%w: until must be after since", repository.ErrInvalidInput)
+	}
+	if query.GroupBy != "" && query.GroupBy != "assignee" {
+		return nil, fmt.Errorf("%w: invalid group_by: %s", repository.ErrInvalidInput, query.GroupBy)
+	}
+
+	completions, err := s.repo.CompletionsByAssignee(ctx, query.Since, until, query.Assignee)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get completion stats: %w", err)
+	}
+
+	response := &models.CompletionStatsResponse{
+		Since:       query.Since,
+		Until:       until,
+		Approximate: true,
+	}
+	for assignee, count := range completions {
+		response.Completed += count
+		if query.GroupBy == "assignee" {
+			response.ByAssignee = append(response.ByAssignee, models.AssigneeCompletions{Assignee: assignee, Completed: count})
+		}
+	}
+	sort.Slice(response.ByAssignee, func(i, j int) bool {
+		a, b := response.ByAssignee[i], response.ByAssignee[j]
+		if a.Completed != b.Completed {
+			return a.Completed > b.Completed
+		}
+		return a.Assignee < b.Assignee
+	})
+
+	return response, nil
+}
+
+// PurgeCompletedTasks removes completed tasks last updated before the cutoff
+func (s *TaskService) PurgeCompletedTasks(ctx context.Context, before time.Time) (int, error) {
+	if before.IsZero() {
+		return 0, errors.New("cutoff is required")
+	}
+
+	var purged int
+	err := withRetry(ctx, func() error {
+		var err error
+		purged, err = s.repo.PurgeCompletedBefore(ctx, before)
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge tasks: %w", err)
+	}
+
+	// Invalidate caches
+	if s.cache != nil && purged > 0 {
+		_ = s.cache.InvalidateAllTasks(ctx)
+		_ = s.cache.InvalidateTaskList(ctx)
+		s.invalidateTaskCount(ctx)
+	}
+
+	return purged, nil
+}
+
+// ExpireStaleTasks moves tasks that have sat in status from without an
+// update for longer than maxAge to status to, and returns how many moved
+func (s *TaskService) ExpireStaleTasks(ctx context.Context, from, to models.TaskStatus, maxAge time.Duration) (int, error) {
+	if !models.IsValidStatus(from) || !models.IsValidStatus(to) || from == to {
+		return 0, fmt.Errorf("%w: invalid status expiry from %q to %q", repository.ErrInvalidInput, from, to)
+	}
+	if maxAge <= 0 {
+		return 0, fmt.Errorf("%w: status expiry age must be positive", repository.ErrInvalidInput)
+	}
+
+	now := time.Now()
+	var expired int
+	err := withRetry(ctx, func() error {
+		var err error
+		expired, err = s.repo.TransitionStale(ctx, from, to, now.Add(-maxAge), now)
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire stale tasks: %w", err)
+	}
+
+	// Invalidate caches
+	if s.cache != nil && expired > 0 {
+		_ = s.cache.InvalidateAllTasks(ctx)
+		_ = s.cache.InvalidateTaskList(ctx)
+	}
+
+	return expired, nil
+}
+
+// PreviewPurgeCompletedTasks returns the IDs of at most maxBatchIDs of the
+// tasks PurgeCompletedTasks would delete for the cutoff, oldest first, and
+// how many it would delete in total, without deleting anything
+func (s *TaskService) PreviewPurgeCompletedTasks(ctx context.Context, before time.Time) ([]string, int, error) {
+	if before.IsZero() {
+		return nil, 0, errors.New("cutoff is required")
+	}
+
+	ids, total, err := s.repo.ListCompletedBefore(ctx, before, maxBatchIDs)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to preview purge: %w", err)
+	}
+	return ids, total, nil
+}
+
+// WarmCache pre-loads the first page of the default task list and the tasks
+// on it into the cache. It is a no-op when no cache is configured.
+func (s *TaskService) WarmCache(ctx context.Context) (int, error) {
+	if s.cache == nil {
+		return 0, nil
+	}
+
+	response, err := s.ListTasks(ctx, &models.TaskFilter{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to warm cache: %w", err)
+	}
+
+	for i := range response.Tasks {
+		if err := s.cache.SetTask(ctx, &response.Tasks[i]); err != nil {
+			return i, fmt.Errorf("failed to warm cache: %w", err)
+		}
+	}
+
+	return len(response.Tasks), nil
+}
+
+// CheckDependencies pings the repository and, when configured, the cache,
+// timing each. The pings run concurrently, so a slow database does not use
+// up the cache's share of ctx's deadline. The database is critical; without
+// the cache requests are still served, only slower.
+func (s *TaskService) CheckDependencies(ctx context.Context) []health.Dependency {
+	probes := []func() health.Dependency{
+		func() health.Dependency { return health.Probe(ctx, "database", true, s.repo.Ping) },
+	}
+	if s.cache != nil {
+		probes = append(probes, func() health.Dependency { return health.Probe(ctx, "cache", false, s.cache.Ping) })
+	}
+
+	deps := make([]health.Dependency, len(probes))
+	var wg sync.WaitGroup
+	for i, probe := range probes {
+		wg.Go(func() {
+			deps[i] = probe()
+		})
+	}
+	wg.Wait()
+	return deps
+}
+
+// withRetry runs fn, retrying with exponential backoff while it fails with a
+// serialization failure or deadlock
+func withRetry(ctx context.Context, fn func() error) error {
+	delay := retryBaseDelay
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= maxRetryAttempts || !repository.IsRetryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
 }
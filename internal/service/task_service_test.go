@@ -2,13 +2,24 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/Ali-Gorgani/task-manager/internal/cache"
 	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/Ali-Gorgani/task-manager/internal/notify"
 	"github.com/Ali-Gorgani/task-manager/internal/repository"
+	"github.com/go-redis/redismock/v9"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockTaskRepository is a mock implementation of TaskRepository
@@ -29,6 +40,11 @@ func (m *MockTaskRepository) GetByID(ctx context.Context, id string) (*models.Ta
 	return args.Get(0).(*models.Task), args.Error(1)
 }
 
+func (m *MockTaskRepository) GetByIDs(ctx context.Context, ids []string) ([]models.Task, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
 func (m *MockTaskRepository) GetAll(ctx context.Context, filter *models.TaskFilter) ([]models.Task, int, error) {
 	args := m.Called(ctx, filter)
 	return args.Get(0).([]models.Task), args.Int(1), args.Error(2)
@@ -39,16 +55,136 @@ func (m *MockTaskRepository) Update(ctx context.Context, task *models.Task) erro
 	return args.Error(0)
 }
 
+func (m *MockTaskRepository) UpdateAssignee(ctx context.Context, id, assignee string, updatedAt time.Time) (*models.Task, string, error) {
+	args := m.Called(ctx, id, assignee, updatedAt)
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+	return args.Get(0).(*models.Task), args.String(1), args.Error(2)
+}
+
+func (m *MockTaskRepository) UpsertByExternalID(ctx context.Context, task *models.Task) (*models.Task, bool, error) {
+	args := m.Called(ctx, task)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).(*models.Task), args.Bool(1), args.Error(2)
+}
+
 func (m *MockTaskRepository) Delete(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
+func (m *MockTaskRepository) DeleteIfUnmodified(ctx context.Context, id string, updatedAt time.Time) error {
+	args := m.Called(ctx, id, updatedAt)
+	return args.Error(0)
+}
+
 func (m *MockTaskRepository) Count(ctx context.Context) (int, error) {
 	args := m.Called(ctx)
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockTaskRepository) CompletionsByAssignee(ctx context.Context, since, until time.Time, assignee *string) (map[string]int, error) {
+	args := m.Called(ctx, since, until, assignee)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *MockTaskRepository) ReassignAll(ctx context.Context, from, to string, status *models.TaskStatus, updatedAt time.Time) ([]models.Task, error) {
+	args := m.Called(ctx, from, to, status, updatedAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) TransitionStale(ctx context.Context, from, to models.TaskStatus, before, updatedAt time.Time) (int, error) {
+	args := m.Called(ctx, from, to, before, updatedAt)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetUpdatedAt(ctx context.Context, id string) (time.Time, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetRecentlyUpdated(ctx context.Context, limit int) ([]models.Task, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) CreateMany(ctx context.Context, tasks []*models.Task) error {
+	args := m.Called(ctx, tasks)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) GetBoard(ctx context.Context, filter *models.TaskFilter, pages map[models.TaskStatus]models.BoardPage) (map[models.TaskStatus]models.BoardColumn, error) {
+	args := m.Called(ctx, filter, pages)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[models.TaskStatus]models.BoardColumn), args.Error(1)
+}
+
+func (m *MockTaskRepository) Trend(ctx context.Context, interval string, from, to time.Time) ([]models.TrendBucket, error) {
+	args := m.Called(ctx, interval, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TrendBucket), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetIDs(ctx context.Context, filter *models.TaskFilter, limit int) ([]string, int, error) {
+	args := m.Called(ctx, filter, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]string), args.Int(1), args.Error(2)
+}
+
+func (m *MockTaskRepository) CreateIfNotExists(ctx context.Context, task *models.Task) (*models.Task, bool, error) {
+	args := m.Called(ctx, task)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).(*models.Task), args.Bool(1), args.Error(2)
+}
+
+func (m *MockTaskRepository) OldestOpenByStatus(ctx context.Context) (map[models.TaskStatus]time.Time, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[models.TaskStatus]time.Time), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetChangedSince(ctx context.Context, since time.Time, afterID string, limit int) ([]models.Task, error) {
+	args := m.Called(ctx, since, afterID, limit)
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) PurgeCompletedBefore(ctx context.Context, before time.Time) (int, error) {
+	args := m.Called(ctx, before)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) ListCompletedBefore(ctx context.Context, before time.Time, limit int) ([]string, int, error) {
+	args := m.Called(ctx, before, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]string), args.Int(1), args.Error(2)
+}
+
+func (m *MockTaskRepository) Ping(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
 func TestCreateTask_Success(t *testing.T) {
 	mockRepo := new(MockTaskRepository)
 	service := NewTaskService(mockRepo, nil)
@@ -82,6 +218,113 @@ func TestCreateTask_EmptyTitle(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, task)
 	assert.Contains(t, err.Error(), "title is required")
+	assert.ErrorIs(t, err, repository.ErrInvalidInput)
+}
+
+func TestCreateTask_ExternalReference(t *testing.T) {
+	t.Run("Stored trimmed", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+			return task.Source != nil && *task.Source == "jira" &&
+				task.ExternalID != nil && *task.ExternalID == "PROJ-1"
+		})).Return(nil)
+
+		task, err := service.CreateTask(context.Background(), &models.CreateTaskRequest{
+			Title: "Synced", Source: " jira ", ExternalID: "PROJ-1",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "jira", *task.Source)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Blank values stored as NULL", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+			return task.Source == nil && task.ExternalID == nil
+		})).Return(nil)
+
+		_, err := service.CreateTask(context.Background(), &models.CreateTaskRequest{Title: "Local", Source: "  "})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("External ID requires source", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		_, err := service.CreateTask(context.Background(), &models.CreateTaskRequest{Title: "Synced", ExternalID: "PROJ-1"})
+		assert.ErrorIs(t, err, repository.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpsertTaskByExternalID(t *testing.T) {
+	t.Run("Created", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		stored := models.NewTask("Synced", "", "", models.TaskStatusPending)
+		source, externalID := "github", "42"
+		stored.Source, stored.ExternalID = &source, &externalID
+		mockRepo.On("UpsertByExternalID", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+			return *task.Source == "github" && *task.ExternalID == "42"
+		})).Return(stored, true, nil)
+
+		task, created, err := service.UpsertTaskByExternalID(context.Background(), &models.CreateTaskRequest{
+			Title: "Synced", Source: "github", ExternalID: "42",
+		})
+		assert.NoError(t, err)
+		assert.True(t, created)
+		assert.Equal(t, "42", *task.ExternalID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Missing external ID", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		_, _, err := service.UpsertTaskByExternalID(context.Background(), &models.CreateTaskRequest{Title: "Synced", Source: "github"})
+		assert.ErrorIs(t, err, repository.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "UpsertByExternalID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCreateTaskIfNotExists(t *testing.T) {
+	stored := models.NewTask("Synced", "", "", models.TaskStatusPending)
+	source, externalID := "github", "42"
+	stored.Source, stored.ExternalID = &source, &externalID
+
+	for _, created := range []bool{true, false} {
+		t.Run(fmt.Sprintf("created=%t", created), func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			service := NewTaskService(mockRepo, nil)
+
+			mockRepo.On("CreateIfNotExists", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+				return *task.Source == "github" && *task.ExternalID == "42"
+			})).Return(stored, created, nil)
+
+			task, gotCreated, err := service.CreateTaskIfNotExists(context.Background(), &models.CreateTaskRequest{
+				Title: "Synced", Source: "github", ExternalID: "42",
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, created, gotCreated)
+			assert.Equal(t, stored.ID, task.ID)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("Missing external ID", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		_, _, err := service.CreateTaskIfNotExists(context.Background(), &models.CreateTaskRequest{Title: "Synced", Source: "github"})
+		assert.ErrorIs(t, err, repository.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "CreateIfNotExists", mock.Anything, mock.Anything)
+	})
 }
 
 func TestCreateTask_InvalidStatus(t *testing.T) {
@@ -97,6 +340,7 @@ func TestCreateTask_InvalidStatus(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, task)
 	assert.Contains(t, err.Error(), "invalid status")
+	assert.ErrorIs(t, err, repository.ErrInvalidInput)
 }
 
 func TestGetTask_Success(t *testing.T) {
@@ -169,6 +413,41 @@ func TestUpdateTask_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestValidateTask(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil, WithSanitization(HTMLStrip))
+
+	req := models.CreateTaskRequest{Title: "<b></b>\x00", Status: "pending"}
+	result := service.ValidateTask(req)
+	assert.False(t, result.Valid)
+	assert.Equal(t, []models.FieldError{{Field: "title", Message: "is required"}}, result.Errors)
+	assert.Equal(t, "<b></b>\x00", req.Title)
+
+	result = service.ValidateTask(models.CreateTaskRequest{Title: "<b>Docs</b>", Source: "jira", ExternalID: "PROJ-1"})
+	assert.True(t, result.Valid)
+	assert.Empty(t, result.Errors)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateTask_UpdatedAtNotBeforeCreatedAt(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil)
+
+	// Imported within the allowed clock skew, so created_at is ahead of now
+	existingTask := models.NewTask("Imported", "", "", models.TaskStatusPending)
+	existingTask.CreatedAt = time.Now().Add(time.Minute)
+	existingTask.UpdatedAt = existingTask.CreatedAt
+	newTitle := "Edited"
+
+	mockRepo.On("GetByID", mock.Anything, existingTask.ID).Return(existingTask, nil)
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*models.Task")).Return(nil)
+
+	task, err := service.UpdateTask(context.Background(), existingTask.ID, &models.UpdateTaskRequest{Title: &newTitle})
+	require.NoError(t, err)
+	assert.False(t, task.UpdatedAt.Before(task.CreatedAt))
+	mockRepo.AssertExpectations(t)
+}
+
 func TestUpdateTask_NotFound(t *testing.T) {
 	mockRepo := new(MockTaskRepository)
 	service := NewTaskService(mockRepo, nil)
@@ -294,6 +573,439 @@ func TestListTasks_MaxPageSize(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestListTasks_StrictPageSize(t *testing.T) {
+	t.Run("service option rejects oversized page", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil, WithStrictPageSize(true))
+
+		response, err := service.ListTasks(context.Background(), &models.TaskFilter{Page: 1, PageSize: 200})
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Contains(t, err.Error(), "maximum of 100")
+		mockRepo.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("filter flag rejects oversized page", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		response, err := service.ListTasks(context.Background(), &models.TaskFilter{Page: 1, PageSize: 101, StrictPageSize: true})
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		mockRepo.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("maximum page size is accepted", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil, WithStrictPageSize(true))
+
+		mockRepo.On("GetAll", mock.Anything, mock.MatchedBy(func(f *models.TaskFilter) bool {
+			return f.PageSize == 100
+		})).Return([]models.Task{}, 0, nil)
+
+		response, err := service.ListTasks(context.Background(), &models.TaskFilter{Page: 1, PageSize: 100})
+		assert.NoError(t, err)
+		assert.NotNil(t, response)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestListTasks_MaxOffset(t *testing.T) {
+	t.Run("rejects page beyond the maximum offset", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil, WithMaxOffset(1000))
+
+		response, err := service.ListTasks(context.Background(), &models.TaskFilter{Page: 102, PageSize: 10})
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Contains(t, err.Error(), "offset 1010")
+		assert.Contains(t, err.Error(), "/api/v1/tasks/changes")
+		mockRepo.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects page token beyond the maximum offset", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil, WithMaxOffset(1000))
+
+		filter := &models.TaskFilter{PageSize: 100}
+		require.NoError(t, service.normalizeFilter(filter, false))
+		token := encodePageToken(service.pageTokenKey, filter, 20)
+
+		response, err := service.ListTasks(context.Background(), &models.TaskFilter{PageToken: token})
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		mockRepo.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("last page within the limit is accepted", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil, WithMaxOffset(1000))
+
+		mockRepo.On("GetAll", mock.Anything, mock.Anything).Return([]models.Task{}, 0, nil)
+
+		response, err := service.ListTasks(context.Background(), &models.TaskFilter{Page: 101, PageSize: 10})
+		assert.NoError(t, err)
+		assert.NotNil(t, response)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestListTasks_FullListCaching(t *testing.T) {
+	tasks := []models.Task{
+		*models.NewTask("Task 1", "", "user1@example.com", models.TaskStatusPending),
+		*models.NewTask("Task 2", "", "user1@example.com", models.TaskStatusPending),
+		*models.NewTask("Task 3", "", "user1@example.com", models.TaskStatusPending),
+	}
+	fullKey := "tasks:list:sort:created_at:order:desc:full"
+	firstPage := mock.MatchedBy(func(f *models.TaskFilter) bool { return f.Page == 1 && f.PageSize == maxPageSize })
+
+	t.Run("loads the full list once and slices pages from it", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		db, redisMock := redismock.NewClientMock()
+		service := NewTaskService(mockRepo, cache.NewRedisCache(db), WithFullListCaching(100))
+
+		fullData, _ := json.Marshal(cache.TaskListEntry{Tasks: tasks, Total: 3})
+		redisMock.ExpectGet(fullKey).RedisNil()
+		mockRepo.On("GetAll", mock.Anything, firstPage).Return(tasks, 3, nil).Once()
+		redisMock.ExpectSet(fullKey, fullData, 5*time.Minute).SetVal("OK")
+
+		response, err := service.ListTasks(context.Background(), &models.TaskFilter{Page: 2, PageSize: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []models.Task{tasks[2]}, response.Tasks)
+		assert.Equal(t, 3, response.Total)
+		assert.Equal(t, 2, response.TotalPages)
+
+		// The next page comes from the cached list without touching the database
+		redisMock.ExpectGet(fullKey).SetVal(string(fullData))
+
+		response, err = service.ListTasks(context.Background(), &models.TaskFilter{Page: 1, PageSize: 2})
+		require.NoError(t, err)
+		require.Len(t, response.Tasks, 2)
+		assert.Equal(t, tasks[0].ID, response.Tasks[0].ID)
+		assert.Equal(t, tasks[1].ID, response.Tasks[1].ID)
+		assert.NotEmpty(t, response.NextPageToken)
+
+		assert.NoError(t, redisMock.ExpectationsWereMet())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("pages beyond the cached rows are queried", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		db, redisMock := redismock.NewClientMock()
+		service := NewTaskService(mockRepo, cache.NewRedisCache(db), WithFullListCaching(2))
+
+		fullData, _ := json.Marshal(cache.TaskListEntry{Tasks: tasks[:2], Total: 3})
+		pageData, _ := json.Marshal(cache.TaskListEntry{Tasks: tasks[2:], Total: 3})
+		pageKey := "tasks:list:sort:created_at:order:desc:page:2:size:2"
+
+		redisMock.ExpectGet(fullKey).SetVal(string(fullData))
+		redisMock.ExpectGet(pageKey).RedisNil()
+		mockRepo.On("GetAll", mock.Anything, mock.MatchedBy(func(f *models.TaskFilter) bool { return f.Page == 2 && f.PageSize == 2 })).
+			Return(tasks[2:], 3, nil).Once()
+		redisMock.ExpectSet(pageKey, pageData, 5*time.Minute).SetVal("OK")
+
+		response, err := service.ListTasks(context.Background(), &models.TaskFilter{Page: 2, PageSize: 2})
+		require.NoError(t, err)
+		assert.Equal(t, tasks[2:], response.Tasks)
+		assert.Equal(t, 3, response.Total)
+
+		assert.NoError(t, redisMock.ExpectationsWereMet())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("a cold page beyond the cap does not load the full list", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		db, redisMock := redismock.NewClientMock()
+		service := NewTaskService(mockRepo, cache.NewRedisCache(db), WithFullListCaching(2))
+
+		pageData, _ := json.Marshal(cache.TaskListEntry{Tasks: tasks[2:], Total: 3})
+		pageKey := "tasks:list:sort:created_at:order:desc:page:2:size:2"
+
+		redisMock.ExpectGet(fullKey).RedisNil()
+		redisMock.ExpectGet(pageKey).RedisNil()
+		mockRepo.On("GetAll", mock.Anything, mock.MatchedBy(func(f *models.TaskFilter) bool { return f.Page == 2 && f.PageSize == 2 })).
+			Return(tasks[2:], 3, nil).Once()
+		redisMock.ExpectSet(pageKey, pageData, 5*time.Minute).SetVal("OK")
+
+		response, err := service.ListTasks(context.Background(), &models.TaskFilter{Page: 2, PageSize: 2})
+		require.NoError(t, err)
+		assert.Equal(t, tasks[2:], response.Tasks)
+
+		assert.NoError(t, redisMock.ExpectationsWereMet())
+		mockRepo.AssertNotCalled(t, "GetAll", mock.Anything, firstPage)
+	})
+}
+
+func TestListTasks_AppliedFilters(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil)
+
+	mockRepo.On("GetAll", mock.Anything, mock.Anything).Return([]models.Task{}, 0, nil).Twice()
+
+	status := models.TaskStatus(" In-Progress ")
+	response, err := service.ListTasks(context.Background(), &models.TaskFilter{
+		Status:         &status,
+		Order:          "ascending",
+		PageSize:       500,
+		AppliedFilters: true,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, response.AppliedFilters)
+
+	applied := response.AppliedFilters
+	require.NotNil(t, applied.Status)
+	assert.Equal(t, models.TaskStatusInProgress, *applied.Status)
+	assert.Equal(t, models.DefaultSortField, applied.Sort)
+	assert.Equal(t, models.SortOrderAsc, applied.Order)
+	assert.Equal(t, 1, applied.Page)
+	assert.Equal(t, 100, applied.PageSize)
+
+	response, err = service.ListTasks(context.Background(), &models.TaskFilter{})
+	require.NoError(t, err)
+	assert.Nil(t, response.AppliedFilters)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListTasks_DefaultSort(t *testing.T) {
+	t.Run("applies the configured default", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil, WithDefaultSort("Updated_At", "ascending"))
+
+		mockRepo.On("GetAll", mock.Anything, mock.MatchedBy(func(f *models.TaskFilter) bool {
+			return f.Sort == "updated_at" && f.Order == models.SortOrderAsc
+		})).Return([]models.Task{}, 0, nil)
+
+		_, err := service.ListTasks(context.Background(), &models.TaskFilter{})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("request overrides the default", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil, WithDefaultSort("updated_at", "asc"))
+
+		mockRepo.On("GetAll", mock.Anything, mock.MatchedBy(func(f *models.TaskFilter) bool {
+			return f.Sort == "created_at" && f.Order == models.SortOrderDesc
+		})).Return([]models.Task{}, 0, nil)
+
+		_, err := service.ListTasks(context.Background(), &models.TaskFilter{Sort: "created_at", Order: "desc"})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown order falls back to the default", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil, WithDefaultSort("created_at", "asc"))
+
+		mockRepo.On("GetAll", mock.Anything, mock.MatchedBy(func(f *models.TaskFilter) bool {
+			return f.Order == models.SortOrderAsc
+		})).Return([]models.Task{}, 0, nil)
+
+		_, err := service.ListTasks(context.Background(), &models.TaskFilter{Order: "sideways"})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestValidateDefaultSort(t *testing.T) {
+	assert.NoError(t, ValidateDefaultSort("created_at", "desc"))
+	assert.NoError(t, ValidateDefaultSort("updated_at", "ASC"))
+	assert.ErrorIs(t, ValidateDefaultSort("description", "desc"), repository.ErrInvalidInput)
+	assert.ErrorIs(t, ValidateDefaultSort("created_at", "sideways"), repository.ErrInvalidInput)
+}
+
+func TestListTasks_TitlePrefix(t *testing.T) {
+	t.Run("trims the prefix", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		mockRepo.On("GetAll", mock.Anything, mock.MatchedBy(func(f *models.TaskFilter) bool {
+			return f.TitlePrefix != nil && *f.TitlePrefix == "Comp"
+		})).Return([]models.Task{}, 0, nil)
+
+		prefix := "  Comp "
+		_, err := service.ListTasks(context.Background(), &models.TaskFilter{TitlePrefix: &prefix})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects an empty prefix", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		prefix := " "
+		_, err := service.ListTasks(context.Background(), &models.TaskFilter{TitlePrefix: &prefix})
+		assert.EqualError(t, err, "title_prefix must not be empty")
+		mockRepo.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+	})
+}
+
+func TestListTasks_Stale(t *testing.T) {
+	t.Run("defaults stale_days", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		mockRepo.On("GetAll", mock.Anything, mock.MatchedBy(func(f *models.TaskFilter) bool {
+			return f.StaleDays == defaultStaleDays
+		})).Return([]models.Task{}, 0, nil)
+
+		_, err := service.ListTasks(context.Background(), &models.TaskFilter{Stale: true})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects invalid stale_days", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		_, err := service.ListTasks(context.Background(), &models.TaskFilter{Stale: true, StaleDays: -1})
+		assert.EqualError(t, err, "stale_days must be positive")
+
+		_, err = service.ListTasks(context.Background(), &models.TaskFilter{StaleDays: 7})
+		assert.EqualError(t, err, "stale_days requires stale=true")
+		mockRepo.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+	})
+}
+
+func TestListTaskIDs(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil)
+
+	mockRepo.On("GetIDs", mock.Anything, mock.MatchedBy(func(f *models.TaskFilter) bool {
+		return f.Sort == "created_at" && f.Order == models.SortOrderDesc
+	}), maxTaskIDs).Return([]string{"id-1"}, 12000, nil)
+
+	resp, err := service.ListTaskIDs(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id-1"}, resp.IDs)
+	assert.Equal(t, 12000, resp.Total)
+	mockRepo.AssertExpectations(t)
+
+	status := models.TaskStatus("bogus")
+	_, err = service.ListTaskIDs(context.Background(), &models.TaskFilter{Status: &status})
+	assert.Error(t, err)
+}
+
+func TestListTasks_Assignees(t *testing.T) {
+	t.Run("normalizes the list", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		mockRepo.On("GetAll", mock.Anything, mock.MatchedBy(func(f *models.TaskFilter) bool {
+			return assert.ObjectsAreEqual([]string{"alice@example.com", "bob@example.com"}, f.Assignees)
+		})).Return([]models.Task{}, 0, nil)
+
+		_, err := service.ListTasks(context.Background(), &models.TaskFilter{
+			Assignees: []string{" bob@example.com", "alice@example.com", "bob@example.com"},
+		})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("blank means unassigned", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		mockRepo.On("GetAll", mock.Anything, mock.MatchedBy(func(f *models.TaskFilter) bool {
+			return assert.ObjectsAreEqual([]string{""}, f.Assignees)
+		})).Return([]models.Task{}, 0, nil)
+
+		response, err := service.ListTasks(context.Background(), &models.TaskFilter{Assignees: []string{" "}, AppliedFilters: true})
+		assert.NoError(t, err)
+		require.NotNil(t, response.AppliedFilters.Assignee)
+		assert.Equal(t, "", *response.AppliedFilters.Assignee)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("applied filters keep the single assignee key", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		mockRepo.On("GetAll", mock.Anything, mock.Anything).Return([]models.Task{}, 0, nil)
+
+		response, err := service.ListTasks(context.Background(), &models.TaskFilter{
+			Assignees:      []string{"alice@example.com"},
+			AppliedFilters: true,
+		})
+		require.NoError(t, err)
+		data, err := json.Marshal(response.AppliedFilters)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"assignee":"alice@example.com"`)
+		assert.NotContains(t, string(data), `"assignees"`)
+
+		response, err = service.ListTasks(context.Background(), &models.TaskFilter{
+			Assignees:      []string{"bob@example.com", "alice@example.com"},
+			AppliedFilters: true,
+		})
+		require.NoError(t, err)
+		assert.Nil(t, response.AppliedFilters.Assignee)
+		assert.Equal(t, []string{"alice@example.com", "bob@example.com"}, response.AppliedFilters.Assignees)
+	})
+
+	t.Run("rejects too many", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		_, err := service.ListTasks(context.Background(), &models.TaskFilter{
+			Assignees: make([]string, maxAssigneeFilters+1),
+		})
+		assert.Error(t, err)
+		mockRepo.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetBoard(t *testing.T) {
+	t.Run("one page per status", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		columns := map[models.TaskStatus]models.BoardColumn{}
+		mockRepo.On("GetBoard", mock.Anything, mock.Anything, map[models.TaskStatus]models.BoardPage{
+			models.TaskStatusPending:    {Offset: 20, Limit: 20},
+			models.TaskStatusInProgress: {Limit: 20},
+			models.TaskStatusCompleted:  {Limit: 20},
+			models.TaskStatusCancelled:  {Limit: 20},
+		}).Return(columns, nil)
+
+		response, err := service.GetBoard(context.Background(), &models.BoardQuery{},
+			map[models.TaskStatus]int{models.TaskStatusPending: 20})
+		require.NoError(t, err)
+		assert.Equal(t, columns, response.Columns)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("status filter keeps one column", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		mockRepo.On("GetBoard", mock.Anything, mock.Anything, map[models.TaskStatus]models.BoardPage{
+			models.TaskStatusCompleted: {Limit: 100},
+		}).Return(map[models.TaskStatus]models.BoardColumn{}, nil)
+
+		status := models.TaskStatus("Completed")
+		_, err := service.GetBoard(context.Background(), &models.BoardQuery{
+			TaskFilter: models.TaskFilter{Status: &status},
+			Limit:      500,
+		}, nil)
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects bad offsets", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		_, err := service.GetBoard(context.Background(), &models.BoardQuery{},
+			map[models.TaskStatus]int{"archived": 10})
+		assert.ErrorIs(t, err, repository.ErrInvalidInput)
+
+		_, err = service.GetBoard(context.Background(), &models.BoardQuery{},
+			map[models.TaskStatus]int{models.TaskStatusPending: -1})
+		assert.ErrorIs(t, err, repository.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "GetBoard", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
 func TestListTasks_NilFilter(t *testing.T) {
 	mockRepo := new(MockTaskRepository)
 	service := NewTaskService(mockRepo, nil)
@@ -346,6 +1058,7 @@ func TestUpdateTask_InvalidStatus(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, task)
 	assert.Contains(t, err.Error(), "invalid status")
+	assert.ErrorIs(t, err, repository.ErrInvalidInput)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -449,3 +1162,729 @@ func TestListTasks_TotalPagesCalculation(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 }
+
+func TestPurgeCompletedTasks(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil)
+
+	cutoff := time.Now().Add(-30 * 24 * time.Hour)
+	mockRepo.On("PurgeCompletedBefore", mock.Anything, cutoff).Return(7, nil)
+
+	purged, err := service.PurgeCompletedTasks(context.Background(), cutoff)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, purged)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPurgeCompletedTasks_ZeroCutoff(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil)
+
+	purged, err := service.PurgeCompletedTasks(context.Background(), time.Time{})
+	assert.Error(t, err)
+	assert.Equal(t, 0, purged)
+	assert.Contains(t, err.Error(), "cutoff is required")
+}
+
+func TestExpireStaleTasks(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil)
+
+	maxAge := 30 * 24 * time.Hour
+	mockRepo.On("TransitionStale", mock.Anything, models.TaskStatusPending, models.TaskStatusCancelled,
+		mock.MatchedBy(func(before time.Time) bool {
+			return time.Since(before)-maxAge < time.Minute
+		}), mock.AnythingOfType("time.Time")).Return(3, nil)
+
+	expired, err := service.ExpireStaleTasks(context.Background(), models.TaskStatusPending, models.TaskStatusCancelled, maxAge)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, expired)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestExpireStaleTasks_InvalidSettings(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil)
+
+	_, err := service.ExpireStaleTasks(context.Background(), models.TaskStatusPending, models.TaskStatusPending, time.Hour)
+	assert.ErrorIs(t, err, repository.ErrInvalidInput)
+
+	_, err = service.ExpireStaleTasks(context.Background(), models.TaskStatusPending, "archived", time.Hour)
+	assert.ErrorIs(t, err, repository.ErrInvalidInput)
+
+	_, err = service.ExpireStaleTasks(context.Background(), models.TaskStatusPending, models.TaskStatusCancelled, 0)
+	assert.ErrorIs(t, err, repository.ErrInvalidInput)
+	mockRepo.AssertNotCalled(t, "TransitionStale", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestListChangesSince(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil)
+
+	since := time.Now().Add(-time.Hour)
+	task1 := models.NewTask("Task 1", "Desc 1", "user1@example.com", models.TaskStatusPending)
+	task2 := models.NewTask("Task 2", "Desc 2", "user2@example.com", models.TaskStatusPending)
+	task3 := models.NewTask("Task 3", "Desc 3", "user3@example.com", models.TaskStatusPending)
+	task2.UpdatedAt = task1.UpdatedAt.Add(time.Second)
+	task3.UpdatedAt = task2.UpdatedAt.Add(time.Second)
+
+	mockRepo.On("GetChangedSince", mock.Anything, since, "", 3).Return([]models.Task{*task1, *task2, *task3}, nil)
+
+	response, err := service.ListChangesSince(context.Background(), since, "", 2)
+	assert.NoError(t, err)
+	assert.Len(t, response.Tasks, 2)
+	assert.True(t, response.HasMore)
+	assert.Equal(t, since, response.Since)
+	assert.Equal(t, task2.UpdatedAt, response.NextSince)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListChangesSince_CursorResumesWithinSameTimestamp(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil)
+
+	since := time.Now().Add(-time.Hour)
+	stamp := time.Now().UTC().Truncate(time.Microsecond)
+	task1 := models.NewTask("Task 1", "Desc 1", "user1@example.com", models.TaskStatusPending)
+	task2 := models.NewTask("Task 2", "Desc 2", "user2@example.com", models.TaskStatusPending)
+	task3 := models.NewTask("Task 3", "Desc 3", "user3@example.com", models.TaskStatusPending)
+	task1.ID, task2.ID, task3.ID = "a", "b", "c"
+	task1.UpdatedAt, task2.UpdatedAt, task3.UpdatedAt = stamp, stamp, stamp
+
+	mockRepo.On("GetChangedSince", mock.Anything, since, "", 3).Return([]models.Task{*task1, *task2, *task3}, nil)
+	first, err := service.ListChangesSince(context.Background(), since, "", 2)
+	assert.NoError(t, err)
+	assert.True(t, first.HasMore)
+	assert.NotEmpty(t, first.NextCursor)
+
+	mockRepo.On("GetChangedSince", mock.Anything, mock.MatchedBy(func(t time.Time) bool {
+		return t.Equal(stamp)
+	}), "b", 3).Return([]models.Task{*task3}, nil)
+	second, err := service.ListChangesSince(context.Background(), time.Time{}, first.NextCursor, 2)
+	assert.NoError(t, err)
+	assert.Len(t, second.Tasks, 1)
+	assert.Equal(t, "c", second.Tasks[0].ID)
+	assert.False(t, second.HasMore)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListChangesSince_InvalidCursor(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil)
+
+	_, err := service.ListChangesSince(context.Background(), time.Time{}, "not-a-cursor!", 10)
+	assert.ErrorIs(t, err, repository.ErrInvalidInput)
+	mockRepo.AssertNotCalled(t, "GetChangedSince", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestListChangesSince_NoChanges(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil)
+
+	since := time.Now()
+	mockRepo.On("GetChangedSince", mock.Anything, since, "", 101).Return([]models.Task{}, nil)
+
+	response, err := service.ListChangesSince(context.Background(), since, "", 0)
+	assert.NoError(t, err)
+	assert.Empty(t, response.Tasks)
+	assert.False(t, response.HasMore)
+	assert.Equal(t, since, response.NextSince)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateTask_RetriesSerializationFailure(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil)
+
+	existingTask := models.NewTask("Old Title", "Old Desc", "old@example.com", models.TaskStatusPending)
+	newTitle := "New Title"
+
+	mockRepo.On("GetByID", mock.Anything, existingTask.ID).Return(existingTask, nil)
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*models.Task")).Return(&pq.Error{Code: "40001"}).Once()
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*models.Task")).Return(nil).Once()
+
+	task, err := service.UpdateTask(context.Background(), existingTask.ID, &models.UpdateTaskRequest{Title: &newTitle})
+	assert.NoError(t, err)
+	assert.Equal(t, newTitle, task.Title)
+	mockRepo.AssertNumberOfCalls(t, "Update", 2)
+}
+
+func TestDeleteTask_RetryExhausted(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil)
+
+	mockRepo.On("Delete", mock.Anything, "test-id").Return(&pq.Error{Code: "40P01"})
+
+	err := service.DeleteTask(context.Background(), "test-id")
+	assert.Error(t, err)
+	assert.True(t, repository.IsRetryable(err))
+	mockRepo.AssertNumberOfCalls(t, "Delete", maxRetryAttempts)
+}
+
+func TestCreateTask_NoRetryOnOtherErrors(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil)
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Task")).Return(&pq.Error{Code: "23505"})
+
+	task, err := service.CreateTask(context.Background(), &models.CreateTaskRequest{Title: "Test"})
+	assert.Error(t, err)
+	assert.Nil(t, task)
+	mockRepo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestCheckDependencies(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil)
+
+	mockRepo.On("Ping", mock.Anything).Return(nil)
+
+	deps := service.CheckDependencies(context.Background())
+	require.Len(t, deps, 1)
+	assert.Equal(t, "database", deps[0].Name)
+	assert.NoError(t, deps[0].Err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCheckDependencies_DatabaseDown(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	db, redisMock := redismock.NewClientMock()
+	service := NewTaskService(mockRepo, cache.NewRedisCache(db))
+
+	mockRepo.On("Ping", mock.Anything).WaitUntil(time.After(20 * time.Millisecond)).Return(errors.New("connection refused"))
+	redisMock.ExpectPing().SetVal("PONG")
+
+	deps := service.CheckDependencies(context.Background())
+	require.Len(t, deps, 2)
+	assert.Equal(t, "database", deps[0].Name)
+	assert.True(t, deps[0].Critical)
+	assert.EqualError(t, deps[0].Err, "connection refused")
+	assert.Equal(t, "cache", deps[1].Name)
+	assert.NoError(t, deps[1].Err)
+	assert.Less(t, deps[1].Latency, deps[0].Latency)
+	assert.NoError(t, redisMock.ExpectationsWereMet())
+}
+
+func TestListRecentTasks(t *testing.T) {
+	t.Run("clamps the limit", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		mockRepo.On("GetRecentlyUpdated", mock.Anything, 10).Return([]models.Task{}, nil).Once()
+		mockRepo.On("GetRecentlyUpdated", mock.Anything, 100).Return([]models.Task{}, nil).Once()
+
+		response, err := service.ListRecentTasks(context.Background(), 0)
+		require.NoError(t, err)
+		assert.NotNil(t, response.Tasks)
+
+		_, err = service.ListRecentTasks(context.Background(), 500)
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("caches briefly", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		redisClient, redisMock := redismock.NewClientMock()
+		service := NewTaskService(mockRepo, cache.NewRedisCache(redisClient))
+
+		tasks := []models.Task{*models.NewTask("Task 1", "", "user1@example.com", models.TaskStatusPending)}
+		data, _ := json.Marshal(tasks)
+
+		redisMock.ExpectGet("tasks:list:recent:5").RedisNil()
+		mockRepo.On("GetRecentlyUpdated", mock.Anything, 5).Return(tasks, nil).Once()
+		redisMock.ExpectSet("tasks:list:recent:5", data, 30*time.Second).SetVal("OK")
+
+		response, err := service.ListRecentTasks(context.Background(), 5)
+		require.NoError(t, err)
+		assert.Len(t, response.Tasks, 1)
+
+		redisMock.ExpectGet("tasks:list:recent:5").SetVal(string(data))
+
+		response, err = service.ListRecentTasks(context.Background(), 5)
+		require.NoError(t, err)
+		assert.Equal(t, tasks[0].ID, response.Tasks[0].ID)
+
+		assert.NoError(t, redisMock.ExpectationsWereMet())
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestWarmCache(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	redisClient, redisMock := redismock.NewClientMock()
+	service := NewTaskService(mockRepo, cache.NewRedisCache(redisClient))
+
+	task := models.NewTask("Task 1", "Desc 1", "user1@example.com", models.TaskStatusPending)
+	tasks := []models.Task{*task}
+	listData, _ := json.Marshal(cache.TaskListEntry{Tasks: tasks, Total: 1})
+	taskData, _ := json.Marshal(task)
+
+	redisMock.ExpectGet("tasks:list:sort:created_at:order:desc:page:1:size:10").RedisNil()
+	mockRepo.On("GetAll", mock.Anything, mock.AnythingOfType("*models.TaskFilter")).Return(tasks, 1, nil)
+	redisMock.ExpectSet("tasks:list:sort:created_at:order:desc:page:1:size:10", listData, 5*time.Minute).SetVal("OK")
+	redisMock.ExpectSet("task:"+task.ID, taskData, 5*time.Minute).SetVal("OK")
+
+	warmed, err := service.WarmCache(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, warmed)
+	assert.NoError(t, redisMock.ExpectationsWereMet())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWarmCache_NoCache(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil)
+
+	warmed, err := service.WarmCache(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, warmed)
+	mockRepo.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+}
+
+func TestListTasks_NormalizesStatusFilter(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil)
+
+	status := models.TaskStatus(" In_Progress ")
+	filter := &models.TaskFilter{Status: &status, Page: 1, PageSize: 10}
+
+	mockRepo.On("GetAll", mock.Anything, mock.MatchedBy(func(f *models.TaskFilter) bool {
+		return *f.Status == models.TaskStatusInProgress
+	})).Return([]models.Task{}, 0, nil)
+
+	_, err := service.ListTasks(context.Background(), filter)
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// recordingNotifier captures assignee change events for assertions. Bulk
+// events are recorded only when bulk is set.
+type recordingNotifier struct {
+	events chan models.AssigneeChangedEvent
+	bulk   chan models.AssigneesReassignedEvent
+}
+
+func (n *recordingNotifier) NotifyAssigneeChanged(ctx context.Context, event models.AssigneeChangedEvent) error {
+	n.events <- event
+	return nil
+}
+
+func (n *recordingNotifier) NotifyAssigneesReassigned(ctx context.Context, event models.AssigneesReassignedEvent) error {
+	if n.bulk != nil {
+		n.bulk <- event
+	}
+	return nil
+}
+
+func TestUpdateTask_NotifiesAssigneeChange(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	notifier := &recordingNotifier{events: make(chan models.AssigneeChangedEvent, 1)}
+	service := NewTaskService(mockRepo, nil, WithAssigneeNotifier(notifier))
+
+	existingTask := models.NewTask("Task", "Desc", "old@example.com", models.TaskStatusPending)
+	newAssignee := "new@example.com"
+
+	mockRepo.On("GetByID", mock.Anything, existingTask.ID).Return(existingTask, nil)
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*models.Task")).Return(nil)
+
+	_, err := service.UpdateTask(context.Background(), existingTask.ID, &models.UpdateTaskRequest{Assignee: &newAssignee})
+	assert.NoError(t, err)
+
+	select {
+	case event := <-notifier.events:
+		assert.Equal(t, existingTask.ID, event.TaskID)
+		assert.Equal(t, "old@example.com", event.OldAssignee)
+		assert.Equal(t, "new@example.com", event.NewAssignee)
+	case <-time.After(time.Second):
+		t.Fatal("expected assignee change notification")
+	}
+}
+
+func TestWaitForNotifications(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	// Unbuffered, so the notification blocks until it is received
+	notifier := &recordingNotifier{events: make(chan models.AssigneeChangedEvent)}
+	service := NewTaskService(mockRepo, nil, WithAssigneeNotifier(notifier))
+
+	existingTask := models.NewTask("Task", "Desc", "old@example.com", models.TaskStatusPending)
+	newAssignee := "new@example.com"
+
+	mockRepo.On("GetByID", mock.Anything, existingTask.ID).Return(existingTask, nil)
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*models.Task")).Return(nil)
+
+	_, err := service.UpdateTask(context.Background(), existingTask.ID, &models.UpdateTaskRequest{Assignee: &newAssignee})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, service.WaitForNotifications(ctx), context.DeadlineExceeded)
+
+	<-notifier.events
+	assert.NoError(t, service.WaitForNotifications(context.Background()))
+}
+
+func TestUpdateTask_NoNotificationWhenAssigneeUnchanged(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	notifier := &recordingNotifier{events: make(chan models.AssigneeChangedEvent, 1)}
+	service := NewTaskService(mockRepo, nil, WithAssigneeNotifier(notifier))
+
+	existingTask := models.NewTask("Task", "Desc", "same@example.com", models.TaskStatusPending)
+	sameAssignee := "same@example.com"
+	newTitle := "Renamed"
+
+	mockRepo.On("GetByID", mock.Anything, existingTask.ID).Return(existingTask, nil)
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*models.Task")).Return(nil)
+
+	req := &models.UpdateTaskRequest{Title: &newTitle, Assignee: &sameAssignee}
+	_, err := service.UpdateTask(context.Background(), existingTask.ID, req)
+	assert.NoError(t, err)
+
+	select {
+	case <-notifier.events:
+		t.Fatal("unexpected assignee change notification")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestGetTasksByIDs(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil)
+
+	task1 := models.NewTask("Task 1", "Desc 1", "user1@example.com", models.TaskStatusPending)
+	task2 := models.NewTask("Task 2", "Desc 2", "user2@example.com", models.TaskStatusPending)
+	ids := []string{task2.ID, "missing", task1.ID}
+
+	mockRepo.On("GetByIDs", mock.Anything, ids).Return([]models.Task{*task1, *task2}, nil)
+
+	tasks, missing, err := service.GetTasksByIDs(context.Background(), ids)
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 2)
+	assert.Equal(t, task2.ID, tasks[0].ID)
+	assert.Equal(t, task1.ID, tasks[1].ID)
+	assert.Equal(t, []string{"missing"}, missing)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetTasksByIDs_TooMany(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil)
+
+	ids := make([]string, maxBatchIDs+1)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("id-%d", i)
+	}
+
+	tasks, _, err := service.GetTasksByIDs(context.Background(), ids)
+	assert.Error(t, err)
+	assert.Nil(t, tasks)
+}
+
+func TestGetOldestOpenTaskAges(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil)
+
+	oldest := map[models.TaskStatus]time.Time{
+		models.TaskStatusPending: time.Now().Add(-time.Hour),
+	}
+	mockRepo.On("OldestOpenByStatus", mock.Anything).Return(oldest, nil)
+
+	ages, err := service.GetOldestOpenTaskAges(context.Background())
+	assert.NoError(t, err)
+	assert.InDelta(t, time.Hour.Seconds(), ages[models.TaskStatusPending].Seconds(), 5)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAssignTask(t *testing.T) {
+	t.Run("Notifies on change", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		notifier := &recordingNotifier{events: make(chan models.AssigneeChangedEvent, 1)}
+		service := NewTaskService(mockRepo, nil, WithAssigneeNotifier(notifier))
+
+		task := models.NewTask("Task", "Desc", "new@example.com", models.TaskStatusPending)
+		mockRepo.On("UpdateAssignee", mock.Anything, task.ID, "new@example.com", mock.AnythingOfType("time.Time")).
+			Return(task, "old@example.com", nil)
+
+		result, err := service.AssignTask(context.Background(), task.ID, "new@example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, "new@example.com", result.Assignee)
+
+		select {
+		case event := <-notifier.events:
+			assert.Equal(t, "old@example.com", event.OldAssignee)
+			assert.Equal(t, "new@example.com", event.NewAssignee)
+		case <-time.After(time.Second):
+			t.Fatal("expected assignee change notification")
+		}
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		mockRepo.On("UpdateAssignee", mock.Anything, "missing", "new@example.com", mock.AnythingOfType("time.Time")).
+			Return(nil, "", repository.ErrTaskNotFound)
+
+		result, err := service.AssignTask(context.Background(), "missing", "new@example.com")
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, repository.ErrTaskNotFound)
+	})
+}
+
+func TestReassignTasks(t *testing.T) {
+	t.Run("Notifies once in bulk", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		notifier := &recordingNotifier{bulk: make(chan models.AssigneesReassignedEvent, 1)}
+		service := NewTaskService(mockRepo, nil, WithAssigneeNotifier(notifier))
+
+		first := models.NewTask("First", "", "new@example.com", models.TaskStatusPending)
+		second := models.NewTask("Second", "", "new@example.com", models.TaskStatusPending)
+		mockRepo.On("ReassignAll", mock.Anything, "old@example.com", "new@example.com", mock.MatchedBy(func(s *models.TaskStatus) bool {
+			return s != nil && *s == models.TaskStatusPending
+		}), mock.AnythingOfType("time.Time")).Return([]models.Task{*first, *second}, nil)
+
+		status := models.TaskStatus("Pending")
+		reassigned, err := service.ReassignTasks(context.Background(), &models.ReassignTasksRequest{
+			From:   "old@example.com",
+			To:     "new@example.com",
+			Status: &status,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, reassigned)
+
+		select {
+		case event := <-notifier.bulk:
+			assert.Equal(t, "task.assignees_reassigned", event.Event)
+			assert.Equal(t, "old@example.com", event.OldAssignee)
+			assert.Equal(t, "new@example.com", event.NewAssignee)
+			require.NotNil(t, event.Status)
+			assert.Equal(t, models.TaskStatusPending, *event.Status)
+			assert.Equal(t, []string{first.ID, second.ID}, event.TaskIDs)
+		case <-time.After(time.Second):
+			t.Fatal("expected bulk reassignment notification")
+		}
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("More tasks than the webhook queue holds", func(t *testing.T) {
+		var received []models.AssigneesReassignedEvent
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var event models.AssigneesReassignedEvent
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+			received = append(received, event)
+		}))
+		defer server.Close()
+
+		dispatcher := notify.NewDispatcher(notify.NewWebhookNotifier(server.URL, time.Second), notify.WithQueueSize(2))
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil, WithAssigneeNotifier(dispatcher))
+
+		moved := make([]models.Task, 5)
+		for i := range moved {
+			moved[i] = *models.NewTask(fmt.Sprintf("Task %d", i), "", "new@example.com", models.TaskStatusPending)
+		}
+		mockRepo.On("ReassignAll", mock.Anything, "old@example.com", "new@example.com", (*models.TaskStatus)(nil), mock.AnythingOfType("time.Time")).
+			Return(moved, nil)
+
+		reassigned, err := service.ReassignTasks(context.Background(), &models.ReassignTasksRequest{
+			From: "old@example.com",
+			To:   "new@example.com",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 5, reassigned)
+		require.NoError(t, service.WaitForNotifications(context.Background()))
+
+		delivered, dropped := dispatcher.Flush(context.Background())
+		assert.Equal(t, 1, delivered)
+		assert.Zero(t, dropped)
+		require.Len(t, received, 1)
+		assert.Len(t, received[0].TaskIDs, 5)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Rejects invalid requests", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		_, err := service.ReassignTasks(context.Background(), &models.ReassignTasksRequest{From: "a@example.com", To: "A@example.com"})
+		assert.ErrorIs(t, err, repository.ErrInvalidInput)
+
+		status := models.TaskStatus("bogus")
+		_, err = service.ReassignTasks(context.Background(), &models.ReassignTasksRequest{From: "a@example.com", To: "b@example.com", Status: &status})
+		assert.ErrorIs(t, err, repository.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "ReassignAll", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestListTasks_NormalizesSortOrder(t *testing.T) {
+	tests := []struct {
+		raw      models.SortOrder
+		expected models.SortOrder
+	}{
+		{"ASC", models.SortOrderAsc},
+		{"descending", models.SortOrderDesc},
+		{"bogus", models.SortOrderDesc},
+		{"", models.SortOrderDesc},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.raw), func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			service := NewTaskService(mockRepo, nil)
+
+			mockRepo.On("GetAll", mock.Anything, mock.MatchedBy(func(f *models.TaskFilter) bool {
+				return f.Order == tt.expected
+			})).Return([]models.Task{}, 0, nil)
+
+			_, err := service.ListTasks(context.Background(), &models.TaskFilter{Order: tt.raw})
+			assert.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateTask_Sanitization(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil, WithSanitization(HTMLStrip))
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Task")).Return(nil)
+
+	req := &models.CreateTaskRequest{
+		Title:       "<b>Ship\x00 it</b>",
+		Description: "<i>soon</i>",
+	}
+
+	task, err := service.CreateTask(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, "Ship it", task.Title)
+	assert.Equal(t, "soon", task.Description)
+}
+
+func TestCreateTask_SanitizedTitleEmpty(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := NewTaskService(mockRepo, nil, WithSanitization(HTMLStrip))
+
+	_, err := service.CreateTask(context.Background(), &models.CreateTaskRequest{Title: "<br>"})
+	assert.Error(t, err)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestListTasks_SortField(t *testing.T) {
+	t.Run("Defaults to created_at", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		mockRepo.On("GetAll", mock.Anything, mock.MatchedBy(func(f *models.TaskFilter) bool {
+			return f.Sort == "created_at"
+		})).Return([]models.Task{}, 0, nil)
+
+		_, err := service.ListTasks(context.Background(), &models.TaskFilter{})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Rejects unsortable field", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		_, err := service.ListTasks(context.Background(), &models.TaskFilter{Sort: "description"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid sort field")
+		mockRepo.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+	})
+}
+
+func TestListTasks_EmptyListMarshalsAsArray(t *testing.T) {
+	t.Run("Cache miss", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		mockRepo.On("GetAll", mock.Anything, mock.AnythingOfType("*models.TaskFilter")).Return([]models.Task(nil), 0, nil)
+
+		response, err := service.ListTasks(context.Background(), &models.TaskFilter{})
+		assert.NoError(t, err)
+
+		body, err := json.Marshal(response)
+		assert.NoError(t, err)
+		assert.Contains(t, string(body), `"tasks":[]`)
+	})
+
+	t.Run("Cache hit", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		db, redisMock := redismock.NewClientMock()
+		service := NewTaskService(mockRepo, cache.NewRedisCache(db))
+
+		redisMock.ExpectGet("tasks:list:sort:created_at:order:desc:page:1:size:10").SetVal(`{"tasks":null,"total":0}`)
+
+		response, err := service.ListTasks(context.Background(), &models.TaskFilter{})
+		assert.NoError(t, err)
+
+		body, err := json.Marshal(response)
+		assert.NoError(t, err)
+		assert.Contains(t, string(body), `"tasks":[]`)
+		mockRepo.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetTaskCount_Cached(t *testing.T) {
+	t.Run("Cache hit skips the database", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		db, redisMock := redismock.NewClientMock()
+		service := NewTaskService(mockRepo, cache.NewRedisCache(db), WithCountCacheTTL(10*time.Second))
+
+		redisMock.ExpectGet("tasks:count").SetVal("7")
+
+		count, err := service.GetTaskCount(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 7, count)
+		mockRepo.AssertNotCalled(t, "Count", mock.Anything)
+	})
+
+	t.Run("Cache miss stores the count", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		db, redisMock := redismock.NewClientMock()
+		service := NewTaskService(mockRepo, cache.NewRedisCache(db), WithCountCacheTTL(10*time.Second))
+
+		redisMock.ExpectGet("tasks:count").RedisNil()
+		mockRepo.On("Count", mock.Anything).Return(12, nil)
+		redisMock.ExpectSet("tasks:count", 12, 10*time.Second).SetVal("OK")
+
+		count, err := service.GetTaskCount(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 12, count)
+		assert.NoError(t, redisMock.ExpectationsWereMet())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Delete drops the cached count", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		db, redisMock := redismock.NewClientMock()
+		service := NewTaskService(mockRepo, cache.NewRedisCache(db), WithCountCacheTTL(10*time.Second))
+
+		mockRepo.On("Delete", mock.Anything, "task-1").Return(nil)
+		redisMock.ExpectDel("task:task-1").SetVal(1)
+		redisMock.ExpectScan(0, "tasks:list*", 0).SetVal([]string{}, 0)
+		redisMock.ExpectDel("tasks:count").SetVal(1)
+
+		require.NoError(t, service.DeleteTask(context.Background(), "task-1"))
+		assert.NoError(t, redisMock.ExpectationsWereMet())
+	})
+
+	t.Run("Concurrent misses share one count", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		mockRepo.On("Count", mock.Anything).WaitUntil(time.After(50*time.Millisecond)).Return(3, nil)
+
+		var wg sync.WaitGroup
+		for range 5 {
+			wg.Go(func() {
+				count, err := service.GetTaskCount(context.Background())
+				assert.NoError(t, err)
+				assert.Equal(t, 3, count)
+			})
+		}
+		wg.Wait()
+		mockRepo.AssertNumberOfCalls(t, "Count", 1)
+	})
+}
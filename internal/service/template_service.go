@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/Ali-Gorgani/task-manager/internal/repository"
+)
+
+// Column limits of the task_templates table, in characters
+const (
+	maxTemplateNameLength     = 100
+	maxTemplateTitleLength    = 255
+	maxTemplateAssigneeLength = 255
+)
+
+// placeholderPattern matches {{name}} placeholders in template text
+var placeholderPattern = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+// TemplateService handles business logic for task templates
+type TemplateService struct {
+	repo  repository.TemplateRepository
+	tasks *TaskService
+}
+
+// NewTemplateService creates a new template service. Tasks instantiated from
+// templates are created through tasks, so they get the same validation,
+// sanitization and cache invalidation as any other new task.
+func NewTemplateService(repo repository.TemplateRepository, tasks *TaskService) *TemplateService {
+	return &TemplateService{repo: repo, tasks: tasks}
+}
+
+// CreateTemplate creates a new template
+func (s *TemplateService) CreateTemplate(ctx context.Context, req *models.CreateTemplateRequest) (*models.TaskTemplate, error) {
+	template := models.NewTaskTemplate(strings.TrimSpace(req.Name), req.Title, req.Description, req.Assignee, req.Status)
+	if err := validateTemplate(template); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, template); err != nil {
+		return nil, fmt.Errorf("failed to create template: %w", err)
+	}
+	return template, nil
+}
+
+// GetTemplate retrieves a template by ID
+func (s *TemplateService) GetTemplate(ctx context.Context, id string) (*models.TaskTemplate, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListTemplates retrieves every template ordered by name
+func (s *TemplateService) ListTemplates(ctx context.Context) (*models.TemplateListResponse, error) {
+	templates, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	if templates == nil {
+		templates = []models.TaskTemplate{}
+	}
+	return &models.TemplateListResponse{Templates: templates}, nil
+}
+
+// UpdateTemplate updates the fields of a template that req sets
+func (s *TemplateService) UpdateTemplate(ctx context.Context, id string, req *models.UpdateTemplateRequest) (*models.TaskTemplate, error) {
+	template, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		template.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Title != nil {
+		template.Title = *req.Title
+	}
+	if req.Description != nil {
+		template.Description = *req.Description
+	}
+	if req.Status != nil {
+		template.Status = *req.Status
+	}
+	if req.Assignee != nil {
+		template.Assignee = *req.Assignee
+	}
+	if err := validateTemplate(template); err != nil {
+		return nil, err
+	}
+
+	template.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, template); err != nil {
+		return nil, fmt.Errorf("failed to update template: %w", err)
+	}
+	return template, nil
+}
+
+// DeleteTemplate deletes a template. Tasks created from it are kept.
+func (s *TemplateService) DeleteTemplate(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// InstantiateTemplate creates a task from a template. Placeholders in the
+// template's title and description are filled from req.Variables, with
+// {{date}} defaulting to today's UTC date; the other fields of req replace
+// the template's values verbatim.
+func (s *TemplateService) InstantiateTemplate(ctx context.Context, id string, req *models.InstantiateTemplateRequest) (*models.Task, error) {
+	template, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := map[string]string{"date": time.Now().UTC().Format(time.DateOnly)}
+	for name, value := range req.Variables {
+		variables[name] = value
+	}
+
+	missing := map[string]bool{}
+	var title, description string
+	if req.Title != nil {
+		title = *req.Title
+	} else {
+		title = fillPlaceholders(template.Title, variables, missing)
+	}
+	if req.Description != nil {
+		description = *req.Description
+	} else {
+		description = fillPlaceholders(template.Description, variables, missing)
+	}
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("%w: no value for template variables: %s", repository.ErrInvalidInput, strings.Join(names, ", "))
+	}
+
+	status := template.Status
+	if req.Status != nil {
+		status = *req.Status
+	}
+	if status != "" && !models.IsValidStatus(status) {
+		return nil, fmt.Errorf("%w: invalid status", repository.ErrInvalidInput)
+	}
+	assignee := template.Assignee
+	if req.Assignee != nil {
+		assignee = *req.Assignee
+	}
+
+	return s.tasks.CreateTask(ctx, &models.CreateTaskRequest{
+		Title:       title,
+		Description: description,
+		Status:      status,
+		Assignee:    assignee,
+	})
+}
+
+// validateTemplate checks the fields every template must have
+func validateTemplate(template *models.TaskTemplate) error {
+	if template.Name == "" {
+		return fmt.Errorf("%w: name is required", repository.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(template.Name) > maxTemplateNameLength {
+		return fmt.Errorf("%w: name must be at most %d characters", repository.ErrInvalidInput, maxTemplateNameLength)
+	}
+	if strings.TrimSpace(template.Title) == "" {
+		return fmt.Errorf("%w: title is required", repository.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(template.Title) > maxTemplateTitleLength {
+		return fmt.Errorf("%w: title must be at most %d characters", repository.ErrInvalidInput, maxTemplateTitleLength)
+	}
+	if utf8.RuneCountInString(template.Assignee) > maxTemplateAssigneeLength {
+		return fmt.Errorf("%w: assignee must be at most %d characters", repository.ErrInvalidInput, maxTemplateAssigneeLength)
+	}
+	if template.Status != "" && !models.IsValidStatus(template.Status) {
+		return fmt.Errorf("%w: invalid status", repository.ErrInvalidInput)
+	}
+	return nil
+}
+
+// fillPlaceholders replaces every {{name}} in text with its variable and
+// records the names that have no value in missing
+func fillPlaceholders(text string, variables map[string]string, missing map[string]bool) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(match string) string {
+		name := placeholderPattern.FindStringSubmatch(match)[1]
+		value, ok := variables[name]
+		if !ok {
+			missing[name] = true
+			return match
+		}
+		return value
+	})
+}
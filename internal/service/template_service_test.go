@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/Ali-Gorgani/task-manager/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockTemplateRepository is a mock implementation of TemplateRepository
+type MockTemplateRepository struct {
+	mock.Mock
+}
+
+func (m *MockTemplateRepository) Create(ctx context.Context, template *models.TaskTemplate) error {
+	args := m.Called(ctx, template)
+	return args.Error(0)
+}
+
+func (m *MockTemplateRepository) GetByID(ctx context.Context, id string) (*models.TaskTemplate, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTemplateRepository) List(ctx context.Context) ([]models.TaskTemplate, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTemplateRepository) Update(ctx context.Context, template *models.TaskTemplate) error {
+	args := m.Called(ctx, template)
+	return args.Error(0)
+}
+
+func (m *MockTemplateRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestCreateTemplate(t *testing.T) {
+	mockRepo := new(MockTemplateRepository)
+	service := NewTemplateService(mockRepo, NewTaskService(new(MockTaskRepository), nil))
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.TaskTemplate")).Return(nil)
+
+	template, err := service.CreateTemplate(context.Background(), &models.CreateTemplateRequest{
+		Name:  "  weekly-report ",
+		Title: "Weekly report {{date}}",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "weekly-report", template.Name)
+	assert.NotEmpty(t, template.ID)
+
+	_, err = service.CreateTemplate(context.Background(), &models.CreateTemplateRequest{
+		Name:   "review",
+		Title:  "Review",
+		Status: "someday",
+	})
+	assert.ErrorIs(t, err, repository.ErrInvalidInput)
+
+	_, err = service.CreateTemplate(context.Background(), &models.CreateTemplateRequest{
+		Name:  strings.Repeat("n", 101),
+		Title: "Review",
+	})
+	assert.ErrorIs(t, err, repository.ErrInvalidInput)
+
+	_, err = service.CreateTemplate(context.Background(), &models.CreateTemplateRequest{
+		Name:  "review",
+		Title: strings.Repeat("ü", 256),
+	})
+	assert.ErrorIs(t, err, repository.ErrInvalidInput)
+	mockRepo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestUpdateTemplate(t *testing.T) {
+	mockRepo := new(MockTemplateRepository)
+	service := NewTemplateService(mockRepo, NewTaskService(new(MockTaskRepository), nil))
+
+	template := models.NewTaskTemplate("weekly-report", "Weekly report", "", "", "")
+	mockRepo.On("GetByID", mock.Anything, template.ID).Return(template, nil)
+	mockRepo.On("Update", mock.Anything, template).Return(nil)
+
+	assignee := "jane.doe@example.com"
+	updated, err := service.UpdateTemplate(context.Background(), template.ID, &models.UpdateTemplateRequest{Assignee: &assignee})
+	require.NoError(t, err)
+	assert.Equal(t, assignee, updated.Assignee)
+	assert.Equal(t, "Weekly report", updated.Title)
+
+	blank := " "
+	_, err = service.UpdateTemplate(context.Background(), template.ID, &models.UpdateTemplateRequest{Name: &blank})
+	assert.ErrorIs(t, err, repository.ErrInvalidInput)
+	mockRepo.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func TestInstantiateTemplate(t *testing.T) {
+	template := models.NewTaskTemplate("weekly-report", "Weekly report {{date}} for {{ team }}",
+		"Summarize {{team}}", "john.doe@example.com", models.TaskStatusPending)
+
+	t.Run("fills placeholders and applies overrides", func(t *testing.T) {
+		mockRepo := new(MockTemplateRepository)
+		taskRepo := new(MockTaskRepository)
+		service := NewTemplateService(mockRepo, NewTaskService(taskRepo, nil))
+
+		mockRepo.On("GetByID", mock.Anything, template.ID).Return(template, nil)
+		taskRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Task")).Return(nil)
+
+		status := models.TaskStatusInProgress
+		task, err := service.InstantiateTemplate(context.Background(), template.ID, &models.InstantiateTemplateRequest{
+			Variables: map[string]string{"team": "platform"},
+			Status:    &status,
+		})
+		require.NoError(t, err)
+
+		today := time.Now().UTC().Format(time.DateOnly)
+		assert.Equal(t, "Weekly report "+today+" for platform", task.Title)
+		assert.Equal(t, "Summarize platform", task.Description)
+		assert.Equal(t, models.TaskStatusInProgress, task.Status)
+		assert.Equal(t, "john.doe@example.com", task.Assignee)
+		taskRepo.AssertExpectations(t)
+	})
+
+	t.Run("missing variables are rejected", func(t *testing.T) {
+		mockRepo := new(MockTemplateRepository)
+		taskRepo := new(MockTaskRepository)
+		service := NewTemplateService(mockRepo, NewTaskService(taskRepo, nil))
+
+		mockRepo.On("GetByID", mock.Anything, template.ID).Return(template, nil)
+
+		_, err := service.InstantiateTemplate(context.Background(), template.ID, &models.InstantiateTemplateRequest{})
+		assert.ErrorIs(t, err, repository.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "team")
+		taskRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("overridden fields need no variables", func(t *testing.T) {
+		mockRepo := new(MockTemplateRepository)
+		taskRepo := new(MockTaskRepository)
+		service := NewTemplateService(mockRepo, NewTaskService(taskRepo, nil))
+
+		mockRepo.On("GetByID", mock.Anything, template.ID).Return(template, nil)
+		taskRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Task")).Return(nil)
+
+		title, description := "Ad hoc report", ""
+		task, err := service.InstantiateTemplate(context.Background(), template.ID, &models.InstantiateTemplateRequest{
+			Title:       &title,
+			Description: &description,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Ad hoc report", task.Title)
+	})
+
+	t.Run("unknown template", func(t *testing.T) {
+		mockRepo := new(MockTemplateRepository)
+		service := NewTemplateService(mockRepo, NewTaskService(new(MockTaskRepository), nil))
+
+		mockRepo.On("GetByID", mock.Anything, "missing").Return(nil, repository.ErrTemplateNotFound)
+
+		_, err := service.InstantiateTemplate(context.Background(), "missing", &models.InstantiateTemplateRequest{})
+		assert.ErrorIs(t, err, repository.ErrTemplateNotFound)
+	})
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/Ali-Gorgani/task-manager/internal/repository"
+)
+
+const (
+	defaultTrendInterval = "day"
+	// maxTrendBuckets bounds the range of a trend request: a year of days,
+	// about seven years of weeks or thirty years of months
+	maxTrendBuckets = 366
+)
+
+// trendIntervals maps each supported interval to the step between buckets
+var trendIntervals = map[string]func(time.Time) time.Time{
+	"day":   func(t time.Time) time.Time { return t.AddDate(0, 0, 1) },
+	"week":  func(t time.Time) time.Time { return t.AddDate(0, 0, 7) },
+	"month": func(t time.Time) time.Time { return t.AddDate(0, 1, 0) },
+}
+
+// GetTrend counts the tasks created and completed per interval between
+// from and to, for burn-down charts. To defaults to now and the interval to
+// day. Every bucket in the range is returned, empty ones included; the first
+// and last may cover only part of their interval. Completion time is
+// approximated by updated_at.
+func (s *TaskService) GetTrend(ctx context.Context, query *models.TrendQuery) (*models.TrendResponse, error) {
+	interval := query.Interval
+	if interval == "" {
+		interval = defaultTrendInterval
+	}
+	next, ok := trendIntervals[interval]
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid interval: %s (must be day, week or month)", repository.ErrInvalidInput, interval)
+	}
+
+	from := query.From.UTC()
+	to := time.Now().UTC()
+	if query.To != nil {
+		to = query.To.UTC()
+	}
+	if !to.After(from) {
+		return nil, fmt.Errorf("%w: to must be after from", repository.ErrInvalidInput)
+	}
+
+	var starts []time.Time
+	for start := truncateToInterval(from, interval); start.Before(to); start = next(start) {
+		if len(starts) == maxTrendBuckets {
+			return nil, fmt.Errorf("%w: range spans more than %d %s buckets", repository.ErrInvalidInput, maxTrendBuckets, interval)
+		}
+		starts = append(starts, start)
+	}
+
+	counts, err := s.repo.Trend(ctx, interval, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trend: %w", err)
+	}
+	byStart := make(map[time.Time]models.TrendBucket, len(counts))
+	for _, bucket := range counts {
+		byStart[bucket.Start] = bucket
+	}
+
+	response := &models.TrendResponse{
+		From:        from,
+		To:          to,
+		Interval:    interval,
+		Buckets:     make([]models.TrendBucket, 0, len(starts)),
+		Approximate: true,
+	}
+	for _, start := range starts {
+		bucket := byStart[start]
+		bucket.Start = start
+		response.Buckets = append(response.Buckets, bucket)
+	}
+
+	return response, nil
+}
+
+// truncateToInterval returns the UTC start of the interval containing t,
+// matching PostgreSQL's date_trunc: midnight, ISO week Monday or the first
+// of the month
+func truncateToInterval(t time.Time, interval string) time.Time {
+	t = t.UTC()
+	switch interval {
+	case "month":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case "week":
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+	"github.com/Ali-Gorgani/task-manager/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTrend(t *testing.T) {
+	from := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 11, 4, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Fills empty days", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		mockRepo.On("Trend", mock.Anything, "day", from, to).Return([]models.TrendBucket{
+			{Start: from, Created: 4, Completed: 1},
+			{Start: from.AddDate(0, 0, 2), Completed: 2},
+		}, nil)
+
+		resp, err := service.GetTrend(context.Background(), &models.TrendQuery{From: from, To: &to})
+		require.NoError(t, err)
+		assert.Equal(t, "day", resp.Interval)
+		assert.True(t, resp.Approximate)
+		assert.Equal(t, []models.TrendBucket{
+			{Start: from, Created: 4, Completed: 1},
+			{Start: from.AddDate(0, 0, 1)},
+			{Start: from.AddDate(0, 0, 2), Completed: 2},
+		}, resp.Buckets)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Weeks start on Monday", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		// 2025-11-01 is a Saturday
+		until := time.Date(2025, 11, 11, 0, 0, 0, 0, time.UTC)
+		mockRepo.On("Trend", mock.Anything, "week", from, until).Return([]models.TrendBucket(nil), nil)
+
+		resp, err := service.GetTrend(context.Background(), &models.TrendQuery{From: from, To: &until, Interval: "week"})
+		require.NoError(t, err)
+		require.Len(t, resp.Buckets, 3)
+		assert.Equal(t, time.Date(2025, 10, 27, 0, 0, 0, 0, time.UTC), resp.Buckets[0].Start)
+		assert.Equal(t, time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC), resp.Buckets[2].Start)
+	})
+
+	t.Run("Invalid query", func(t *testing.T) {
+		mockRepo := new(MockTaskRepository)
+		service := NewTaskService(mockRepo, nil)
+
+		tooFar := from.AddDate(2, 0, 0)
+		for name, query := range map[string]*models.TrendQuery{
+			"interval": {From: from, To: &to, Interval: "hour"},
+			"reversed": {From: to, To: &from},
+			"range":    {From: from, To: &tooFar},
+		} {
+			_, err := service.GetTrend(context.Background(), query)
+			assert.ErrorIs(t, err, repository.ErrInvalidInput, name)
+		}
+		mockRepo.AssertNotCalled(t, "Trend", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestTruncateToInterval(t *testing.T) {
+	ts := time.Date(2025, 11, 6, 15, 4, 5, 0, time.FixedZone("CET", 3600))
+
+	assert.Equal(t, time.Date(2025, 11, 6, 0, 0, 0, 0, time.UTC), truncateToInterval(ts, "day"))
+	assert.Equal(t, time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC), truncateToInterval(ts, "week"))
+	assert.Equal(t, time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC), truncateToInterval(ts, "month"))
+}
@@ -0,0 +1,286 @@
+// Package client provides a typed Go client for the Task Manager API.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+)
+
+// API types shared with the server
+type (
+	Task              = models.Task
+	TaskStatus        = models.TaskStatus
+	TaskFilter        = models.TaskFilter
+	TaskListResponse  = models.TaskListResponse
+	CreateTaskRequest = models.CreateTaskRequest
+	UpdateTaskRequest = models.UpdateTaskRequest
+)
+
+// Task statuses
+const (
+	TaskStatusPending    = models.TaskStatusPending
+	TaskStatusInProgress = models.TaskStatusInProgress
+	TaskStatusCompleted  = models.TaskStatusCompleted
+	TaskStatusCancelled  = models.TaskStatusCancelled
+)
+
+var (
+	ErrBadRequest   = errors.New("bad request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrServer       = errors.New("server error")
+)
+
+// APIError describes a non-2xx response from the API
+type APIError struct {
+	StatusCode int
+	Message    string
+	kind       error
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("task manager API: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// Unwrap allows errors.Is(err, client.ErrNotFound) and friends
+func (e *APIError) Unwrap() error {
+	return e.kind
+}
+
+// Client calls the Task Manager REST API
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	authToken  string
+}
+
+// Option configures optional Client behaviour
+type Option func(*Client)
+
+// WithHTTPClient overrides the default HTTP client
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithAuthToken sends the token as a bearer Authorization header
+func WithAuthToken(token string) Option {
+	return func(c *Client) {
+		c.authToken = token
+	}
+}
+
+// New creates a client for the API served at baseURL, e.g. http://localhost:3000
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// CreateTask creates a new task
+func (c *Client) CreateTask(ctx context.Context, req *CreateTaskRequest) (*Task, error) {
+	var task Task
+	if err := c.do(ctx, http.MethodPost, "/api/v1/tasks", nil, req, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// GetTask retrieves a task by ID
+func (c *Client) GetTask(ctx context.Context, id string) (*Task, error) {
+	var task Task
+	if err := c.do(ctx, http.MethodGet, "/api/v1/tasks/"+url.PathEscape(id), nil, nil, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// ListTasks retrieves a page of tasks. A nil filter uses server defaults.
+// Every LIST_RESPONSE_FORMAT the server may use is understood.
+func (c *Client) ListTasks(ctx context.Context, filter *TaskFilter) (*TaskListResponse, error) {
+	data, header, err := c.send(ctx, http.MethodGet, "/api/v1/tasks", filterQuery(filter), nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := decodeTaskList(data, header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return response, nil
+}
+
+// UpdateTask applies a partial update to a task
+func (c *Client) UpdateTask(ctx context.Context, id string, req *UpdateTaskRequest) (*Task, error) {
+	var task Task
+	if err := c.do(ctx, http.MethodPut, "/api/v1/tasks/"+url.PathEscape(id), nil, req, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// DeleteTask deletes a task by ID
+func (c *Client) DeleteTask(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/api/v1/tasks/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// filterQuery encodes a TaskFilter as list query parameters
+func filterQuery(filter *TaskFilter) url.Values {
+	query := url.Values{}
+	if filter == nil {
+		return query
+	}
+	if filter.Status != nil {
+		query.Set("status", string(*filter.Status))
+	}
+	for _, assignee := range filter.Assignees {
+		query.Add("assignee", assignee)
+	}
+	if filter.Source != nil {
+		query.Set("source", *filter.Source)
+	}
+	if filter.ExternalID != nil {
+		query.Set("external_id", *filter.ExternalID)
+	}
+	if filter.HasDescription != nil {
+		query.Set("has_description", strconv.FormatBool(*filter.HasDescription))
+	}
+	if filter.Search != "" {
+		query.Set("search", filter.Search)
+	}
+	if filter.TitlePrefix != nil {
+		query.Set("title_prefix", *filter.TitlePrefix)
+	}
+	if filter.Stale {
+		query.Set("stale", "true")
+	}
+	if filter.StaleDays > 0 {
+		query.Set("stale_days", strconv.Itoa(filter.StaleDays))
+	}
+	if filter.Sort != "" {
+		query.Set("sort", filter.Sort)
+	}
+	if filter.Order != "" {
+		query.Set("order", string(filter.Order))
+	}
+	if filter.Page > 0 {
+		query.Set("page", strconv.Itoa(filter.Page))
+	}
+	if filter.PageSize > 0 {
+		query.Set("page_size", strconv.Itoa(filter.PageSize))
+	}
+	if filter.PageToken != "" {
+		query.Set("page_token", filter.PageToken)
+	}
+	return query
+}
+
+// do sends a request and decodes a successful JSON response into out
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	data, _, err := c.send(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	if err := decodeJSON(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// send sends a request and returns the body and headers of a successful
+// response
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, body interface{}) ([]byte, http.Header, error) {
+	endpoint := c.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.authToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, nil, newAPIError(resp)
+	}
+
+	if resp.StatusCode == http.StatusNoContent {
+		return nil, resp.Header, nil
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return data, resp.Header, nil
+}
+
+// newAPIError maps an error response to an *APIError
+func newAPIError(resp *http.Response) error {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+
+	var payload struct {
+		Error string `json:"error"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if json.Unmarshal(data, &payload) == nil && payload.Error != "" {
+		apiErr.Message = payload.Error
+	} else {
+		apiErr.Message = strings.TrimSpace(string(data))
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		apiErr.kind = ErrNotFound
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		apiErr.kind = ErrUnauthorized
+	case resp.StatusCode == http.StatusConflict || resp.StatusCode == http.StatusPreconditionFailed:
+		apiErr.kind = ErrConflict
+	case resp.StatusCode >= 500:
+		apiErr.kind = ErrServer
+	default:
+		apiErr.kind = ErrBadRequest
+	}
+	return apiErr
+}
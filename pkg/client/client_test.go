@@ -0,0 +1,196 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateTask(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/tasks", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var req CreateTaskRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(Task{ID: "task-1", Title: req.Title, Status: TaskStatusPending})
+	}))
+	defer server.Close()
+
+	c := New(server.URL+"/", WithAuthToken("secret"))
+	task, err := c.CreateTask(context.Background(), &CreateTaskRequest{Title: "Write docs"})
+	require.NoError(t, err)
+	assert.Equal(t, "task-1", task.ID)
+	assert.Equal(t, "Write docs", task.Title)
+}
+
+func TestClient_GetTask_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/tasks/missing", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"task not found","id":"missing"}`))
+	}))
+	defer server.Close()
+
+	task, err := New(server.URL).GetTask(context.Background(), "missing")
+	assert.Nil(t, task)
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "task not found", apiErr.Message)
+}
+
+func TestClient_ListTasks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "pending", r.URL.Query().Get("status"))
+		assert.Equal(t, "2", r.URL.Query().Get("page"))
+		assert.Equal(t, "", r.URL.Query().Get("assignee"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(TaskListResponse{
+			Tasks: []Task{{ID: "task-1"}},
+			Total: 11, Page: 2, PageSize: 10, TotalPages: 2,
+		})
+	}))
+	defer server.Close()
+
+	status := TaskStatusPending
+	response, err := New(server.URL).ListTasks(context.Background(), &TaskFilter{Status: &status, Page: 2})
+	require.NoError(t, err)
+	assert.Len(t, response.Tasks, 1)
+	assert.Equal(t, 11, response.Total)
+}
+
+func TestClient_ListTasks_Formats(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		body    string
+	}{
+		{
+			name: "Envelope",
+			body: `{"data":[{"id":"task-1","external_id":"PROJ-1","created_at":"2025-11-01T10:00:00Z"}],
+				"meta":{"total":11,"page":2,"page_size":10,"total_pages":2,"next_page_token":"tok"}}`,
+		},
+		{
+			name: "Links",
+			headers: map[string]string{
+				"X-Total-Count": "11", "X-Page": "2", "X-Page-Size": "10", "X-Total-Pages": "2", "X-Next-Page-Token": "tok",
+			},
+			body: `[{"id":"task-1","external_id":"PROJ-1","created_at":"2025-11-01T10:00:00Z"}]`,
+		},
+		{
+			name: "Camel case",
+			body: `{"tasks":[{"id":"task-1","externalId":"PROJ-1","createdAt":"2025-11-01T10:00:00Z"}],
+				"total":11,"page":2,"pageSize":10,"totalPages":2,"nextPageToken":"tok"}`,
+		},
+		{
+			name: "Camel case envelope",
+			body: `{"data":[{"id":"task-1","externalId":"PROJ-1","createdAt":"2025-11-01T10:00:00Z"}],
+				"meta":{"total":11,"page":2,"pageSize":10,"totalPages":2,"nextPageToken":"tok"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for name, value := range tt.headers {
+					w.Header().Set(name, value)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			response, err := New(server.URL).ListTasks(context.Background(), nil)
+			require.NoError(t, err)
+			require.Len(t, response.Tasks, 1)
+			assert.Equal(t, "task-1", response.Tasks[0].ID)
+			require.NotNil(t, response.Tasks[0].ExternalID)
+			assert.Equal(t, "PROJ-1", *response.Tasks[0].ExternalID)
+			assert.Equal(t, 2025, response.Tasks[0].CreatedAt.Year())
+			assert.Equal(t, 11, response.Total)
+			assert.Equal(t, 2, response.Page)
+			assert.Equal(t, 10, response.PageSize)
+			assert.Equal(t, 2, response.TotalPages)
+			assert.Equal(t, "tok", response.NextPageToken)
+		})
+	}
+}
+
+func TestClient_GetTask_CamelCase(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"task-1","title":"Docs","externalId":"PROJ-1","updatedAt":"2025-11-01T12:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	task, err := New(server.URL).GetTask(context.Background(), "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Docs", task.Title)
+	require.NotNil(t, task.ExternalID)
+	assert.Equal(t, "PROJ-1", *task.ExternalID)
+	assert.Equal(t, 12, task.UpdatedAt.Hour())
+}
+
+func TestClient_UpdateTask(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/v1/tasks/task-1", r.URL.Path)
+
+		var req UpdateTaskRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Task{ID: "task-1", Title: *req.Title})
+	}))
+	defer server.Close()
+
+	title := "Renamed"
+	task, err := New(server.URL).UpdateTask(context.Background(), "task-1", &UpdateTaskRequest{Title: &title})
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", task.Title)
+}
+
+func TestClient_DeleteTask(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr error
+	}{
+		{"No content", http.StatusNoContent, nil},
+		{"Server error", http.StatusInternalServerError, ErrServer},
+		{"Bad request", http.StatusBadRequest, ErrBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodDelete, r.Method)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := New(server.URL).DeleteTask(context.Background(), "task-1")
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, tt.wantErr))
+		})
+	}
+}
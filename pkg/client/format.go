@@ -0,0 +1,121 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Ali-Gorgani/task-manager/internal/models"
+)
+
+// decodeJSON decodes a response body into out. Keys are converted to
+// snake_case first, so responses of a server running with
+// RESPONSE_FIELD_CASE=camel decode into the same types.
+func decodeJSON(data []byte, out interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// Keep numbers exact through the round trip
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return err
+	}
+
+	normalized, err := json.Marshal(snakeCaseKeys(value))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(normalized, out)
+}
+
+// snakeCaseKeys rewrites the object keys in value to snake_case. The API
+// types have no map fields, so every object key is a field name.
+func snakeCaseKeys(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		rewritten := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			rewritten[camelToSnake(key)] = snakeCaseKeys(item)
+		}
+		return rewritten
+	case []interface{}:
+		for i, item := range v {
+			v[i] = snakeCaseKeys(item)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// camelToSnake converts a camelCase name to snake_case, leaving snake_case
+// names unchanged
+func camelToSnake(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// decodeTaskList decodes a list response in any LIST_RESPONSE_FORMAT: the
+// flat object, the {data, meta} envelope, or the bare tasks array of the
+// links format, whose pagination is read from the response headers
+func decodeTaskList(data []byte, header http.Header) (*TaskListResponse, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		response := &TaskListResponse{}
+		if err := decodeJSON(trimmed, &response.Tasks); err != nil {
+			return nil, err
+		}
+		response.Total = headerInt(header, "X-Total-Count")
+		response.Page = headerInt(header, "X-Page")
+		response.PageSize = headerInt(header, "X-Page-Size")
+		response.TotalPages = headerInt(header, "X-Total-Pages")
+		response.NextPageToken = header.Get("X-Next-Page-Token")
+		return response, nil
+	}
+
+	var shape struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(trimmed, &shape); err != nil {
+		return nil, err
+	}
+	if shape.Data == nil {
+		response := &TaskListResponse{}
+		if err := decodeJSON(trimmed, response); err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+
+	var envelope models.TaskListEnvelope
+	if err := decodeJSON(trimmed, &envelope); err != nil {
+		return nil, err
+	}
+	return &TaskListResponse{
+		Tasks:          envelope.Data,
+		Total:          envelope.Meta.Total,
+		Page:           envelope.Meta.Page,
+		PageSize:       envelope.Meta.PageSize,
+		TotalPages:     envelope.Meta.TotalPages,
+		NextPageToken:  envelope.Meta.NextPageToken,
+		AppliedFilters: envelope.Meta.AppliedFilters,
+	}, nil
+}
+
+// headerInt parses an integer response header, yielding zero when it is
+// missing or malformed
+func headerInt(header http.Header, name string) int {
+	value, _ := strconv.Atoi(header.Get(name))
+	return value
+}